@@ -0,0 +1,511 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	bvColumnNum       = 5
+	bvDateFormat      = "2006-01-02"
+	bvUpdateBatchSize = 1000
+)
+
+type blameViewHandler func(*BlameView, Action) error
+
+// BlameView displays line by line blame information for a file
+type BlameView struct {
+	channels       *Channels
+	repoData       RepoData
+	path           string
+	oid            *Oid
+	followRenames  bool
+	blameLines     []*BlameLine
+	blameLineCh    <-chan *BlameLine
+	loading        bool
+	viewPos        ViewPos
+	viewDimension  ViewDimension
+	tableFormatter *TableFormatter
+	handlers       map[ActionType]blameViewHandler
+	active         bool
+	viewSearch     *ViewSearch
+	lock           sync.Mutex
+}
+
+// NewBlameView creates a new instance of the blame view
+func NewBlameView(repoData RepoData, channels *Channels) *BlameView {
+	blameView := &BlameView{
+		repoData:       repoData,
+		channels:       channels,
+		viewPos:        NewViewPosition(),
+		tableFormatter: NewTableFormatter(bvColumnNum),
+		handlers: map[ActionType]blameViewHandler{
+			ActionPrevLine:            moveUpBlameLine,
+			ActionNextLine:            moveDownBlameLine,
+			ActionPrevPage:            moveUpBlamePage,
+			ActionNextPage:            moveDownBlamePage,
+			ActionPrevHalfPage:        moveUpBlameHalfPage,
+			ActionNextHalfPage:        moveDownBlameHalfPage,
+			ActionScrollRight:         scrollBlameViewRight,
+			ActionScrollLeft:          scrollBlameViewLeft,
+			ActionFirstLine:           moveToFirstBlameLine,
+			ActionLastLine:            moveToLastBlameLine,
+			ActionCenterView:          centerBlameView,
+			ActionOpenFileInEditor:    openBlameFileInEditor,
+			ActionToggleFollowRenames: toggleFollowRenames,
+		},
+	}
+
+	blameView.viewSearch = NewViewSearch(blameView, channels)
+
+	return blameView
+}
+
+// Initialise does nothing
+func (blameView *BlameView) Initialise() (err error) {
+	log.Info("Initialising BlameView")
+	return
+}
+
+// LoadBlame starts loading blame information for the file at the provided path as of the provided commit
+// Lines are displayed as they are received so the UI remains responsive when blaming large files
+func (blameView *BlameView) LoadBlame(path string, oid *Oid) (err error) {
+	log.Debugf("BlameView loading blame for file %v at commit %v", path, oid)
+
+	blameView.lock.Lock()
+	followRenames := blameView.followRenames
+	blameView.lock.Unlock()
+
+	blameLineCh, err := blameView.repoData.Blame(path, oid, followRenames)
+	if err != nil {
+		return
+	}
+
+	blameView.lock.Lock()
+	blameView.path = path
+	blameView.oid = oid
+	blameView.blameLines = nil
+	blameView.blameLineCh = blameLineCh
+	blameView.loading = true
+	blameView.viewPos = NewViewPosition()
+	blameView.lock.Unlock()
+
+	go blameView.receiveBlameLines(blameLineCh)
+
+	return
+}
+
+// receiveBlameLines adds lines received on the provided channel to the view
+// Lines are discarded if the blame has since been reloaded
+func (blameView *BlameView) receiveBlameLines(blameLineCh <-chan *BlameLine) {
+	var blameLines []*BlameLine
+
+	addBlameLines := func() {
+		blameView.lock.Lock()
+		defer blameView.lock.Unlock()
+
+		if blameView.blameLineCh == blameLineCh {
+			blameView.blameLines = append(blameView.blameLines, blameLines...)
+		}
+
+		blameLines = blameLines[:0]
+	}
+
+	for blameLine := range blameLineCh {
+		blameLines = append(blameLines, blameLine)
+
+		if len(blameLines) >= bvUpdateBatchSize {
+			addBlameLines()
+			blameView.channels.UpdateDisplay()
+		}
+	}
+
+	addBlameLines()
+
+	blameView.lock.Lock()
+	if blameView.blameLineCh != blameLineCh {
+		blameView.lock.Unlock()
+		return
+	}
+
+	blameView.loading = false
+	lineNum := len(blameView.blameLines)
+	path := blameView.path
+	blameView.lock.Unlock()
+
+	log.Debugf("BlameView loaded %v lines for file %v", lineNum, path)
+	blameView.channels.UpdateDisplay()
+}
+
+// Render generates and writes the blame view to the provided window
+func (blameView *BlameView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering BlameView")
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	blameView.viewDimension = win.ViewDimensions()
+
+	lineNum := uint(len(blameView.blameLines))
+
+	if lineNum == 0 {
+		return blameView.renderEmptyView(win)
+	}
+
+	rows := blameView.pageRows()
+	viewPos := blameView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	tableFormatter := blameView.tableFormatter
+	tableFormatter.Resize(rows)
+	tableFormatter.Clear()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		if err = blameView.renderBlameLine(tableFormatter, rowIndex, blameView.blameLines[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, blameView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	title := fmt.Sprintf("Blame for %v at %v", blameView.path, blameView.oid.ShortID())
+	if blameView.followRenames {
+		title += " (following renames)"
+	}
+
+	if err = win.SetTitle(CmpBlameviewTitle, "%v", title); err != nil {
+		return
+	}
+
+	footer := fmt.Sprintf("Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum)
+	if blameView.loading {
+		footer += " (loading)"
+	}
+
+	if err = win.SetFooter(CmpBlameviewFooter, "%v", footer); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := blameView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (blameView *BlameView) renderEmptyView(win RenderWindow) (err error) {
+	message := "No blame to display"
+	if blameView.loading {
+		message = fmt.Sprintf("Loading blame for %v", blameView.path)
+	}
+
+	if err = win.SetRow(2, 1, CmpNone, "   %v", message); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return
+}
+
+func (blameView *BlameView) renderBlameLine(tableFormatter *TableFormatter, rowIndex uint, blameLine *BlameLine) (err error) {
+	colIndex := uint(0)
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpBlameviewShortOid, "%v", blameLine.oid.ShortID()); err != nil {
+		return
+	}
+
+	if blameLine.origPath != "" && blameLine.origPath != blameView.path {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpBlameviewRenamedFrom, " (%v)", blameLine.origPath); err != nil {
+			return
+		}
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpBlameviewAuthor, "%v", blameLine.author.Name); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpBlameviewDate, "%v", blameLine.author.When.Format(bvDateFormat)); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpBlameviewLineNumber, "%v", blameLine.lineNumber); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpBlameviewLine, "%v", blameLine.line); err != nil {
+		return
+	}
+
+	return
+}
+
+// RenderHelpBar shows key bindings custom to the blame view
+func (blameView *BlameView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(blameView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionOpenFileInEditor, message: "Edit"},
+		{action: ActionToggleFollowRenames, message: "Follow Renames"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the blame view is the active view or not
+func (blameView *BlameView) OnActiveChange(active bool) {
+	log.Debugf("BlameView active: %v", active)
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	blameView.active = active
+}
+
+// ViewID returns the blame views ID
+func (blameView *BlameView) ViewID() ViewID {
+	return ViewBlame
+}
+
+// HandleEvent does nothing
+func (blameView *BlameView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// ViewPos returns the current view position
+func (blameView *BlameView) ViewPos() ViewPos {
+	return blameView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (blameView *BlameView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	viewPos := blameView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Blamed file has changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleAction checks if the blame view supports the provided action and executes it if so
+func (blameView *BlameView) HandleAction(action Action) (err error) {
+	log.Debugf("BlameView handling action %v", action)
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	if handler, ok := blameView.handlers[action.ActionType]; ok {
+		err = handler(blameView, action)
+	} else {
+		_, err = blameView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the blame view at the specified line index
+func (blameView *BlameView) Line(lineIndex uint) (line string) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	lineNum := blameView.lineNumber()
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	blameLine := blameView.blameLines[lineIndex]
+	line = fmt.Sprintf("%v %v %v %v %v", blameLine.oid.ShortID(), blameLine.author.Name,
+		blameLine.author.When.Format(bvDateFormat), blameLine.lineNumber, blameLine.line)
+
+	return
+}
+
+// LineNumber returns the number of lines the blame view currently has
+func (blameView *BlameView) LineNumber() (lineNumber uint) {
+	blameView.lock.Lock()
+	defer blameView.lock.Unlock()
+
+	return blameView.lineNumber()
+}
+
+func (blameView *BlameView) lineNumber() uint {
+	return uint(len(blameView.blameLines))
+}
+
+// pageRows returns the number of blame lines visible in the view
+// excluding the rows used by the border
+func (blameView *BlameView) pageRows() uint {
+	if blameView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return blameView.viewDimension.rows - 2
+}
+
+func moveDownBlameLine(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MoveLinesDown(ActionCount(action), blameView.lineNumber()) {
+		log.Debugf("Moving down %v lines in blame view", ActionCount(action))
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpBlameLine(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v lines in blame view", ActionCount(action))
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownBlamePage(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MovePageDown(blameView.pageRows(), blameView.lineNumber()) {
+		log.Debugf("Moving down one page in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpBlamePage(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MovePageUp(blameView.pageRows()) {
+		log.Debugf("Moving up one page in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownBlameHalfPage(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MovePageDown(blameView.pageRows()/2, blameView.lineNumber()) {
+		log.Debugf("Moving down half a page in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpBlameHalfPage(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MovePageUp(blameView.pageRows() / 2) {
+		log.Debugf("Moving up half a page in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollBlameViewRight(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+	viewPos.MovePageRight(blameView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	blameView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollBlameViewLeft(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MovePageLeft(blameView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstBlameLine(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first line in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastBlameLine(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.MoveToLastLine(blameView.lineNumber()) {
+		log.Debugf("Moving to last line in blame view")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerBlameView(blameView *BlameView, action Action) (err error) {
+	viewPos := blameView.viewPos
+
+	if viewPos.CenterActiveRow(blameView.pageRows()) {
+		log.Debug("Centering BlameView")
+		blameView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func openBlameFileInEditor(blameView *BlameView, action Action) (err error) {
+	if blameView.oid == nil {
+		return
+	}
+
+	return openFileInEditor(blameView.repoData, blameView.channels, blameView.path, blameView.oid)
+}
+
+// toggleFollowRenames reloads the blame with rename and copy tracking toggled
+func toggleFollowRenames(blameView *BlameView, action Action) (err error) {
+	if blameView.oid == nil {
+		return
+	}
+
+	blameView.followRenames = !blameView.followRenames
+	path, oid := blameView.path, blameView.oid
+
+	log.Debugf("Following renames in BlameView toggled: %v", blameView.followRenames)
+
+	go func() {
+		if err := blameView.LoadBlame(path, oid); err != nil {
+			blameView.channels.ReportError(err)
+		}
+	}()
+
+	return
+}
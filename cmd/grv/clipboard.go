@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type clipboardCommand struct {
+	name string
+	args []string
+}
+
+// Clipboard tools in order of preference
+var clipboardCommands = []clipboardCommand{
+	{name: "pbcopy"},
+	{name: "xclip", args: []string{"-selection", "clipboard"}},
+	{name: "xsel", args: []string{"--clipboard", "--input"}},
+	{name: "clip"},
+}
+
+// CopyToClipboard writes the provided text to the system clipboard
+// using the first available clipboard tool
+func CopyToClipboard(text string) (err error) {
+	for _, clipboardCommand := range clipboardCommands {
+		if _, lookupErr := exec.LookPath(clipboardCommand.name); lookupErr != nil {
+			continue
+		}
+
+		log.Debugf("Copying %v bytes to clipboard using %v", len(text), clipboardCommand.name)
+
+		cmd := exec.Command(clipboardCommand.name, clipboardCommand.args...)
+		cmd.Stdin = strings.NewReader(text)
+
+		if output, cmdErr := cmd.CombinedOutput(); cmdErr != nil {
+			err = fmt.Errorf("Failed to copy to clipboard using %v: %v %v", clipboardCommand.name, cmdErr, strings.TrimSpace(string(output)))
+			log.Error(err)
+		}
+
+		return
+	}
+
+	err = fmt.Errorf("Unable to copy to clipboard: No clipboard tool found")
+	log.Error(err)
+
+	return
+}
@@ -1,7 +1,15 @@
 package main
 
 import (
+	"fmt"
 	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	cfDateRangeSeparator = ".."
 )
 
 // CreateCommitFilter constructs a commit filter from the provided query
@@ -33,6 +41,96 @@ func (commitFilter *CommitFilter) MatchesFilter(commit *Commit) bool {
 	return commitFilter.filter(commit)
 }
 
+// NewDateRangeCommitFilter creates a commit filter which matches commits with an author date
+// within the provided range. A zero from or to date leaves that end of the range open
+func NewDateRangeCommitFilter(from, to time.Time) *CommitFilter {
+	return NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+		return DateInRange(commit.commit.Author().When, from, to)
+	})
+}
+
+// NewPathCommitFilter creates a commit filter which matches commits that modified
+// the file or directory at the provided path
+func NewPathCommitFilter(repoData RepoData, path string) *CommitFilter {
+	return NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+
+		modified, err := repoData.CommitModifiesPath(commit, path)
+		if err != nil {
+			log.Errorf("Unable to determine if commit %v modified path %v: %v", commit.oid, path, err)
+		}
+
+		return modified
+	})
+}
+
+// NormalisePath converts the path into the form used for paths
+// within a repository tree, relative to its root and without trailing separators
+func NormalisePath(path string) string {
+	path = strings.TrimSpace(path)
+
+	for strings.HasPrefix(path, "./") {
+		path = path[2:]
+	}
+
+	return strings.Trim(path, "/")
+}
+
+// DateInRange returns true if the date falls within the provided inclusive range
+// A zero from or to date leaves that end of the range open
+func DateInRange(date, from, to time.Time) bool {
+	return (from.IsZero() || !date.Before(from)) && (to.IsZero() || !date.After(to))
+}
+
+// ParseDateRange parses a date range of the form from..to
+// Either date can be omitted to leave that end of the range open
+// A to date without a time includes the whole of that day
+func ParseDateRange(dateRange string) (from, to time.Time, err error) {
+	dates := strings.Split(dateRange, cfDateRangeSeparator)
+	if len(dates) != 2 {
+		err = fmt.Errorf("Invalid date range: %v. Format must be from%vto", dateRange, cfDateRangeSeparator)
+		return
+	}
+
+	if from, _, err = parseRangeDate(dates[0]); err != nil {
+		return
+	}
+
+	var hasTime bool
+	if to, hasTime, err = parseRangeDate(dates[1]); err != nil {
+		return
+	}
+
+	if !to.IsZero() && !hasTime {
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		err = fmt.Errorf("Invalid date range: %v. End date is before start date", dateRange)
+	}
+
+	return
+}
+
+func parseRangeDate(dateString string) (date time.Time, hasTime bool, err error) {
+	dateString = strings.TrimSpace(dateString)
+
+	switch {
+	case dateString == "":
+		return
+	case dateFormatPattern.MatchString(dateString):
+		date, err = time.ParseInLocation(queryDateFormat, dateString, time.Local)
+	case dateTimeFormatPattern.MatchString(dateString):
+		date, err = time.ParseInLocation(queryDateTimeFormat, dateString, time.Local)
+		hasTime = true
+	default:
+		err = fmt.Errorf("Invalid date: %v. Format must be either %v or %v", dateString, queryDateFormat, queryDateTimeFormat)
+	}
+
+	return
+}
+
 // CommitFieldDescriptor exposes functions describing commit field properties
 type CommitFieldDescriptor struct{}
 
@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	git "gopkg.in/libgit2/git2go.v25"
 )
@@ -219,3 +220,100 @@ func TestNilCommitFilterIsReturnedIfQueryDoesNotDefineFilter(t *testing.T) {
 		t.Errorf("Expected returned filter to be nil but found: %[1]v of type %[1]T", commitFilter)
 	}
 }
+
+func TestParseDateRange(t *testing.T) {
+	var dateRangeTests = []struct {
+		dateRange    string
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{
+			dateRange:    "2017-01-01..2017-06-30",
+			expectedFrom: time.Date(2017, 1, 1, 0, 0, 0, 0, time.Local),
+			expectedTo:   time.Date(2017, 6, 30, 23, 59, 59, 999999999, time.Local),
+		},
+		{
+			dateRange:    "2017-01-01 10:30:00..",
+			expectedFrom: time.Date(2017, 1, 1, 10, 30, 0, 0, time.Local),
+		},
+		{
+			dateRange:  "..2017-06-30 12:00:00",
+			expectedTo: time.Date(2017, 6, 30, 12, 0, 0, 0, time.Local),
+		},
+	}
+
+	for _, dateRangeTest := range dateRangeTests {
+		from, to, err := ParseDateRange(dateRangeTest.dateRange)
+
+		if err != nil {
+			t.Errorf("ParseDateRange failed for %v with error: %v", dateRangeTest.dateRange, err)
+		} else if !from.Equal(dateRangeTest.expectedFrom) || !to.Equal(dateRangeTest.expectedTo) {
+			t.Errorf("Date range does not match expected value for %v. Expected: %v - %v, Actual: %v - %v",
+				dateRangeTest.dateRange, dateRangeTest.expectedFrom, dateRangeTest.expectedTo, from, to)
+		}
+	}
+}
+
+func TestParseDateRangeReturnsErrorForInvalidRanges(t *testing.T) {
+	for _, dateRange := range []string{"2017-01-01", "2017-13..", "2017-06-30..2017-01-01"} {
+		if _, _, err := ParseDateRange(dateRange); err == nil {
+			t.Errorf("Expected error for date range %v", dateRange)
+		}
+	}
+}
+
+func TestDateInRangeSupportsOpenEndedRanges(t *testing.T) {
+	date := time.Date(2017, 3, 1, 0, 0, 0, 0, time.Local)
+	from := time.Date(2017, 1, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2017, 2, 1, 0, 0, 0, 0, time.Local)
+
+	if DateInRange(date, from, to) {
+		t.Errorf("Expected %v to be outside range %v - %v", date, from, to)
+	}
+
+	if !DateInRange(date, from, time.Time{}) {
+		t.Errorf("Expected %v to be after %v", date, from)
+	}
+
+	if DateInRange(date, time.Time{}, to) {
+		t.Errorf("Expected %v not to be before %v", date, to)
+	}
+}
+
+func TestNormalisePath(t *testing.T) {
+	var normalisePathTests = []struct {
+		path         string
+		expectedPath string
+	}{
+		{path: "cmd/grv/main.go", expectedPath: "cmd/grv/main.go"},
+		{path: "./cmd/grv/", expectedPath: "cmd/grv"},
+		{path: " /doc ", expectedPath: "doc"},
+		{path: "./", expectedPath: ""},
+	}
+
+	for _, normalisePathTest := range normalisePathTests {
+		if path := NormalisePath(normalisePathTest.path); path != normalisePathTest.expectedPath {
+			t.Errorf("Normalised path does not match expected value for path %q. Expected: %q, Actual: %q",
+				normalisePathTest.path, normalisePathTest.expectedPath, path)
+		}
+	}
+}
+
+func TestPathCommitFilterMatchesCommitsWhichModifiedPath(t *testing.T) {
+	modifyingCommit := &Commit{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)}
+	otherCommit := &Commit{oid: newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)}
+
+	repoData := &MockRepoData{}
+	repoData.On("CommitModifiesPath", modifyingCommit, "doc").Return(true, nil)
+	repoData.On("CommitModifiesPath", otherCommit, "doc").Return(false, nil)
+
+	commitFilter := NewPathCommitFilter(repoData, "doc")
+
+	if !commitFilter.MatchesFilter(modifyingCommit) {
+		t.Errorf("Expected commit which modified path to match filter")
+	}
+
+	if commitFilter.MatchesFilter(otherCommit) {
+		t.Errorf("Expected commit which did not modify path not to match filter")
+	}
+}
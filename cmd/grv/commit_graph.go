@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"sync"
+)
+
+const (
+	cgCommit     = '*'
+	cgLane       = '|'
+	cgConverge   = '/'
+	cgForkRight  = '\\'
+	cgForkLeft   = '/'
+	cgHorizontal = '-'
+	cgEmpty      = ' '
+)
+
+const cgNoLane = -1
+
+// CommitGraph generates a textual representation of the commit graph
+// Commits must be added in the order they are displayed with children
+// preceding their parents
+type CommitGraph struct {
+	lanes []string
+	rows  []string
+	lock  sync.Mutex
+}
+
+// NewCommitGraph creates a new instance
+func NewCommitGraph() *CommitGraph {
+	return &CommitGraph{}
+}
+
+// AddCommit generates the graph row for the next commit
+func (commitGraph *CommitGraph) AddCommit(id string, parentIDs []string) {
+	commitGraph.lock.Lock()
+	defer commitGraph.lock.Unlock()
+
+	commitLane := cgNoLane
+	var convergingLanes []int
+
+	for laneIndex, laneID := range commitGraph.lanes {
+		if laneID != id {
+			continue
+		}
+
+		if commitLane == cgNoLane {
+			commitLane = laneIndex
+		} else {
+			convergingLanes = append(convergingLanes, laneIndex)
+		}
+	}
+
+	if commitLane == cgNoLane {
+		commitLane = commitGraph.freeLane()
+		commitGraph.lanes[commitLane] = id
+	}
+
+	var newLanes []int
+
+	if len(parentIDs) > 1 {
+		for _, parentID := range parentIDs[1:] {
+			if commitGraph.laneIndex(parentID) != cgNoLane {
+				continue
+			}
+
+			laneIndex := commitGraph.freeLane()
+			commitGraph.lanes[laneIndex] = parentID
+			newLanes = append(newLanes, laneIndex)
+		}
+	}
+
+	commitGraph.rows = append(commitGraph.rows, commitGraph.renderRow(commitLane, convergingLanes, newLanes))
+
+	if len(parentIDs) > 0 {
+		commitGraph.lanes[commitLane] = parentIDs[0]
+	} else {
+		commitGraph.lanes[commitLane] = ""
+	}
+
+	for _, laneIndex := range convergingLanes {
+		commitGraph.lanes[laneIndex] = ""
+	}
+
+	commitGraph.trimLanes()
+}
+
+func (commitGraph *CommitGraph) laneIndex(id string) int {
+	for laneIndex, laneID := range commitGraph.lanes {
+		if laneID == id {
+			return laneIndex
+		}
+	}
+
+	return cgNoLane
+}
+
+func (commitGraph *CommitGraph) freeLane() int {
+	for laneIndex, laneID := range commitGraph.lanes {
+		if laneID == "" {
+			return laneIndex
+		}
+	}
+
+	commitGraph.lanes = append(commitGraph.lanes, "")
+
+	return len(commitGraph.lanes) - 1
+}
+
+func (commitGraph *CommitGraph) trimLanes() {
+	laneNum := len(commitGraph.lanes)
+
+	for laneNum > 0 && commitGraph.lanes[laneNum-1] == "" {
+		laneNum--
+	}
+
+	commitGraph.lanes = commitGraph.lanes[:laneNum]
+}
+
+func (commitGraph *CommitGraph) renderRow(commitLane int, convergingLanes, newLanes []int) string {
+	cells := make([]rune, len(commitGraph.lanes))
+	connectionStart := commitLane
+	connectionEnd := commitLane
+
+	for laneIndex, laneID := range commitGraph.lanes {
+		if laneID == "" {
+			cells[laneIndex] = cgEmpty
+		} else {
+			cells[laneIndex] = cgLane
+		}
+	}
+
+	for _, laneIndex := range convergingLanes {
+		cells[laneIndex] = cgConverge
+		connectionEnd = MaxInt(connectionEnd, laneIndex)
+	}
+
+	for _, laneIndex := range newLanes {
+		if laneIndex > commitLane {
+			cells[laneIndex] = cgForkRight
+			connectionEnd = MaxInt(connectionEnd, laneIndex)
+		} else {
+			cells[laneIndex] = cgForkLeft
+			connectionStart = MinInt(connectionStart, laneIndex)
+		}
+	}
+
+	cells[commitLane] = cgCommit
+
+	var row bytes.Buffer
+
+	for laneIndex, cell := range cells {
+		inConnection := laneIndex >= connectionStart && laneIndex <= connectionEnd
+
+		if cell == cgEmpty && inConnection {
+			cell = cgHorizontal
+		}
+
+		row.WriteRune(cell)
+
+		if inConnection && laneIndex < connectionEnd {
+			row.WriteRune(cgHorizontal)
+		} else {
+			row.WriteRune(cgEmpty)
+		}
+	}
+
+	return row.String()
+}
+
+// Row returns the graph row for the commit at the provided index
+func (commitGraph *CommitGraph) Row(index uint) (row string, exists bool) {
+	commitGraph.lock.Lock()
+	defer commitGraph.lock.Unlock()
+
+	if index < uint(len(commitGraph.rows)) {
+		row = commitGraph.rows[index]
+		exists = true
+	}
+
+	return
+}
+
+// RowNum returns the number of commits the graph has been generated for
+func (commitGraph *CommitGraph) RowNum() uint {
+	commitGraph.lock.Lock()
+	defer commitGraph.lock.Unlock()
+
+	return uint(len(commitGraph.rows))
+}
+
+// Clear removes all generated graph rows
+func (commitGraph *CommitGraph) Clear() {
+	commitGraph.lock.Lock()
+	defer commitGraph.lock.Unlock()
+
+	commitGraph.lanes = nil
+	commitGraph.rows = nil
+}
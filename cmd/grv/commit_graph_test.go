@@ -0,0 +1,105 @@
+package main
+
+import (
+	"testing"
+)
+
+type testGraphCommit struct {
+	id        string
+	parentIDs []string
+}
+
+func checkCommitGraphRows(commits []testGraphCommit, expectedRows []string, t *testing.T) {
+	commitGraph := NewCommitGraph()
+
+	for _, commit := range commits {
+		commitGraph.AddCommit(commit.id, commit.parentIDs)
+	}
+
+	if commitGraph.RowNum() != uint(len(expectedRows)) {
+		t.Fatalf("Row number does not match expected value. Expected: %v, Actual: %v", len(expectedRows), commitGraph.RowNum())
+	}
+
+	for rowIndex, expectedRow := range expectedRows {
+		actualRow, exists := commitGraph.Row(uint(rowIndex))
+
+		if !exists {
+			t.Errorf("Expected row %v to exist", rowIndex)
+		} else if actualRow != expectedRow {
+			t.Errorf("Row %v does not match expected value. Expected: \"%v\", Actual: \"%v\"", rowIndex, expectedRow, actualRow)
+		}
+	}
+}
+
+func TestCommitGraphForLinearHistory(t *testing.T) {
+	commits := []testGraphCommit{
+		{id: "c", parentIDs: []string{"b"}},
+		{id: "b", parentIDs: []string{"a"}},
+		{id: "a"},
+	}
+
+	expectedRows := []string{
+		"* ",
+		"* ",
+		"* ",
+	}
+
+	checkCommitGraphRows(commits, expectedRows, t)
+}
+
+func TestCommitGraphForMergedHistory(t *testing.T) {
+	commits := []testGraphCommit{
+		{id: "merge", parentIDs: []string{"b", "c"}},
+		{id: "b", parentIDs: []string{"a"}},
+		{id: "c", parentIDs: []string{"a"}},
+		{id: "a"},
+	}
+
+	expectedRows := []string{
+		"*-\\ ",
+		"* | ",
+		"| * ",
+		"*-/ ",
+	}
+
+	checkCommitGraphRows(commits, expectedRows, t)
+}
+
+func TestCommitGraphReusesFreeLanes(t *testing.T) {
+	commits := []testGraphCommit{
+		{id: "merge", parentIDs: []string{"b", "c"}},
+		{id: "b", parentIDs: []string{"a"}},
+		{id: "c", parentIDs: []string{"a"}},
+		{id: "a", parentIDs: []string{"root"}},
+		{id: "root"},
+	}
+
+	expectedRows := []string{
+		"*-\\ ",
+		"* | ",
+		"| * ",
+		"*-/ ",
+		"* ",
+	}
+
+	checkCommitGraphRows(commits, expectedRows, t)
+}
+
+func TestCommitGraphRowDoesNotExistForUnknownIndex(t *testing.T) {
+	commitGraph := NewCommitGraph()
+	commitGraph.AddCommit("a", nil)
+
+	if _, exists := commitGraph.Row(1); exists {
+		t.Errorf("Expected row 1 not to exist")
+	}
+}
+
+func TestCommitGraphClearRemovesAllRows(t *testing.T) {
+	commitGraph := NewCommitGraph()
+	commitGraph.AddCommit("b", []string{"a"})
+	commitGraph.Clear()
+
+	if rowNum := commitGraph.RowNum(); rowNum != 0 {
+		t.Errorf("Expected no rows after clear but found %v", rowNum)
+	}
+}
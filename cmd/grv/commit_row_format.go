@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	crfFieldPrefix = "%"
+	crfEllipsis    = "…"
+)
+
+// CommitRowField is a commit field which can be displayed in a column of the commit view
+type CommitRowField int
+
+// The set of supported commit row fields
+const (
+	CrfLiteral CommitRowField = iota
+	CrfOid
+	CrfDate
+	CrfAuthor
+	CrfSubject
+	CrfSignature
+	CrfDiffStat
+)
+
+var commitRowFieldNames = map[string]CommitRowField{
+	"oid":       CrfOid,
+	"date":      CrfDate,
+	"author":    CrfAuthor,
+	"subject":   CrfSubject,
+	"signature": CrfSignature,
+	"diffstat":  CrfDiffStat,
+}
+
+// CommitRowFormatToken describes the content of a single column of the commit view
+type CommitRowFormatToken struct {
+	field    CommitRowField
+	maxWidth uint
+	literal  string
+}
+
+// Truncate shortens the provided text to the maximum display width of the token
+// if a maximum width has been specified. Text is always cut on a rune boundary
+// and truncated subjects end with an ellipsis
+func (token CommitRowFormatToken) Truncate(text string) string {
+	if token.maxWidth == 0 {
+		return text
+	}
+
+	if token.field == CrfSubject && token.maxWidth > 1 {
+		return TruncateToWidth(text, token.maxWidth, crfEllipsis)
+	}
+
+	return TruncateToWidth(text, token.maxWidth, "")
+}
+
+// ParseCommitRowFormat splits the provided format into whitespace separated tokens.
+// A token of the form %[width]field specifies a commit field with an optional maximum width.
+// All other tokens, including unknown fields, are rendered literally
+func ParseCommitRowFormat(format string) (tokens []CommitRowFormatToken) {
+	for _, word := range strings.Fields(format) {
+		tokens = append(tokens, parseCommitRowFormatToken(word))
+	}
+
+	return
+}
+
+func parseCommitRowFormatToken(word string) CommitRowFormatToken {
+	literalToken := CommitRowFormatToken{
+		field:   CrfLiteral,
+		literal: word,
+	}
+
+	if !strings.HasPrefix(word, crfFieldPrefix) {
+		return literalToken
+	}
+
+	fieldSpec := strings.TrimPrefix(word, crfFieldPrefix)
+	nameIndex := strings.IndexFunc(fieldSpec, func(char rune) bool {
+		return char < '0' || char > '9'
+	})
+
+	if nameIndex == -1 {
+		log.Infof("Missing field name in commit row format token %v. Rendering literally", word)
+		return literalToken
+	}
+
+	var maxWidth uint
+
+	if nameIndex > 0 {
+		width, err := strconv.ParseUint(fieldSpec[:nameIndex], 10, 32)
+		if err != nil {
+			log.Infof("Invalid width in commit row format token %v: %v. Rendering literally", word, err)
+			return literalToken
+		}
+
+		maxWidth = uint(width)
+	}
+
+	field, ok := commitRowFieldNames[fieldSpec[nameIndex:]]
+	if !ok {
+		log.Infof("Unknown commit row format token %v. Rendering literally", word)
+		return literalToken
+	}
+
+	return CommitRowFormatToken{
+		field:    field,
+		maxWidth: maxWidth,
+	}
+}
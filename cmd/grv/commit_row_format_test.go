@@ -0,0 +1,112 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseCommitRowFormat(t *testing.T) {
+	var commitRowFormatTests = []struct {
+		format         string
+		expectedTokens []CommitRowFormatToken
+	}{
+		{
+			format: "%oid %date %author %subject",
+			expectedTokens: []CommitRowFormatToken{
+				{field: CrfOid},
+				{field: CrfDate},
+				{field: CrfAuthor},
+				{field: CrfSubject},
+			},
+		},
+		{
+			format: "%signature %oid",
+			expectedTokens: []CommitRowFormatToken{
+				{field: CrfSignature},
+				{field: CrfOid},
+			},
+		},
+		{
+			format: "%oid %diffstat %subject",
+			expectedTokens: []CommitRowFormatToken{
+				{field: CrfOid},
+				{field: CrfDiffStat},
+				{field: CrfSubject},
+			},
+		},
+		{
+			format: "  %20author\t%subject  ",
+			expectedTokens: []CommitRowFormatToken{
+				{field: CrfAuthor, maxWidth: 20},
+				{field: CrfSubject},
+			},
+		},
+		{
+			format: "| %unknown %10 % %5x5date",
+			expectedTokens: []CommitRowFormatToken{
+				{field: CrfLiteral, literal: "|"},
+				{field: CrfLiteral, literal: "%unknown"},
+				{field: CrfLiteral, literal: "%10"},
+				{field: CrfLiteral, literal: "%"},
+				{field: CrfLiteral, literal: "%5x5date"},
+			},
+		},
+		{
+			format: "",
+		},
+	}
+
+	for _, commitRowFormatTest := range commitRowFormatTests {
+		tokens := ParseCommitRowFormat(commitRowFormatTest.format)
+
+		if !reflect.DeepEqual(commitRowFormatTest.expectedTokens, tokens) {
+			t.Errorf("Tokens do not match expected tokens for format %v. Expected: %v, Actual: %v",
+				commitRowFormatTest.format, commitRowFormatTest.expectedTokens, tokens)
+		}
+	}
+}
+
+func TestCommitRowFormatTokenTruncatesToMaxWidth(t *testing.T) {
+	var truncateTests = []struct {
+		token        CommitRowFormatToken
+		text         string
+		expectedText string
+	}{
+		{token: CommitRowFormatToken{field: CrfAuthor}, text: "John Smith", expectedText: "John Smith"},
+		{token: CommitRowFormatToken{field: CrfAuthor, maxWidth: 4}, text: "John Smith", expectedText: "John"},
+		{token: CommitRowFormatToken{field: CrfAuthor, maxWidth: 20}, text: "John Smith", expectedText: "John Smith"},
+		{token: CommitRowFormatToken{field: CrfAuthor, maxWidth: 2}, text: "日本語", expectedText: "日"},
+		{token: CommitRowFormatToken{field: CrfAuthor, maxWidth: 3}, text: "日本語", expectedText: "日"},
+		{token: CommitRowFormatToken{field: CrfSubject, maxWidth: 5}, text: "日本語", expectedText: "日本…"},
+		{token: CommitRowFormatToken{field: CrfSubject, maxWidth: 4}, text: "日本語", expectedText: "日…"},
+		{token: CommitRowFormatToken{field: CrfSubject, maxWidth: 6}, text: "Fix summary", expectedText: "Fix s…"},
+		{token: CommitRowFormatToken{field: CrfSubject, maxWidth: 20}, text: "Fix summary", expectedText: "Fix summary"},
+		{token: CommitRowFormatToken{field: CrfSubject, maxWidth: 1}, text: "Fix summary", expectedText: "F"},
+	}
+
+	for _, truncateTest := range truncateTests {
+		if text := truncateTest.token.Truncate(truncateTest.text); text != truncateTest.expectedText {
+			t.Errorf("Truncated text does not match expected text. Expected: %v, Actual: %v", truncateTest.expectedText, text)
+		}
+	}
+}
+
+func TestCommitRowFormatTokenTruncatesMultibyteSubjectOnRuneBoundary(t *testing.T) {
+	token := CommitRowFormatToken{field: CrfSubject, maxWidth: 14}
+	summary := "Añadir café y documentación"
+
+	text := token.Truncate(summary)
+
+	if !utf8.ValidString(text) {
+		t.Fatalf("Truncated subject is not valid UTF-8: %q", text)
+	}
+
+	if expectedText := "Añadir café y…"; text != expectedText {
+		t.Errorf("Truncated subject does not match expected text. Expected: %q, Actual: %q", expectedText, text)
+	}
+
+	if runeCount := utf8.RuneCountInString(text); runeCount != 14 {
+		t.Errorf("Expected truncated subject to contain 14 runes but found %v", runeCount)
+	}
+}
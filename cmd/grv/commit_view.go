@@ -3,18 +3,100 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	log "github.com/Sirupsen/logrus"
 )
 
 const (
-	cvLoadRefreshMs = 500
-	cvColumnNum     = 4
-	cvDateFormat    = "2006-01-02 15:04"
+	cvDateFormat              = "2006-01-02 15:04"
+	cvPager                   = "less"
+	cvRowCacheMaxSize         = 1000
+	cvSelectionHistoryMaxSize = 100
+	cvHeadMarker              = ">"
+	cvMarkedCommitMarker      = "*"
+	cvEditor                  = "vi"
+	cvCommitMessageFile       = "COMMIT_EDITMSG"
+	cvMinimapMinCols          = 100
+	cvMinimapSamples          = 8
+	cvPreviewSeparator        = "─"
 )
 
+// CommitDateFormat is a format in which commit dates are displayed
+type CommitDateFormat int
+
+// The set of supported commit date formats in the order they are cycled through
+const (
+	CdfShort CommitDateFormat = iota
+	CdfRelative
+	CdfFull
+	cdfFormatNum
+)
+
+// Next returns the date format which follows this one when cycling
+func (dateFormat CommitDateFormat) Next() CommitDateFormat {
+	return (dateFormat + 1) % cdfFormatNum
+}
+
+// FormatCommitDate formats the date using the provided format.
+// Relative dates are calculated against now
+func FormatCommitDate(date time.Time, dateFormat CommitDateFormat, now time.Time) string {
+	switch dateFormat {
+	case CdfRelative:
+		return FormatRelativeTime(date, now)
+	case CdfFull:
+		return date.Format(time.RFC3339)
+	}
+
+	return date.Format(cvDateFormat)
+}
+
+// commitViewConfigVariables are the config variables the commit view listens for changes to
+var commitViewConfigVariables = []ConfigVariable{
+	CfCommitRowFormat,
+	CfAuthorColors,
+	CfCommitDecorations,
+	CfShortOidLength,
+	CfCommitPreview,
+}
+
+// minimapDensityChars are the characters used to display increasing proportions of merge commits in the minimap
+var minimapDensityChars = []rune{'·', ':', '+', '#'}
+
+// signatureStatusDisplay contains the letter and theme component used to display each signature status
+// The letters are those output by git's %G? format placeholder
+var signatureStatusDisplay = map[SignatureStatus]struct {
+	letter           string
+	themeComponentID ThemeComponentID
+}{
+	SsUnknown:             {" ", CmpCommitviewSignatureNone},
+	SsNone:                {"N", CmpCommitviewSignatureNone},
+	SsGood:                {"G", CmpCommitviewSignatureGood},
+	SsGoodUnknownValidity: {"U", CmpCommitviewSignatureGood},
+	SsBad:                 {"B", CmpCommitviewSignatureBad},
+	SsExpiredSignature:    {"X", CmpCommitviewSignatureBad},
+	SsExpiredKey:          {"Y", CmpCommitviewSignatureBad},
+	SsRevokedKey:          {"R", CmpCommitviewSignatureBad},
+	SsMissingKey:          {"E", CmpCommitviewSignatureNone},
+}
+
+// The theme components author names are colored with when author colors are enabled
+var authorColorComponents = []ThemeComponentID{
+	CmpCommitviewAuthorColor1,
+	CmpCommitviewAuthorColor2,
+	CmpCommitviewAuthorColor3,
+	CmpCommitviewAuthorColor4,
+	CmpCommitviewAuthorColor5,
+	CmpCommitviewAuthorColor6,
+}
+
 type commitViewHandler func(*CommitView, Action) error
 
 type loadingCommitsRefreshTask struct {
@@ -24,9 +106,127 @@ type loadingCommitsRefreshTask struct {
 	cancelCh    chan<- bool
 }
 
+type commitRowCacheEntry struct {
+	shortID     string
+	when        time.Time
+	author      string
+	authorColor ThemeComponentID
+	summary     string
+}
+
 type referenceViewData struct {
-	viewPos        ViewPos
-	tableFormatter *TableFormatter
+	viewPos          ViewPos
+	tableFormatter   *TableFormatter
+	commitGraph      *CommitGraph
+	rowCache         map[*Oid]*commitRowCacheEntry
+	bodyCache        map[*Oid]string
+	pendingOid       *Oid
+	selectedOid      *Oid
+	selectionHistory []*Oid
+	wrapRows         uint
+}
+
+func newReferenceViewData(columnNum uint) *referenceViewData {
+	return &referenceViewData{
+		viewPos:        NewViewPosition(),
+		tableFormatter: NewTableFormatter(columnNum),
+		commitGraph:    NewCommitGraph(),
+		rowCache:       make(map[*Oid]*commitRowCacheEntry),
+		bodyCache:      make(map[*Oid]string),
+	}
+}
+
+// rowCacheEntry returns the formatted fields for the provided commit.
+// Fields are only formatted if they aren't already cached. The date is formatted
+// when rendered so that relative dates remain current
+func (refViewData *referenceViewData) rowCacheEntry(commit *Commit, shortIDLength int, showCommitter bool) *commitRowCacheEntry {
+	if cacheEntry, ok := refViewData.rowCache[commit.oid]; ok {
+		return cacheEntry
+	}
+
+	if len(refViewData.rowCache) >= cvRowCacheMaxSize {
+		refViewData.clearRowCache()
+	}
+
+	author := commit.commit.Author()
+	if showCommitter {
+		author = commit.commit.Committer()
+	}
+
+	cacheEntry := &commitRowCacheEntry{
+		shortID:     commit.oid.AbbreviatedID(shortIDLength),
+		when:        author.When,
+		author:      author.Name,
+		authorColor: colorForAuthor(author.Email),
+		summary:     commit.commit.Summary(),
+	}
+
+	refViewData.rowCache[commit.oid] = cacheEntry
+
+	return cacheEntry
+}
+
+func (refViewData *referenceViewData) clearRowCache() {
+	refViewData.rowCache = make(map[*Oid]*commitRowCacheEntry)
+}
+
+// recordSelection adds the provided commit to the selection history.
+// The oldest entry is discarded once the history reaches its maximum size
+func (refViewData *referenceViewData) recordSelection(oid *Oid) {
+	if len(refViewData.selectionHistory) >= cvSelectionHistoryMaxSize {
+		refViewData.selectionHistory = refViewData.selectionHistory[1:]
+	}
+
+	refViewData.selectionHistory = append(refViewData.selectionHistory, oid)
+}
+
+// popSelection removes and returns the most recent entry of the selection history
+func (refViewData *referenceViewData) popSelection() (oid *Oid, ok bool) {
+	historySize := len(refViewData.selectionHistory)
+	if historySize == 0 {
+		return
+	}
+
+	oid = refViewData.selectionHistory[historySize-1]
+	refViewData.selectionHistory = refViewData.selectionHistory[:historySize-1]
+
+	return oid, true
+}
+
+// messageBody returns the body of the commit message for the provided commit.
+// Bodies are only extracted when first requested and are then cached
+func (refViewData *referenceViewData) messageBody(commit *Commit) string {
+	if body, ok := refViewData.bodyCache[commit.oid]; ok {
+		return body
+	}
+
+	if len(refViewData.bodyCache) >= cvRowCacheMaxSize {
+		refViewData.bodyCache = make(map[*Oid]string)
+	}
+
+	body := CommitMessageBody(commit.commit.Message())
+	refViewData.bodyCache[commit.oid] = body
+
+	return body
+}
+
+// CommitMessageBody returns the commit message without its summary line.
+// Whitespace is collapsed so the body can be searched as a single line
+func CommitMessageBody(message string) string {
+	lines := strings.SplitN(message, "\n", 2)
+	if len(lines) < 2 {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(lines[1]), " ")
+}
+
+// colorForAuthor maps the provided author email to a stable entry in the author color palette
+func colorForAuthor(email string) ThemeComponentID {
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.ToLower(email)))
+
+	return authorColorComponents[hash.Sum32()%uint32(len(authorColorComponents))]
 }
 
 // CommitViewListener is notified when a commit is selected
@@ -34,46 +234,112 @@ type CommitViewListener interface {
 	OnCommitSelected(*Commit) error
 }
 
+// CommitRangeListener is notified when two commits are selected to be compared
+type CommitRangeListener interface {
+	OnCommitRangeSelected(fromCommit, toCommit *Commit) error
+}
+
 // CommitView is the overall instance representing the commit view
 type CommitView struct {
 	channels            *Channels
 	repoData            RepoData
+	config              Config
 	activeRef           Ref
 	active              bool
 	refViewData         map[string]*referenceViewData
 	handlers            map[ActionType]commitViewHandler
 	refreshTask         *loadingCommitsRefreshTask
 	commitViewListeners []CommitViewListener
+	showCommitGraph     bool
+	dateFormat          CommitDateFormat
+	rowDateFormats      map[*Oid]CommitDateFormat
+	showCommitter       bool
+	searchMessageBodies bool
+	wrapSummary         bool
+	showMinimap         bool
+	markedOid           *Oid
+	rowFormat           []CommitRowFormatToken
+	signatureStatuses   map[*Oid]SignatureStatus
+	unverifiedOids      []*Oid
+	diffStatLoader      *DiffStatLoader
+	loadError           error
+	bisecting           bool
+	bisectStep          *BisectStep
 	viewDimension       ViewDimension
 	viewSearch          *ViewSearch
 	lock                sync.Mutex
 }
 
 // NewCommitView creates a new instance of the commit view
-func NewCommitView(repoData RepoData, channels *Channels) *CommitView {
+func NewCommitView(repoData RepoData, channels *Channels, config Config) *CommitView {
 	commitView := &CommitView{
-		channels:    channels,
-		repoData:    repoData,
-		refViewData: make(map[string]*referenceViewData),
+		channels:          channels,
+		repoData:          repoData,
+		config:            config,
+		refViewData:       make(map[string]*referenceViewData),
+		signatureStatuses: make(map[*Oid]SignatureStatus),
+		diffStatLoader:    NewDiffStatLoader(repoData, channels, dslWorkerNum),
+		rowDateFormats:    make(map[*Oid]CommitDateFormat),
 		handlers: map[ActionType]commitViewHandler{
-			ActionPrevLine:     moveUpCommit,
-			ActionNextLine:     moveDownCommit,
-			ActionPrevPage:     moveUpCommitPage,
-			ActionNextPage:     moveDownCommitPage,
-			ActionPrevHalfPage: moveUpCommitHalfPage,
-			ActionNextHalfPage: moveDownCommitHalfPage,
-			ActionScrollRight:  scrollCommitViewRight,
-			ActionScrollLeft:   scrollCommitViewLeft,
-			ActionFirstLine:    moveToFirstCommit,
-			ActionLastLine:     moveToLastCommit,
-			ActionAddFilter:    addCommitFilter,
-			ActionRemoveFilter: removeCommitFilter,
-			ActionCenterView:   centerCommitView,
-			ActionSelect:       selectCommit,
+			ActionPrevLine:                moveUpCommit,
+			ActionNextLine:                moveDownCommit,
+			ActionPrevPage:                moveUpCommitPage,
+			ActionNextPage:                moveDownCommitPage,
+			ActionPrevHalfPage:            moveUpCommitHalfPage,
+			ActionNextHalfPage:            moveDownCommitHalfPage,
+			ActionScrollRight:             scrollCommitViewRight,
+			ActionScrollLeft:              scrollCommitViewLeft,
+			ActionFirstLine:               moveToFirstCommit,
+			ActionLastLine:                moveToLastCommit,
+			ActionAddFilter:               addCommitFilter,
+			ActionAddDateFilter:           addCommitDateFilter,
+			ActionGotoCommit:              gotoCommit,
+			ActionCreateTag:               createTag,
+			ActionCreateBranch:            createBranch,
+			ActionNextMergeCommit:         moveToNextMergeCommit,
+			ActionPrevMergeCommit:         moveToPrevMergeCommit,
+			ActionNextAuthorCommit:        moveToNextAuthorCommit,
+			ActionPrevAuthorCommit:        moveToPrevAuthorCommit,
+			ActionDismissLoadError:        dismissLoadError,
+			ActionBisectStart:             bisectStart,
+			ActionBisectGood:              bisectGood,
+			ActionBisectBad:               bisectBad,
+			ActionBisectReset:             bisectReset,
+			ActionInteractiveRebase:       interactiveRebase,
+			ActionAmendCommit:             amendCommit,
+			ActionPrevSelection:           selectPrevSelection,
+			ActionShowTree:                showCommitTree,
+			ActionAddPathFilter:           addCommitPathFilter,
+			ActionRemoveFilter:            removeCommitFilter,
+			ActionCenterView:              centerCommitView,
+			ActionSelect:                  selectCommit,
+			ActionToggleCommitGraph:       toggleCommitGraph,
+			ActionToggleRelativeDate:      toggleRelativeDate,
+			ActionCycleDateFormat:         cycleSelectedCommitDateFormat,
+			ActionCycleAllDateFormats:     cycleAllCommitDateFormats,
+			ActionToggleCommitter:         toggleCommitter,
+			ActionToggleSearchMessageBody: toggleSearchMessageBody,
+			ActionToggleSummaryWrap:       toggleSummaryWrap,
+			ActionToggleCommitOrder:       toggleCommitOrder,
+			ActionToggleFirstParent:       toggleFirstParent,
+			ActionToggleHideMerges:        toggleHideMerges,
+			ActionToggleCommitMinimap:     toggleCommitMinimap,
+			ActionMarkCommit:              markCommit,
+			ActionShowAuthorStats:         showAuthorStats,
+			ActionDiffAgainstMark:         diffAgainstMarkedCommit,
+			ActionClearCommitMark:         clearCommitMark,
+			ActionReloadCommits:           reloadActiveRefCommits,
+			ActionSelectHead:              selectHead,
+			ActionCopyCommitID:            copyCommitID,
+			ActionCopyCommitSummary:       copyCommitSummary,
+			ActionCopyCommitMessage:       copyCommitMessage,
+			ActionShowCommitInPager:       showCommitInPager,
+			ActionMouseSelect:             mouseSelectCommit,
 		},
 	}
 
 	commitView.viewSearch = NewViewSearch(commitView, channels)
+	commitView.setRowFormat(config.GetString(CfCommitRowFormat))
 
 	return commitView
 }
@@ -83,6 +349,11 @@ func (commitView *CommitView) Initialise() (err error) {
 	log.Info("Initialising CommitView")
 
 	commitView.repoData.RegisterCommitSetListener(commitView)
+	commitView.repoData.RegisterRefStateListener(commitView)
+
+	for _, configVariable := range commitViewConfigVariables {
+		commitView.config.AddOnChangeListener(configVariable, commitView)
+	}
 
 	return
 }
@@ -95,8 +366,18 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 
 	commitView.viewDimension = win.ViewDimensions()
 
+	if commitView.pageRows() == 0 {
+		log.Debugf("Window with %v rows is too small to render CommitView", win.Rows())
+		win.DrawBorder()
+		return
+	}
+
+	if commitView.loadError != nil && (commitView.activeRef == nil || commitView.repoData.CommitSetState(commitView.activeRef).commitNum == 0) {
+		return commitView.renderEmptyView(win, commitView.loadError.Error())
+	}
+
 	if commitView.activeRef == nil {
-		return commitView.renderEmptyView(win)
+		return commitView.renderEmptyView(win, "No commits to display")
 	}
 
 	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
@@ -107,45 +388,91 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 	commitNum := commitSetState.commitNum
 
+	if commitNum == 0 && !commitSetState.loading && commitSetState.filterState == nil {
+		return commitView.renderEmptyView(win, fmt.Sprintf("No commits for %v", commitView.activeRef.Shorthand()))
+	}
+
+	if commitSetState.loading && commitSetState.filterState == nil {
+		commitView.trackSelectedCommit(refViewData, commitSetState)
+	}
+
 	viewPos := refViewData.viewPos
-	rows := win.Rows() - 2
+	rows := commitView.pageRows()
 	viewPos.DetermineViewStartRow(rows, commitNum)
 
-	commitDisplayNum := rows
-	startCommitIndex := viewPos.ViewStartRowIndex()
+	tableFormatter := refViewData.tableFormatter
+	tableFormatter.Resize(rows)
 
-	commitCh, err := commitView.repoData.Commits(commitView.activeRef, startCommitIndex, commitDisplayNum)
+	showCommitGraph := commitView.showCommitGraph && commitSetState.filterState == nil
+	if showCommitGraph {
+		commitView.generateCommitGraph(refViewData.commitGraph, viewPos.ViewStartRowIndex()+rows)
+	}
+
+	startCommitIndex := viewPos.ViewStartRowIndex()
+	commits, err := commitView.visibleCommits(startCommitIndex, rows)
 	if err != nil {
-		return err
+		return
 	}
 
-	tableFormatter := refViewData.tableFormatter
-	tableFormatter.Resize(rows)
-	tableFormatter.Clear()
+	if err = commitView.renderCommits(refViewData, commits, showCommitGraph, 0); err != nil {
+		return
+	}
 
-	rowIndex := uint(0)
+	var wrapRows uint
+	if commitView.wrapSummary && commitNum > 0 {
+		wrapRows = commitView.selectedSummaryWrapRows(refViewData, commits, startCommitIndex)
 
-	for commit := range commitCh {
-		if err = commitView.renderCommit(tableFormatter, rowIndex, commit); err != nil {
-			return
+		if wrapRows > 0 {
+			viewPos.DetermineViewStartRow(rows-wrapRows, commitNum)
+
+			if viewStartRowIndex := viewPos.ViewStartRowIndex(); viewStartRowIndex >= startCommitIndex {
+				commits = commits[MinUint(viewStartRowIndex-startCommitIndex, uint(len(commits))):]
+			} else if commits, err = commitView.visibleCommits(viewStartRowIndex, rows-wrapRows); err != nil {
+				return
+			}
+
+			if err = commitView.renderCommits(refViewData, commits, showCommitGraph, wrapRows); err != nil {
+				return
+			}
 		}
+	}
 
-		rowIndex++
+	refViewData.wrapRows = wrapRows
+
+	if err = commitView.truncateSubjects(refViewData, wrapRows); err != nil {
+		return
 	}
 
+	commitView.verifyUnverifiedCommits()
+
 	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
 		return
 	}
 
 	if commitSetState.commitNum > 0 {
-		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, commitView.active); err != nil {
+		for rowIndex := uint(0); rowIndex <= wrapRows; rowIndex++ {
+			if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+rowIndex+1, commitView.active); err != nil {
+				return
+			}
+		}
+	}
+
+	if commitView.showMinimap && win.Cols() >= cvMinimapMinCols {
+		if err = commitView.renderMinimap(win, viewPos.ViewStartRowIndex(), rows, commitNum); err != nil {
+			return
+		}
+	}
+
+	if previewRows := commitView.previewRows(); previewRows > 0 {
+		if err = commitView.renderPreview(win, refViewData, rows+1, previewRows-1, commitNum); err != nil {
 			return
 		}
 	}
 
 	win.DrawBorder()
+	win.DrawScrollBar(viewPos.ViewStartRowIndex(), rows, commitNum, CmpCommitviewScrollBar)
 
-	if err = win.SetTitle(CmpCommitviewTitle, "Commits for %v", commitView.activeRef.Shorthand()); err != nil {
+	if err = win.SetTitle(CmpCommitviewTitle, "%v", commitView.title(commitSetState)); err != nil {
 		return
 	}
 
@@ -171,7 +498,21 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 		footerText.WriteString(fmt.Sprintf(" (%v filter%v applied)", commitSetState.filterState.filtersApplied, filtersTextSuffix))
 	}
 
-	if err = win.SetFooter(CmpCommitviewFooter, "%v", footerText.String()); err != nil {
+	if commitView.showCommitter {
+		footerText.WriteString(" (showing committer)")
+	}
+
+	if commitView.bisecting {
+		footerText.WriteString(fmt.Sprintf(" [%v]", commitView.bisectDescription()))
+	}
+
+	if commitView.loadError != nil {
+		err = win.SetFooter(CmpCommitviewLoadError, "%v", commitView.loadError)
+	} else {
+		err = win.SetFooter(CmpCommitviewFooter, "%v", footerText.String())
+	}
+
+	if err != nil {
 		return
 	}
 
@@ -184,196 +525,972 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 	return err
 }
 
-func (commitView *CommitView) renderEmptyView(win RenderWindow) (err error) {
-	if err = win.SetRow(2, 1, CmpNone, "   No commits to display"); err != nil {
+func (commitView *CommitView) renderEmptyView(win RenderWindow, message string) (err error) {
+	if err = win.SetRow(2, 1, CmpNone, "   %v", message); err != nil {
 		return
 	}
 
 	win.DrawBorder()
 
+	if commitView.activeRef != nil {
+		err = win.SetTitle(CmpCommitviewTitle, "Commits for %v", commitView.activeRef.Shorthand())
+	}
+
 	return
 }
 
-func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIndex uint, commit *Commit) (err error) {
-	author := commit.commit.Author()
-	commitRefs := commitView.repoData.RefsForCommit(commit)
-	colIndex := uint(0)
+// renderCommits populates the table formatter with the commits visible in the view.
+// When wrapRows is non-zero, that many rows after the selected commit are used
+// to display the remainder of its summary
+// renderMinimap draws a coarse overview of the loaded commits in the column to the left of the scroll bar.
+// Each row represents a range of commits and displays the proportion of merges in a sample of them.
+// Rows containing commits currently visible in the view are highlighted
+func (commitView *CommitView) renderMinimap(win RenderWindow, viewStartRow, visibleRows, commitNum uint) (err error) {
+	minimapCol := win.Cols() - 2
+
+	for rowIndex := uint(0); rowIndex < visibleRows; rowIndex++ {
+		startCommit, endCommit := commitMinimapBucket(visibleRows, rowIndex, commitNum)
+		codePoint := ' '
+		themeComponentID := CmpCommitviewMinimap
+
+		if startCommit < endCommit {
+			var merges, samples uint
+			if merges, samples, err = commitView.sampleMerges(startCommit, endCommit); err != nil {
+				return
+			}
 
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewShortOid, "%v", commit.oid.ShortID()); err != nil {
-		return
-	}
+			codePoint = minimapDensityChar(merges, samples)
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", author.When.Format(cvDateFormat)); err != nil {
-		return
+			if startCommit < viewStartRow+visibleRows && endCommit > viewStartRow {
+				themeComponentID = CmpCommitviewMinimapWindow
+			}
+		}
+
+		if err = win.SetCell(rowIndex+1, minimapCol-1, ' ', CmpNone); err != nil {
+			return
+		}
+
+		if err = win.SetCell(rowIndex+1, minimapCol, codePoint, themeComponentID); err != nil {
+			return
+		}
 	}
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", author.Name); err != nil {
+	return
+}
+
+// renderPreview displays the first lines of the selected commit message below the commits.
+// The separator row above the preview shows how many lines didn't fit
+func (commitView *CommitView) renderPreview(win RenderWindow, refViewData *referenceViewData, separatorRow, previewLines, commitNum uint) (err error) {
+	if win.Cols() < 3 {
 		return
 	}
 
-	colIndex++
-	if len(commitRefs.tags) > 0 {
-		for _, tag := range commitRefs.tags {
-			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewTag, "<%v>", tag.Shorthand()); err != nil {
-				return
-			}
+	var lines []string
 
-			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
-				return
-			}
+	if commitNum > 0 {
+		var commit *Commit
+		if commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, refViewData.viewPos.ActiveRowIndex()); err != nil {
+			return
 		}
+
+		lines = CommitMessagePreview(commit.commit.Message(), win.Cols()-3)
 	}
 
-	if len(commitRefs.branches) > 0 {
-		for _, branch := range commitRefs.branches {
-			if branch.IsRemote() {
-				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewLocalBranch, "{%v}", branch.Shorthand()); err != nil {
-					return
-				}
-			} else {
-				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewRemoteBranch, "[%v]", branch.Shorthand()); err != nil {
-					return
-				}
-			}
+	separatorWidth := int(win.Cols() - 2)
+	separator := ""
 
-			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
-				return
-			}
-		}
+	if hiddenLines := len(lines) - int(previewLines); hiddenLines > 0 {
+		separator = fmt.Sprintf("%v %v more lines ", cvPreviewSeparator, hiddenLines)
 	}
 
-	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", commit.commit.Summary()); err != nil {
+	separator += strings.Repeat(cvPreviewSeparator, MaxInt(0, separatorWidth-utf8.RuneCountInString(separator)))
+
+	if err = win.SetRow(separatorRow, 1, CmpCommitviewPreviewSeparator, "%v", separator); err != nil {
 		return
 	}
 
+	for lineIndex := uint(0); lineIndex < previewLines && lineIndex < uint(len(lines)); lineIndex++ {
+		if err = win.SetRow(separatorRow+lineIndex+1, 1, CmpCommitviewPreview, " %v", lines[lineIndex]); err != nil {
+			return
+		}
+	}
+
 	return
 }
 
-// RenderHelpBar shows key bindings custom to the commit view
-func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
-	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, []ActionMessage{
-		{action: ActionFilterPrompt, message: "Add Filter"},
-		{action: ActionRemoveFilter, message: "Remove Filter"},
-	})
+// CommitMessagePreview wraps the body of the commit message to the provided width.
+// The summary is used instead if the message has no body
+func CommitMessagePreview(message string, width uint) (lines []string) {
+	messageLines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	bodyLines := messageLines[1:]
 
-	return
-}
+	for len(bodyLines) > 0 && strings.TrimSpace(bodyLines[0]) == "" {
+		bodyLines = bodyLines[1:]
+	}
 
-func newLoadingCommitsRefreshTask(refreshRate time.Duration, channels *Channels) *loadingCommitsRefreshTask {
-	return &loadingCommitsRefreshTask{
-		refreshRate: refreshRate,
-		channels:    channels,
+	if len(bodyLines) == 0 {
+		bodyLines = messageLines[:1]
 	}
+
+	for _, bodyLine := range bodyLines {
+		lines = append(lines, wrapPreviewLine(bodyLine, width)...)
+	}
+
+	return
 }
 
-func (refreshTask *loadingCommitsRefreshTask) start() {
-	log.Debug("Starting commit load refresh task")
+// wrapPreviewLine splits the line into lines no wider than width breaking between words where possible.
+// Words wider than width are split across lines. A blank line is returned as a single empty line
+func wrapPreviewLine(line string, width uint) (lines []string) {
+	width = MaxUint(1, width)
+	var current []rune
 
-	refreshTask.ticker = time.NewTicker(refreshTask.refreshRate)
-	cancelCh := make(chan bool)
-	refreshTask.cancelCh = cancelCh
+	for _, word := range strings.Fields(line) {
+		wordRunes := []rune(word)
 
-	go func(cancelCh <-chan bool) {
-		for {
-			select {
-			case <-refreshTask.ticker.C:
-				log.Debug("Updating display with newly loaded commits")
-				refreshTask.channels.UpdateDisplay()
-			case <-cancelCh:
-				refreshTask.channels.UpdateDisplay()
-				return
-			}
+		if len(current) > 0 && uint(len(current)+1+len(wordRunes)) > width {
+			lines = append(lines, string(current))
+			current = nil
 		}
-	}(cancelCh)
-}
-
-func (refreshTask *loadingCommitsRefreshTask) stop() {
-	log.Debug("Stopping commit load refresh task")
 
-	if refreshTask.ticker != nil {
-		refreshTask.ticker.Stop()
-		refreshTask.cancelCh <- true
-		close(refreshTask.cancelCh)
-		refreshTask.ticker = nil
-	}
-}
+		if len(current) > 0 {
+			current = append(current, ' ')
+		}
 
-// OnRefSelect handles a new ref being selected and fetches/loads the relevant commits to display
-func (commitView *CommitView) OnRefSelect(ref Ref) (err error) {
-	log.Debugf("CommitView loading commits for selected ref %v:%v", ref.Shorthand(), ref.Oid())
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+		for uint(len(current)+len(wordRunes)) > width {
+			splitIndex := int(width) - len(current)
+			lines = append(lines, string(append(current, wordRunes[:splitIndex]...)))
+			current = nil
+			wordRunes = wordRunes[splitIndex:]
+		}
 
-	if commitView.refreshTask != nil {
-		commitView.refreshTask.stop()
+		current = append(current, wordRunes...)
 	}
 
-	refreshTask := newLoadingCommitsRefreshTask(time.Millisecond*cvLoadRefreshMs, commitView.channels)
-	commitView.refreshTask = refreshTask
+	return append(lines, string(current))
+}
 
-	if err = commitView.repoData.LoadCommits(ref); err != nil {
-		return
-	}
+// sampleMerges counts the merge commits in an evenly spaced sample of the commits in the provided range
+func (commitView *CommitView) sampleMerges(startCommit, endCommit uint) (merges, samples uint, err error) {
+	step := MaxUint(1, (endCommit-startCommit)/cvMinimapSamples)
 
-	commitView.activeRef = ref
+	for commitIndex := startCommit; commitIndex < endCommit && samples < cvMinimapSamples; commitIndex += step {
+		var commit *Commit
+		if commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex); err != nil {
+			return
+		}
 
-	refViewData, refViewDataExists := commitView.refViewData[ref.Name()]
-	if !refViewDataExists {
-		refViewData = &referenceViewData{
-			viewPos:        NewViewPosition(),
-			tableFormatter: NewTableFormatter(cvColumnNum),
+		if commitView.repoData.CommitParentCount(commit) > 1 {
+			merges++
 		}
 
-		commitView.refViewData[ref.Name()] = refViewData
+		samples++
 	}
 
-	commitSetState := commitView.repoData.CommitSetState(ref)
+	return
+}
 
-	if commitSetState.loading {
-		commitView.refreshTask.start()
-		commitView.channels.ReportStatus("Loading commits for ref %v", ref.Shorthand())
-	} else {
-		commitView.refreshTask.stop()
+// commitMinimapBucket determines the range of commits represented by a row of a minimap with trackRows rows
+func commitMinimapBucket(trackRows, rowIndex, commitNum uint) (startCommit, endCommit uint) {
+	if trackRows == 0 || rowIndex >= trackRows {
+		return
 	}
 
-	var commit *Commit
-
-	if refViewDataExists {
-		commitIndex := refViewData.viewPos.ActiveRowIndex()
-		commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
-	} else {
-		commit, err = commitView.repoData.Commit(commitView.activeRef.Oid())
-	}
+	if commitNum <= trackRows {
+		if rowIndex < commitNum {
+			return rowIndex, rowIndex + 1
+		}
 
-	if err != nil {
 		return
 	}
 
-	commitView.notifyCommitViewListeners(commit)
+	startCommit = (rowIndex * commitNum) / trackRows
+	endCommit = ((rowIndex + 1) * commitNum) / trackRows
 
 	return
 }
 
-// OnCommitsLoaded stops the refresh task if it's still running
-func (commitView *CommitView) OnCommitsLoaded(ref Ref) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
-
-	if commitView.refreshTask != nil && commitView.activeRef.Name() == ref.Name() {
-		log.Debugf("Commits for ref %v loaded. Stopping display refresh task", ref.Name())
-		commitView.refreshTask.stop()
+// minimapDensityChar returns the character representing the proportion of merges in a sample of commits
+func minimapDensityChar(merges, samples uint) rune {
+	if samples == 0 || merges == 0 {
+		return minimapDensityChars[0]
 	}
 
-	commitSetState := commitView.repoData.CommitSetState(ref)
-	commitView.channels.ReportStatus("Loaded %v commits for ref %v", commitSetState.commitNum, ref.Shorthand())
-}
+	maxIndex := uint(len(minimapDensityChars) - 1)
+	index := MinUint(maxIndex, 1+((merges-1)*maxIndex)/samples)
 
-// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits
-func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+	return minimapDensityChars[index]
+}
+
+func (commitView *CommitView) renderCommits(refViewData *referenceViewData, commits []*Commit, showCommitGraph bool, wrapRows uint) (err error) {
+	viewPos := refViewData.viewPos
+	tableFormatter := refViewData.tableFormatter
+	tableFormatter.Clear()
+
+	startCommitIndex := viewPos.ViewStartRowIndex()
+	commits = commits[:MinUint(uint(len(commits)), tableFormatter.Rows()-wrapRows)]
+
+	var selectedCommit *Commit
+	var selectedRowIndex uint
+	rowIndex := uint(0)
+	commitIndex := startCommitIndex
+	showSignatures := commitView.displaysField(CrfSignature)
+
+	for _, commit := range commits {
+		var graphRow string
+		if showCommitGraph {
+			graphRow, _ = refViewData.commitGraph.Row(commitIndex)
+		}
+
+		if err = commitView.renderCommit(refViewData, rowIndex, commit, graphRow); err != nil {
+			return
+		}
+
+		if showSignatures {
+			commitView.queueSignatureVerification(commit.oid)
+		}
+
+		if wrapRows > 0 && commitIndex == viewPos.ActiveRowIndex() {
+			selectedCommit = commit
+			selectedRowIndex = rowIndex
+			rowIndex += wrapRows
+		}
+
+		rowIndex++
+		commitIndex++
+	}
+
+	if selectedCommit != nil {
+		err = commitView.renderSummaryContinuation(refViewData, selectedRowIndex, selectedCommit, wrapRows)
+	}
+
+	return
+}
+
+// visibleCommits returns up to count commits starting at the provided index
+func (commitView *CommitView) visibleCommits(startCommitIndex, count uint) (commits []*Commit, err error) {
+	commitCh, err := commitView.repoData.Commits(commitView.activeRef, startCommitIndex, count)
+	if err != nil {
+		return
+	}
+
+	for commit := range commitCh {
+		commits = append(commits, commit)
+	}
+
+	return
+}
+
+// selectedSummaryWrapRows returns the number of additional rows required
+// to display the full summary of the selected commit. The commits provided
+// are those rendered starting at startCommitIndex
+func (commitView *CommitView) selectedSummaryWrapRows(refViewData *referenceViewData, commits []*Commit, startCommitIndex uint) (wrapRows uint) {
+	viewPos := refViewData.viewPos
+	activeRowIndex := viewPos.ActiveRowIndex()
+
+	if activeRowIndex < startCommitIndex || activeRowIndex-startCommitIndex >= uint(len(commits)) {
+		return
+	}
+
+	lines, _, err := commitView.summaryContinuation(refViewData, viewPos.SelectedRowIndex(), commits[activeRowIndex-startCommitIndex])
+	if err != nil {
+		log.Errorf("Unable to determine summary continuation: %v", err)
+		return
+	}
+
+	return MinUint(uint(len(lines)), refViewData.tableFormatter.Rows()-1)
+}
+
+// commitIndexAtRow returns the index of the commit displayed on the provided row of the view.
+// Rows containing the wrapped summary of the selected commit belong to the selected commit
+func (commitView *CommitView) commitIndexAtRow(refViewData *referenceViewData, rowIndex uint) uint {
+	viewPos := refViewData.viewPos
+	selectedRowIndex := viewPos.SelectedRowIndex()
+
+	switch {
+	case rowIndex <= selectedRowIndex:
+		return viewPos.ViewStartRowIndex() + rowIndex
+	case rowIndex <= selectedRowIndex+refViewData.wrapRows:
+		return viewPos.ActiveRowIndex()
+	}
+
+	return viewPos.ViewStartRowIndex() + rowIndex - refViewData.wrapRows
+}
+
+// renderSummaryContinuation writes the remainder of the commit summary that didn't fit on the commits row
+// into the subject column of the wrapRows rows following it
+func (commitView *CommitView) renderSummaryContinuation(refViewData *referenceViewData, rowIndex uint, commit *Commit, wrapRows uint) (err error) {
+	lines, indent, err := commitView.summaryContinuation(refViewData, rowIndex, commit)
+	if err != nil {
+		return
+	}
+
+	subjectColIndex, _ := commitView.subjectColumnIndex()
+
+	for lineIndex, line := range lines {
+		if uint(lineIndex) >= wrapRows {
+			break
+		}
+
+		if err = refViewData.tableFormatter.SetCellWithStyle(rowIndex+uint(lineIndex)+1, subjectColIndex, CmpCommitviewSummary,
+			"%v%v", strings.Repeat(" ", int(indent)), line); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// summaryContinuation splits the part of the commit summary that extends beyond the edge of the view
+// into lines of the width available to the summary. The returned indent is the offset of the summary
+// from the start of the subject column
+func (commitView *CommitView) summaryContinuation(refViewData *referenceViewData, rowIndex uint, commit *Commit) (lines []string, indent uint, err error) {
+	subjectColIndex, ok := commitView.subjectColumnIndex()
+	if !ok || commitView.viewDimension.cols < 3 {
+		return
+	}
+
+	cacheEntry := refViewData.rowCacheEntry(commit, commitView.config.GetInt(CfShortOidLength), commitView.showCommitter)
+	summary := []rune(commitView.rowFormat[subjectColIndex].Truncate(cacheEntry.summary))
+	summaryLen := uint(len(summary))
+
+	startColumn, width, err := refViewData.tableFormatter.CellColumns(rowIndex, subjectColIndex, true)
+	if err != nil || width < summaryLen {
+		return
+	}
+
+	indent = width - summaryLen
+	summaryColumn := startColumn + indent
+	lastColumn := refViewData.viewPos.ViewStartColumn() + commitView.viewDimension.cols - 2
+
+	if summaryColumn > lastColumn {
+		return
+	}
+
+	lineWidth := lastColumn - summaryColumn + 1
+
+	for lineStart := lineWidth; lineStart < summaryLen; lineStart += lineWidth {
+		lines = append(lines, string(summary[lineStart:MinUint(lineStart+lineWidth, summaryLen)]))
+	}
+
+	return
+}
+
+// truncateSubjects ends subjects which extend beyond the edge of the view with an ellipsis.
+// The subject of the selected commit is left intact when it is wrapped onto the following rows
+func (commitView *CommitView) truncateSubjects(refViewData *referenceViewData, wrapRows uint) (err error) {
+	subjectColIndex, ok := commitView.subjectColumnIndex()
+	if !ok || commitView.viewDimension.cols < 3 {
+		return
+	}
+
+	tableFormatter := refViewData.tableFormatter
+	lastColumn := refViewData.viewPos.ViewStartColumn() + commitView.viewDimension.cols - 2
+	selectedRowIndex := refViewData.viewPos.SelectedRowIndex()
+
+	for rowIndex := uint(0); rowIndex < tableFormatter.Rows(); rowIndex++ {
+		if wrapRows > 0 && rowIndex == selectedRowIndex {
+			continue
+		}
+
+		if err = tableFormatter.TruncateCell(rowIndex, subjectColIndex, lastColumn, crfEllipsis, true); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (commitView *CommitView) subjectColumnIndex() (colIndex uint, ok bool) {
+	for tokenIndex, token := range commitView.rowFormat {
+		if token.field == CrfSubject {
+			return uint(tokenIndex), true
+		}
+	}
+
+	return
+}
+
+func (commitView *CommitView) renderCommit(refViewData *referenceViewData, rowIndex uint, commit *Commit, graphRow string) (err error) {
+	tableFormatter := refViewData.tableFormatter
+	cacheEntry := refViewData.rowCacheEntry(commit, commitView.config.GetInt(CfShortOidLength), commitView.showCommitter)
+
+	authorComponent := CmpCommitviewAuthor
+	if commitView.config.GetBool(CfAuthorColors) {
+		authorComponent = cacheEntry.authorColor
+	}
+
+	for colIndex, token := range commitView.rowFormat {
+		switch token.field {
+		case CrfOid:
+			err = tableFormatter.SetCellWithStyle(rowIndex, uint(colIndex), CmpCommitviewShortOid, "%v", token.Truncate(cacheEntry.shortID))
+		case CrfDate:
+			dateFormat, ok := commitView.rowDateFormats[commit.oid]
+			if !ok {
+				dateFormat = commitView.dateFormat
+			}
+
+			date := FormatCommitDate(cacheEntry.when, dateFormat, time.Now())
+			err = tableFormatter.SetCellWithStyle(rowIndex, uint(colIndex), CmpCommitviewDate, "%v", token.Truncate(date))
+		case CrfAuthor:
+			err = tableFormatter.SetCellWithStyle(rowIndex, uint(colIndex), authorComponent, "%v", token.Truncate(cacheEntry.author))
+		case CrfSignature:
+			display := signatureStatusDisplay[commitView.signatureStatuses[commit.oid]]
+			err = tableFormatter.SetCellWithStyle(rowIndex, uint(colIndex), display.themeComponentID, "%v", display.letter)
+		case CrfDiffStat:
+			err = commitView.renderDiffStat(tableFormatter, rowIndex, uint(colIndex), commit.oid)
+		case CrfSubject:
+			err = commitView.renderCommitSubject(tableFormatter, rowIndex, uint(colIndex), commit, token.Truncate(cacheEntry.summary), graphRow)
+		default:
+			err = tableFormatter.SetCell(rowIndex, uint(colIndex), "%v", token.literal)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// isHeadCommit returns true if the commit is the commit currently checked out
+func (commitView *CommitView) isHeadCommit(commit *Commit) bool {
+	head := commitView.repoData.Head()
+	return head != nil && head.Oid() != nil && head.Oid().Equal(commit.oid)
+}
+
+// queueSignatureVerification queues the commit with the provided oid for verification
+// if its signature has not already been verified or queued
+func (commitView *CommitView) queueSignatureVerification(oid *Oid) {
+	if _, ok := commitView.signatureStatuses[oid]; !ok {
+		commitView.signatureStatuses[oid] = SsUnknown
+		commitView.unverifiedOids = append(commitView.unverifiedOids, oid)
+	}
+}
+
+// displaysField returns true if the commit row format contains the provided field
+func (commitView *CommitView) displaysField(field CommitRowField) bool {
+	for _, token := range commitView.rowFormat {
+		if token.field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyUnverifiedCommits verifies the signatures of the queued commits in the background
+// and updates the display once all have been verified
+func (commitView *CommitView) verifyUnverifiedCommits() {
+	if len(commitView.unverifiedOids) == 0 {
+		return
+	}
+
+	oids := commitView.unverifiedOids
+	commitView.unverifiedOids = nil
+
+	go func() {
+		for _, oid := range oids {
+			status, err := commitView.repoData.VerifyCommit(oid)
+			if err != nil {
+				log.Errorf("%v", err)
+				status = SsNone
+			}
+
+			commitView.lock.Lock()
+			commitView.signatureStatuses[oid] = status
+			commitView.lock.Unlock()
+		}
+
+		commitView.channels.UpdateDisplay()
+	}()
+}
+
+func (commitView *CommitView) renderDiffStat(tableFormatter *TableFormatter, rowIndex, colIndex uint, oid *Oid) (err error) {
+	diffStat := commitView.diffStatLoader.DiffStat(oid)
+	if diffStat == nil {
+		return tableFormatter.SetCell(rowIndex, colIndex, "")
+	}
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDiffStatAdded, "+%v", diffStat.added); err != nil {
+		return
+	}
+
+	return tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewDiffStatRemoved, " -%v", diffStat.removed)
+}
+
+func (commitView *CommitView) renderCommitSubject(tableFormatter *TableFormatter, rowIndex, colIndex uint, commit *Commit, summary, graphRow string) (err error) {
+	showDecorations := commitView.config.GetBool(CfCommitDecorations)
+
+	if commitView.isHeadCommit(commit) {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewHeadMarker, "%v ", cvHeadMarker); err != nil {
+			return
+		}
+	}
+
+	if commitView.markedOid != nil && commitView.markedOid.Equal(commit.oid) {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewMarkedCommit, "%v ", cvMarkedCommitMarker); err != nil {
+			return
+		}
+	}
+
+	if graphRow != "" {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewGraph, "%v", graphRow); err != nil {
+			return
+		}
+	}
+
+	if showDecorations {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", summary); err != nil {
+			return
+		}
+
+		if decorations := commitView.repoData.RefsAtCommit(commit.oid); len(decorations) > 0 {
+			err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewDecoration, " (%v)", strings.Join(decorations, ", "))
+		}
+
+		return
+	}
+
+	commitRefs := commitView.repoData.RefsForCommit(commit)
+
+	if len(commitRefs.tags) > 0 {
+		for _, tag := range commitRefs.tags {
+			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewTag, "<%v>", tag.Shorthand()); err != nil {
+				return
+			}
+
+			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
+				return
+			}
+		}
+	}
+
+	if len(commitRefs.branches) > 0 {
+		for _, branch := range commitRefs.branches {
+			if branch.IsRemote() {
+				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewLocalBranch, "{%v}", branch.Shorthand()); err != nil {
+					return
+				}
+			} else {
+				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewRemoteBranch, "[%v]", branch.Shorthand()); err != nil {
+					return
+				}
+			}
+
+			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
+				return
+			}
+		}
+	}
+
+	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", summary); err != nil {
+		return
+	}
+
+	return
+}
+
+// SetRowFormat sets the format used to render each commit row
+// An empty format restores the default row format
+func (commitView *CommitView) SetRowFormat(format string) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	commitView.setRowFormat(format)
+	commitView.channels.UpdateDisplay()
+}
+
+func (commitView *CommitView) setRowFormat(format string) {
+	rowFormat := ParseCommitRowFormat(format)
+	if len(rowFormat) == 0 {
+		log.Infof("Empty commit row format. Using default format: %v", cfCommitRowFormatDefaultValue)
+		rowFormat = ParseCommitRowFormat(cfCommitRowFormatDefaultValue)
+	}
+
+	log.Debugf("Setting commit row format: %v", format)
+	commitView.rowFormat = rowFormat
+
+	for _, refViewData := range commitView.refViewData {
+		refViewData.tableFormatter = NewTableFormatter(commitView.columnNum())
+	}
+}
+
+func (commitView *CommitView) columnNum() uint {
+	return uint(len(commitView.rowFormat))
+}
+
+func (commitView *CommitView) onConfigVariableChange(configVariable ConfigVariable) {
+	switch configVariable {
+	case CfCommitRowFormat:
+		commitView.SetRowFormat(commitView.config.GetString(CfCommitRowFormat))
+	case CfAuthorColors, CfCommitDecorations, CfCommitPreview:
+		commitView.channels.UpdateDisplay()
+	case CfShortOidLength:
+		for _, refViewData := range commitView.refViewData {
+			refViewData.clearRowCache()
+		}
+
+		commitView.channels.UpdateDisplay()
+	}
+}
+
+func (commitView *CommitView) generateCommitGraph(commitGraph *CommitGraph, rowNum uint) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+	rowNum = MinUint(rowNum, commitSetState.commitNum)
+
+	for commitIndex := commitGraph.RowNum(); commitIndex < rowNum; commitIndex++ {
+		commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+		if err != nil {
+			log.Errorf("Unable to generate commit graph: %v", err)
+			return
+		}
+
+		var parentIDs []string
+		for _, parentID := range commitView.repoData.CommitParentIDs(commit) {
+			parentIDs = append(parentIDs, parentID.String())
+		}
+
+		commitGraph.AddCommit(commit.oid.String(), parentIDs)
+	}
+}
+
+// title returns the title of the commit view which contains the active ref, its commit count
+// (or loading state) and whether it is ahead or behind its upstream
+func (commitView *CommitView) title(commitSetState CommitSetState) string {
+	var title bytes.Buffer
+
+	title.WriteString(fmt.Sprintf("Commits for %v", commitView.activeRef.Shorthand()))
+
+	if detachedHead := DetachedHeadDescription(commitView.activeRef); detachedHead != "" {
+		title.WriteString(fmt.Sprintf(" (%v)", detachedHead))
+	}
+
+	if commitSetState.loading {
+		title.WriteString(" (loading...)")
+	} else {
+		title.WriteString(fmt.Sprintf(" (%v commits)", commitSetState.commitNum))
+	}
+
+	if commitSetState.loadOptions.sortOrder != CsoDate {
+		title.WriteString(fmt.Sprintf(" (%v order)", CommitSortOrderDisplayName(commitSetState.loadOptions.sortOrder)))
+	}
+
+	if commitSetState.loadOptions.firstParentOnly {
+		title.WriteString(" (first parent)")
+	}
+
+	if commitSetState.loadOptions.hideMerges {
+		title.WriteString(" (no merges)")
+	}
+
+	if ahead, behind, isTrackingBranch := commitView.repoData.AheadBehind(commitView.activeRef); isTrackingBranch {
+		title.WriteString(fmt.Sprintf(" (ahead %v, behind %v)", ahead, behind))
+	}
+
+	return title.String()
+}
+
+// RenderHelpBar shows key bindings custom to the commit view
+func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionFilterPrompt, message: "Add Filter"},
+		{action: ActionDateFilterPrompt, message: "Add Date Filter"},
+		{action: ActionRemoveFilter, message: "Remove Filter"},
+		{action: ActionToggleCommitGraph, message: "Toggle Graph"},
+	})
+
+	return
+}
+
+func newLoadingCommitsRefreshTask(refreshRate time.Duration, channels *Channels) *loadingCommitsRefreshTask {
+	return &loadingCommitsRefreshTask{
+		refreshRate: refreshRate,
+		channels:    channels,
+	}
+}
+
+func (refreshTask *loadingCommitsRefreshTask) start() {
+	log.Debug("Starting commit load refresh task")
+
+	refreshTask.stop()
+
+	ticker := time.NewTicker(refreshTask.refreshRate)
+	cancelCh := make(chan bool)
+	refreshTask.ticker = ticker
+	refreshTask.cancelCh = cancelCh
+
+	go func(ticker *time.Ticker, cancelCh <-chan bool) {
+		for {
+			select {
+			case <-ticker.C:
+				log.Debug("Updating display with newly loaded commits")
+				refreshTask.channels.UpdateDisplay()
+			case <-cancelCh:
+				refreshTask.channels.UpdateDisplay()
+				return
+			}
+		}
+	}(ticker, cancelCh)
+}
+
+// stop cancels the refresh task. It never blocks and is safe to call multiple times
+func (refreshTask *loadingCommitsRefreshTask) stop() {
+	if refreshTask.ticker == nil {
+		return
+	}
+
+	log.Debug("Stopping commit load refresh task")
+
+	refreshTask.ticker.Stop()
+	close(refreshTask.cancelCh)
+	refreshTask.ticker = nil
+	refreshTask.cancelCh = nil
+}
+
+// Dispose stops the refresh task if it's still running, shuts down the diff stat workers
+// and stops listening for config changes
+func (commitView *CommitView) Dispose() {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	for _, configVariable := range commitViewConfigVariables {
+		commitView.config.RemoveOnChangeListener(configVariable, commitView)
+	}
+
+	if commitView.refreshTask != nil {
+		log.Debug("Disposing of CommitView. Stopping display refresh task")
+		commitView.refreshTask.stop()
+	}
+
+	commitView.diffStatLoader.Stop()
+}
+
+// OnRefSelect handles a new ref being selected and fetches/loads the relevant commits to display
+func (commitView *CommitView) OnRefSelect(ref Ref) (err error) {
+	log.Debugf("CommitView loading commits for selected ref %v:%v", ref.Shorthand(), ref.Oid())
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	return commitView.onRefSelect(ref)
+}
+
+func (commitView *CommitView) onRefSelect(ref Ref) (err error) {
+	if commitView.refreshTask != nil {
+		commitView.refreshTask.stop()
+	}
+
+	refreshTask := newLoadingCommitsRefreshTask(time.Millisecond*time.Duration(commitView.config.GetInt(CfCommitRefreshRate)), commitView.channels)
+	commitView.refreshTask = refreshTask
+	commitView.loadError = nil
+	commitView.diffStatLoader.Reset()
+	commitView.signatureStatuses = make(map[*Oid]SignatureStatus)
+	commitView.unverifiedOids = nil
+	commitView.repoData.SetFirstWindowCommitNum(commitView.pageRows())
+
+	if err = commitView.repoData.LoadCommits(ref); err != nil {
+		err = fmt.Errorf("Failed to load commits for ref %v: %v", ref.Shorthand(), err)
+		commitView.setLoadError(err)
+		return
+	}
+
+	commitView.activeRef = ref
+
+	refViewData, refViewDataExists := commitView.refViewData[ref.Name()]
+	if !refViewDataExists {
+		refViewData = newReferenceViewData(commitView.columnNum())
+		commitView.refViewData[ref.Name()] = refViewData
+	}
+
+	commitSetState := commitView.repoData.CommitSetState(ref)
+
+	if commitSetState.loading {
+		commitView.refreshTask.start()
+		commitView.channels.ReportStatus("Loading commits for ref %v", ref.Shorthand())
+	} else {
+		commitView.refreshTask.stop()
+	}
+
+	var commit *Commit
+
+	if refViewDataExists {
+		commitIndex := refViewData.viewPos.ActiveRowIndex()
+
+		if !commitSetState.loading && commitSetState.commitNum > 0 && commitIndex >= commitSetState.commitNum {
+			log.Debugf("Stored active row index %v is no longer valid for ref %v", commitIndex, ref.Name())
+			commitIndex = commitSetState.commitNum - 1
+			refViewData.viewPos.SetActiveRowIndex(commitIndex)
+		}
+
+		commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	} else {
+		commit, err = commitView.repoData.Commit(commitView.activeRef.Oid())
+	}
+
+	if err != nil {
+		return
+	}
+
+	refViewData.selectedOid = commit.oid
+	commitView.notifyCommitViewListeners(commit)
+
+	return
+}
+
+// OnCommitsLoaded stops the refresh task if it's still running and reports the outcome of loading
+func (commitView *CommitView) OnCommitsLoaded(ref Ref, commitSetState CommitSetState, err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.refreshTask != nil && commitView.activeRef.Name() == ref.Name() {
+		log.Debugf("Commits for ref %v loaded. Stopping display refresh task", ref.Name())
+		commitView.refreshTask.stop()
+	}
+
+	defer commitView.channels.UpdateDisplay()
+
+	if err != nil {
+		err = fmt.Errorf("Failed to load commits for ref %v: %v", ref.Shorthand(), err)
+		commitView.channels.ReportError(err)
+
+		if commitView.activeRef != nil && commitView.activeRef.Name() == ref.Name() {
+			commitView.setLoadError(err)
+		}
+
+		return
+	}
+
+	if refViewData, ok := commitView.refViewData[ref.Name()]; ok && refViewData.pendingOid != nil {
+		commitView.restorePendingSelection(ref, refViewData)
+	}
+
+	if commitSetState.moreAvailable {
+		commitView.channels.ReportStatus("Loaded %v commits for ref %v. More commits will be loaded on scrolling", commitSetState.commitNum, ref.Shorthand())
+	} else {
+		commitView.channels.ReportStatus("Loaded %v commits for ref %v", commitSetState.commitNum, ref.Shorthand())
+	}
+}
+
+// OnFirstWindowLoaded updates the display as soon as enough commits have been loaded to fill
+// the view rather than waiting for the refresh task. Any pending selection is restored if possible
+func (commitView *CommitView) OnFirstWindowLoaded(ref Ref) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil || commitView.activeRef.Name() != ref.Name() {
+		return
+	}
+
+	log.Debugf("First window of commits loaded for ref %v", ref.Name())
+
+	if refViewData, ok := commitView.refViewData[ref.Name()]; ok && refViewData.pendingOid != nil {
+		if _, found := commitView.commitIndex(refViewData.pendingOid); found {
+			commitView.restorePendingSelection(ref, refViewData)
+		}
+	}
+
+	commitView.channels.UpdateDisplay()
+}
+
+// setLoadError displays the error in the view until it is dismissed or commits are loaded again
+func (commitView *CommitView) setLoadError(err error) {
+	log.Error(err)
+	commitView.loadError = err
+	commitView.channels.UpdateDisplay()
+}
+
+// restorePendingSelection selects the commit that was selected before the commits of the ref were reloaded
+func (commitView *CommitView) restorePendingSelection(ref Ref, refViewData *referenceViewData) {
+	oid := refViewData.pendingOid
+	refViewData.pendingOid = nil
+
+	if commitView.activeRef.Name() != ref.Name() {
+		return
+	}
+
+	commitIndex, found := commitView.commitIndex(oid)
+	if !found {
+		log.Debugf("Previously selected commit %v is not loaded for ref %v", oid, ref.Name())
+		return
+	}
+
+	if err := commitView.selectCommit(commitIndex); err != nil {
+		commitView.channels.ReportError(err)
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+}
+
+// OnRefsChanged discards the view state of removed refs. If the active ref has been
+// removed then HEAD is displayed instead. The view state of the active ref is retained
+// if HEAD can't be displayed so the view remains usable
+func (commitView *CommitView) OnRefsChanged(addedRefs, removedRefs []Ref, updatedRefs []*UpdatedRef) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	var activeRefViewData *referenceViewData
+	activeRefRemoved := false
+
+	for _, removedRef := range removedRefs {
+		if commitView.activeRef != nil && commitView.activeRef.Name() == removedRef.Name() {
+			activeRefViewData = commitView.refViewData[removedRef.Name()]
+			activeRefRemoved = true
+		}
+
+		delete(commitView.refViewData, removedRef.Name())
+	}
+
+	if !activeRefRemoved {
+		return
+	}
+
+	activeRefName := commitView.activeRef.Name()
+	restoreActiveRefViewData := func() {
+		if _, exists := commitView.refViewData[activeRefName]; !exists && activeRefViewData != nil {
+			commitView.refViewData[activeRefName] = activeRefViewData
+		}
+	}
+
+	head := commitView.repoData.Head()
+	if head == nil {
+		log.Errorf("Active ref %v was removed but HEAD is not loaded", activeRefName)
+		restoreActiveRefViewData()
+		return
+	}
+
+	log.Infof("Active ref %v was removed. Displaying HEAD %v", activeRefName, head.Name())
+	removedRefName := commitView.activeRef.Shorthand()
+
+	if err := commitView.onRefSelect(head); err != nil {
+		restoreActiveRefViewData()
+		commitView.channels.ReportError(err)
+		return
+	}
+
+	commitView.channels.ReportStatus("Ref %v no longer exists. Displaying HEAD", removedRefName)
+	commitView.channels.UpdateDisplay()
+}
+
+// OnHeadChanged does nothing
+func (commitView *CommitView) OnHeadChanged(oldHead, newHead Ref) {
+
+}
+
+// OnTrackingBranchesUpdated does nothing
+func (commitView *CommitView) OnTrackingBranchesUpdated(trackingBranches []*LocalBranch) {
+
+}
+
+// OnCommitsUpdated adjusts the active row index to take account of the newly loaded commits
+func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if refViewData, ok := commitView.refViewData[ref.Name()]; ok {
+		refViewData.commitGraph.Clear()
+		refViewData.clearRowCache()
+	}
 
 	if commitView.activeRef.Name() == ref.Name() {
 		commitSetState := commitView.repoData.CommitSetState(ref)
@@ -382,429 +1499,1726 @@ func (commitView *CommitView) OnCommitsUpdated(ref Ref) {
 			return
 		}
 
-		viewPos := commitView.ViewPos()
-		if viewPos.ActiveRowIndex() > commitSetState.commitNum {
-			viewPos.SetActiveRowIndex(uint(MaxInt(0, int(commitSetState.commitNum)-1)))
-		}
+		if refViewData, ok := commitView.refViewData[ref.Name()]; ok {
+			commitView.trackSelectedCommit(refViewData, commitSetState)
+		}
+
+		viewPos := commitView.ViewPos()
+		if viewPos.ActiveRowIndex() > commitSetState.commitNum {
+			viewPos.SetActiveRowIndex(uint(MaxInt(0, int(commitSetState.commitNum)-1)))
+		}
+
+		if err := commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			commitView.channels.ReportError(err)
+		}
+
+		commitView.channels.UpdateDisplay()
+	}
+}
+
+// OnActiveChange updates whether this view is currently active
+func (commitView *CommitView) OnActiveChange(active bool) {
+	log.Debugf("CommitView active: %v", active)
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	commitView.active = active
+}
+
+// ViewID returns the ViewID for the commit view
+func (commitView *CommitView) ViewID() ViewID {
+	return ViewCommit
+}
+
+// RegisterCommitViewListener accepts a listener to be notified when a commit is selected
+func (commitView *CommitView) RegisterCommitViewListener(commitViewListener CommitViewListener) {
+	if commitViewListener == nil {
+		return
+	}
+
+	log.Debugf("Registering CommitViewListener %T", commitViewListener)
+
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	commitView.commitViewListeners = append(commitView.commitViewListeners, commitViewListener)
+}
+
+func (commitView *CommitView) notifyCommitViewListeners(commit *Commit) {
+	log.Debugf("Notifying commit listeners of selected commit %v", commit.oid.String())
+
+	go func() {
+		for _, commitViewListener := range commitView.commitViewListeners {
+			if err := commitViewListener.OnCommitSelected(commit); err != nil {
+				commitView.channels.ReportError(err)
+			}
+		}
+	}()
+}
+
+func (commitView *CommitView) selectCommit(lineIndex uint) (err error) {
+	commitIndex := lineIndex
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	if commitSetState.commitNum == 0 {
+		return fmt.Errorf("Cannot select commit as there are no commits for ref %v", commitView.activeRef.Name())
+	}
+
+	if commitIndex >= commitSetState.commitNum {
+		return fmt.Errorf("Invalid commitIndex: %v, only %v commits are loaded", commitIndex, commitSetState.commitNum)
+	}
+
+	selectedCommit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		return
+	}
+
+	commitView.ViewPos().SetActiveRowIndex(lineIndex)
+	commitView.refViewData[commitView.activeRef.Name()].selectedOid = selectedCommit.oid
+	commitView.notifyCommitViewListeners(selectedCommit)
+
+	if commitSetState.moreAvailable && commitSetState.commitNum-commitIndex <= commitView.pageRows() {
+		err = commitView.repoData.LoadMoreCommits(commitView.activeRef)
+	}
+
+	return
+}
+
+// trackSelectedCommit moves the active row to the last selected commit if commits have been
+// added before it since it was selected, so the selection follows the commit rather than its index
+func (commitView *CommitView) trackSelectedCommit(refViewData *referenceViewData, commitSetState CommitSetState) {
+	if refViewData.selectedOid == nil || commitSetState.commitNum == 0 {
+		return
+	}
+
+	viewPos := refViewData.viewPos
+
+	if commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, viewPos.ActiveRowIndex()); err == nil && commit.oid.Equal(refViewData.selectedOid) {
+		return
+	}
+
+	if commitIndex, found := commitView.commitIndex(refViewData.selectedOid); found {
+		log.Debugf("Selected commit %v has moved from index %v to %v", refViewData.selectedOid, viewPos.ActiveRowIndex(), commitIndex)
+		viewPos.SetActiveRowIndex(commitIndex)
+	} else if !commitSetState.loading {
+		log.Debugf("Selected commit %v is no longer loaded", refViewData.selectedOid)
+		refViewData.selectedOid = nil
+	}
+}
+
+// jumpToCommit selects the commit at the provided index and records the
+// previously selected commit in the selection history of the active ref
+func (commitView *CommitView) jumpToCommit(commitIndex uint) (err error) {
+	prevIndex := commitView.ViewPos().ActiveRowIndex()
+
+	if err = commitView.selectCommit(commitIndex); err != nil {
+		return
+	}
+
+	if prevIndex != commitIndex {
+		commitView.recordSelection(prevIndex)
+	}
+
+	return
+}
+
+// recordSelection adds the commit at the provided index to the selection history of the active ref
+func (commitView *CommitView) recordSelection(commitIndex uint) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		log.Debugf("Unable to record selection of commit at index %v: %v", commitIndex, err)
+		return
+	}
+
+	commitView.refViewData[commitView.activeRef.Name()].recordSelection(commit.oid)
+}
+
+func (commitView *CommitView) createCommitViewListenerView(viewID ViewID, commits ...*Commit) {
+	var viewArgs []interface{}
+	for _, commit := range commits {
+		viewArgs = append(viewArgs, commit.oid.String())
+	}
+
+	createViewArgs := CreateViewArgs{
+		viewID:   viewID,
+		viewArgs: viewArgs,
+		registerViewListener: func(observer interface{}) (err error) {
+			if observer == nil {
+				return fmt.Errorf("Invalid CommitViewListener: %v", observer)
+			}
+
+			if commitViewListener, ok := observer.(CommitViewListener); ok {
+				commitView.RegisterCommitViewListener(commitViewListener)
+
+				if treeView, isTreeView := observer.(*TreeView); isTreeView {
+					treeView.RegisterFileHistoryListener(commitView)
+				}
+			} else {
+				err = fmt.Errorf("Observer is not a CommitViewListener but has type %T", observer)
+			}
+
+			return
+		},
+	}
+
+	commitView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: createViewArgs,
+				orientation:    CoDynamic,
+			},
+		},
+	})
+}
+
+// ViewPos returns the current view position
+func (commitView *CommitView) ViewPos() ViewPos {
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	return refViewData.viewPos
+}
+
+// OnSearchMatch updates the view position when there is a search match
+func (commitView *CommitView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	viewPos := commitView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Selected ref has changed since search started")
+		return
+	}
+
+	if err := commitView.jumpToCommit(matchLineIndex); err != nil {
+		log.Errorf("Unable to select search match: %v", err)
+	}
+}
+
+// Line returns the rendered line at the index provided
+func (commitView *CommitView) Line(lineIndex uint) (line string) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	lineNumber := commitView.lineNumber()
+
+	if lineIndex >= lineNumber {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
+	if !ok {
+		log.Errorf("Not refViewData for ref %v", commitView.activeRef.Name())
+		return
+	}
+
+	tableFormatter := refViewData.tableFormatter
+	tableFormatter.Clear()
+
+	commitIndex := lineIndex
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		log.Errorf("Error when retrieving commit during search: %v", err)
+		return
+	}
+
+	if err = commitView.renderCommit(refViewData, 0, commit, ""); err != nil {
+		log.Errorf("Error when rendering commit: %v", err)
+		return
+	}
+
+	line, err = tableFormatter.RowString(0)
+	if err != nil {
+		log.Errorf("Error when retrieving row string: %v", err)
+		return
+	}
+
+	if commitView.searchMessageBodies {
+		if body := refViewData.messageBody(commit); body != "" {
+			line = line + " " + body
+		}
+	}
+
+	return
+}
+
+// LineNumber returns the total number of rendered lines the commit view has
+func (commitView *CommitView) LineNumber() (lineNumber uint) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	return commitView.lineNumber()
+}
+
+// pageRows returns the number of commits visible in the view
+// excluding the rows used by the border
+func (commitView *CommitView) pageRows() uint {
+	if commitView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return commitView.viewDimension.rows - 2 - commitView.previewRows()
+}
+
+// previewRows returns the number of rows occupied by the commit message preview including its separator.
+// The preview is hidden if it's disabled or there isn't space for at least one commit row above it
+func (commitView *CommitView) previewRows() uint {
+	previewLines := commitView.config.GetInt(CfCommitPreview)
+	if previewLines <= 0 || commitView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	previewRows := uint(previewLines) + 1
+	if commitView.viewDimension.rows-2 <= previewRows {
+		return 0
+	}
+
+	return previewRows
+}
+
+// commitIndex returns the index of the commit with the provided oid in the commit set of the active ref
+func (commitView *CommitView) commitIndex(oid *Oid) (index uint, found bool) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	for commitIndex := uint(0); commitIndex < commitSetState.commitNum; commitIndex++ {
+		commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+		if err != nil {
+			break
+		}
+
+		if commit.oid.Equal(oid) {
+			return commitIndex, true
+		}
+	}
+
+	return
+}
+
+func (commitView *CommitView) lineNumber() (lineNumber uint) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+	lineNum := commitSetState.commitNum
+
+	return lineNum
+}
+
+// HandleEvent reacts to an event
+func (commitView *CommitView) HandleEvent(event Event) (err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	switch event.EventType {
+	case ViewRemovedEvent:
+		commitView.removeCommitViewListeners(event.Args)
+	}
+
+	return
+}
+
+func (commitView *CommitView) removeCommitViewListeners(views []interface{}) {
+	for _, view := range views {
+		if commitViewListener, ok := view.(CommitViewListener); ok {
+			commitView.removeCommitViewListener(commitViewListener)
+		}
+	}
+}
+
+func (commitView *CommitView) removeCommitViewListener(commitViewListener CommitViewListener) {
+	for index, listener := range commitView.commitViewListeners {
+		if commitViewListener == listener {
+			log.Debugf("Removing CommitViewListener %T", commitViewListener)
+			commitView.commitViewListeners = append(commitView.commitViewListeners[:index], commitView.commitViewListeners[index+1:]...)
+			break
+		}
+	}
+}
+
+// HandleAction checks if commit view supports this action and if it does executes it
+func (commitView *CommitView) HandleAction(action Action) (err error) {
+	log.Debugf("CommitView handling action %v", action)
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	if handler, ok := commitView.handlers[action.ActionType]; ok {
+		err = handler(commitView, action)
+	} else {
+		_, err = commitView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+func moveUpCommit(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v commits", ActionCount(action))
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownCommit(commitView *CommitView, action Action) (err error) {
+	lineNumber := commitView.lineNumber()
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MoveLinesDown(ActionCount(action), lineNumber) {
+		log.Debugf("Moving down %v commits", ActionCount(action))
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func (commitView *CommitView) isMergeCommit(commitIndex uint) bool {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		return false
+	}
+
+	return commitView.repoData.CommitParentCount(commit) > 1
+}
+
+func moveToNextMergeCommit(commitView *CommitView, action Action) (err error) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	for commitIndex := commitView.ViewPos().ActiveRowIndex() + 1; commitIndex < commitSetState.commitNum; commitIndex++ {
+		if commitView.isMergeCommit(commitIndex) {
+			log.Debugf("Moving to next merge commit at index %v", commitIndex)
+
+			if err = commitView.jumpToCommit(commitIndex); err != nil {
+				return
+			}
+
+			commitView.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	commitView.channels.ReportStatus("No more merge commits loaded")
+
+	return
+}
+
+func moveToPrevMergeCommit(commitView *CommitView, action Action) (err error) {
+	for commitIndex := commitView.ViewPos().ActiveRowIndex(); commitIndex > 0; commitIndex-- {
+		if commitView.isMergeCommit(commitIndex - 1) {
+			log.Debugf("Moving to previous merge commit at index %v", commitIndex-1)
+
+			if err = commitView.jumpToCommit(commitIndex - 1); err != nil {
+				return
+			}
+
+			commitView.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	commitView.channels.ReportStatus("No previous merge commits")
+
+	return
+}
+
+// authorEmail returns the email of the author of the commit at the provided index
+func (commitView *CommitView) authorEmail(commitIndex uint) (email string, err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
+	if err != nil {
+		return
+	}
+
+	return strings.ToLower(commit.commit.Author().Email), nil
+}
+
+func (commitView *CommitView) isAuthorCommit(commitIndex uint, email string) bool {
+	commitEmail, err := commitView.authorEmail(commitIndex)
+	return err == nil && commitEmail == email
+}
+
+func moveToNextAuthorCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
+
+	email, err := commitView.authorEmail(commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	for commitIndex := commitView.ViewPos().ActiveRowIndex() + 1; commitIndex < commitSetState.commitNum; commitIndex++ {
+		if commitView.isAuthorCommit(commitIndex, email) {
+			log.Debugf("Moving to next commit by author %v at index %v", email, commitIndex)
+
+			if err = commitView.jumpToCommit(commitIndex); err != nil {
+				return
+			}
+
+			commitView.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	commitView.channels.ReportStatus("No more commits by %v loaded", email)
+
+	return
+}
+
+func moveToPrevAuthorCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
+
+	email, err := commitView.authorEmail(commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	for commitIndex := commitView.ViewPos().ActiveRowIndex(); commitIndex > 0; commitIndex-- {
+		if commitView.isAuthorCommit(commitIndex-1, email) {
+			log.Debugf("Moving to previous commit by author %v at index %v", email, commitIndex-1)
+
+			if err = commitView.jumpToCommit(commitIndex - 1); err != nil {
+				return
+			}
+
+			commitView.channels.UpdateDisplay()
+			return
+		}
+	}
+
+	commitView.channels.ReportStatus("No previous commits by %v", email)
+
+	return
+}
+
+// selectPrevSelection returns to the commit that was selected before the most recent jump
+func selectPrevSelection(commitView *CommitView, action Action) (err error) {
+	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
+	if !ok {
+		return
+	}
+
+	for {
+		oid, ok := refViewData.popSelection()
+		if !ok {
+			commitView.channels.ReportStatus("No previous selection")
+			return
+		}
+
+		if commitIndex, found := commitView.commitIndex(oid); found {
+			log.Debugf("Returning to previous selection %v at index %v", oid, commitIndex)
+
+			if err = commitView.selectCommit(commitIndex); err != nil {
+				return
+			}
+
+			commitView.channels.UpdateDisplay()
+			return
+		}
+	}
+}
+
+func dismissLoadError(commitView *CommitView, action Action) (err error) {
+	if commitView.loadError != nil {
+		commitView.loadError = nil
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpCommitPage(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MovePageUp(commitView.pageRows()) {
+		log.Debug("Moving up one page")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
+	lineNumber := commitView.lineNumber()
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MovePageDown(commitView.pageRows(), lineNumber) {
+		log.Debug("Moving down one page")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpCommitHalfPage(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MovePageUp(commitView.pageRows() / 2) {
+		log.Debug("Moving up one half page")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownCommitHalfPage(commitView *CommitView, action Action) (err error) {
+	lineNumber := commitView.lineNumber()
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MovePageDown(commitView.pageRows()/2, lineNumber) {
+		log.Debug("Moving down one half page")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollCommitViewRight(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+	viewPos.MovePageRight(commitView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollCommitViewLeft(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MovePageLeft(commitView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstCommit(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+	prevIndex := viewPos.ActiveRowIndex()
+
+	if viewPos.MoveToFirstLine() {
+		log.Debug("Moving up to first commit")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.recordSelection(prevIndex)
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastCommit(commitView *CommitView, action Action) (err error) {
+	lineNumber := commitView.lineNumber()
+	viewPos := commitView.ViewPos()
+	prevIndex := viewPos.ActiveRowIndex()
+
+	if viewPos.MoveToLastLine(lineNumber) {
+		log.Debug("Moving to last commit")
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.recordSelection(prevIndex)
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func addCommitFilter(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected filter query argument")
+	}
+
+	query, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected filter query argument to have type string")
+	}
+
+	commitFilter, errors := CreateCommitFilter(query)
+	if len(errors) > 0 {
+		commitView.channels.ReportErrors(errors)
+		return
+	} else if commitFilter == nil {
+		log.Debugf("Query string does not define commit filter: \"%v\"", query)
+		return
+	}
+
+	return commitView.applyCommitFilter(commitFilter)
+}
+
+func addCommitDateFilter(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected date range argument")
+	}
+
+	dateRange, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected date range argument to have type string")
+	}
+
+	from, to, err := ParseDateRange(dateRange)
+	if err != nil {
+		return
+	}
+
+	return commitView.setDateFilter(from, to)
+}
+
+// SetDateFilter filters the commits displayed to those with an author date within the provided range
+// A zero from or to date leaves that end of the range open
+func (commitView *CommitView) SetDateFilter(from, to time.Time) (err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return fmt.Errorf("No ref selected")
+	}
+
+	return commitView.setDateFilter(from, to)
+}
+
+func (commitView *CommitView) setDateFilter(from, to time.Time) (err error) {
+	if from.IsZero() && to.IsZero() {
+		log.Debugf("Date range is unbounded - no filter applied")
+		return
+	}
+
+	log.Debugf("Applying date filter from %v to %v", from, to)
+
+	return commitView.applyCommitFilter(NewDateRangeCommitFilter(from, to))
+}
+
+func addCommitPathFilter(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected path argument")
+	}
+
+	path, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected path argument to have type string")
+	}
+
+	return commitView.applyPathFilter(path)
+}
+
+// OnFileHistoryRequested filters the displayed commits to those which modified the provided path
+func (commitView *CommitView) OnFileHistoryRequested(path string) (err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	return commitView.applyPathFilter(path)
+}
+
+func (commitView *CommitView) applyPathFilter(path string) (err error) {
+	path = NormalisePath(path)
+	if path == "" {
+		return fmt.Errorf("Invalid path: Path cannot be empty")
+	}
+
+	log.Debugf("Filtering commits to those which modified path %v", path)
+
+	return commitView.applyCommitFilter(NewPathCommitFilter(commitView.repoData, path))
+}
+
+func (commitView *CommitView) applyCommitFilter(commitFilter *CommitFilter) (err error) {
+	if err = commitView.repoData.AddCommitFilter(commitView.activeRef, commitFilter); err != nil {
+		return
+	}
+
+	commitView.ViewPos().SetActiveRowIndex(0)
+
+	go func() {
+		// TODO: Works in practice, but there is no guarantee the filtered commit set will have
+		// been populated after 250ms. Need an event based mechanism to be notified when a filtered
+		// set has started to be populated so that the first commit can be selected
+		time.Sleep(250 * time.Millisecond)
+		commitView.lock.Lock()
+		defer commitView.lock.Unlock()
+
+		if err := commitView.selectCommit(commitView.ViewPos().ActiveRowIndex()); err != nil {
+			log.Errorf("Unable to select commit after filter has been applied: %v", err)
+		}
+	}()
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func removeCommitFilter(commitView *CommitView, action Action) (err error) {
+	selectedCommit, selectedCommitErr := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+
+	if err = commitView.repoData.RemoveCommitFilter(commitView.activeRef); err != nil {
+		return
+	}
+
+	commitIndex := uint(0)
+
+	if selectedCommitErr == nil {
+		if index, found := commitView.commitIndex(selectedCommit.oid); found {
+			commitIndex = index
+		}
+	}
+
+	if err = commitView.selectCommit(commitIndex); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func gotoCommit(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected commit id argument")
+	}
+
+	commitID, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected commit id argument to have type string")
+	}
+
+	oid, err := commitView.repoData.ResolveOid(commitID)
+	if err != nil {
+		return
+	}
+
+	commitIndex, found := commitView.commitIndex(oid)
+	if !found {
+		commitView.channels.ReportStatus("Commit %v not loaded yet", oid.ShortID())
+		return
+	}
+
+	if err = commitView.jumpToCommit(commitIndex); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// selectHead displays the commits of the ref HEAD points to and selects the commit HEAD references
+func selectHead(commitView *CommitView, action Action) (err error) {
+	head := commitView.repoData.Head()
+	if head == nil {
+		return fmt.Errorf("HEAD has not been loaded")
+	}
+
+	if commitView.activeRef == nil || commitView.activeRef.Name() != head.Name() {
+		if err = commitView.onRefSelect(head); err != nil {
+			return
+		}
+	}
+
+	commitIndex, found := commitView.commitIndex(head.Oid())
+	if !found {
+		log.Debugf("HEAD commit %v not loaded yet. Selecting once loaded", head.Oid())
+		commitView.refViewData[head.Name()].pendingOid = head.Oid()
+		return
+	}
+
+	if err = commitView.jumpToCommit(commitIndex); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func createTag(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected tag name argument")
+	}
+
+	tagName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected tag name argument to have type string")
+	}
+
+	tagName = strings.TrimSpace(tagName)
+	if err = ValidateRefName(tagName); err != nil {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.channels.RunOperation(fmt.Sprintf("Creating tag %v", tagName), func() (status string, err error) {
+		if err = commitView.repoData.CreateTag(tagName, commit.oid); err != nil {
+			return
+		}
+
+		return fmt.Sprintf("Created tag %v at commit %v", tagName, commit.oid.ShortID()), nil
+	})
+
+	return
+}
+
+// offerCheckout asks the user whether the provided ref should be checked out and checks it out if so
+func (commitView *CommitView) offerCheckout(ref Ref) {
+	if !<-commitView.channels.Confirm(fmt.Sprintf("Checkout %v?", ref.Shorthand())) {
+		return
+	}
+
+	commitView.channels.RunOperation(fmt.Sprintf("Checking out %v", ref.Shorthand()), func() (status string, err error) {
+		if err = commitView.repoData.Checkout(ref); err != nil {
+			return
+		}
+
+		return fmt.Sprintf("Checked out %v", ref.Shorthand()), nil
+	})
+}
+
+func createBranch(commitView *CommitView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected branch name argument")
+	}
+
+	branchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected branch name argument to have type string")
+	}
+
+	branchName = strings.TrimSpace(branchName)
+	if err = ValidateRefName(branchName); err != nil {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.channels.RunOperation(fmt.Sprintf("Creating branch %v", branchName), func() (status string, err error) {
+		branch, err := commitView.repoData.CreateBranch(branchName, commit.oid)
+		if err != nil {
+			return
+		}
+
+		go commitView.offerCheckout(branch)
+
+		return fmt.Sprintf("Created branch %v at commit %v", branchName, commit.oid.ShortID()), nil
+	})
+
+	return
+}
+
+// bisectDescription describes the current bisect range and the number of steps remaining
+func (commitView *CommitView) bisectDescription() string {
+	bisectStep := commitView.bisectStep
+	if bisectStep == nil {
+		return "Bisecting"
+	}
+
+	shortIDLength := commitView.config.GetInt(CfShortOidLength)
+
+	if bisectStep.Finished() {
+		return fmt.Sprintf("Bisect found first bad commit %v", abbreviateID(bisectStep.firstBadID, shortIDLength))
+	}
+
+	var description bytes.Buffer
+	description.WriteString("Bisecting")
+
+	if bisectStep.badID != "" {
+		description.WriteString(fmt.Sprintf(" bad: %v", abbreviateID(bisectStep.badID, shortIDLength)))
+	}
+
+	if goodNum := len(bisectStep.goodIDs); goodNum > 0 {
+		description.WriteString(fmt.Sprintf(" good: %v", abbreviateID(bisectStep.goodIDs[0], shortIDLength)))
+
+		if goodNum > 1 {
+			description.WriteString(fmt.Sprintf(" (+%v)", goodNum-1))
+		}
+	}
+
+	if bisectStep.nextID != "" {
+		description.WriteString(fmt.Sprintf(" - %v revisions left (roughly %v steps)", bisectStep.revisionsLeft, bisectStep.stepsLeft))
+	}
+
+	return description.String()
+}
+
+func abbreviateID(id string, length int) string {
+	if length > 0 && len(id) > length {
+		return id[:length]
+	}
+
+	return id
+}
+
+func bisectStart(commitView *CommitView, action Action) (err error) {
+	if commitView.bisecting {
+		commitView.channels.ReportStatus("Bisect already in progress")
+		return
+	}
+
+	go func() {
+		if !<-commitView.channels.Confirm("Start bisect?") {
+			return
+		}
+
+		commitView.channels.RunOperation("Starting bisect", func() (status string, err error) {
+			if err = commitView.repoData.BisectStart(); err != nil {
+				return
+			}
+
+			commitView.lock.Lock()
+			commitView.bisecting = true
+			commitView.bisectStep = nil
+			commitView.lock.Unlock()
+
+			commitView.channels.UpdateDisplay()
+
+			return "Bisect started. Mark commits as good or bad", nil
+		})
+	}()
+
+	return
+}
+
+func bisectGood(commitView *CommitView, action Action) (err error) {
+	return commitView.bisectMark("good", commitView.repoData.BisectGood)
+}
+
+func bisectBad(commitView *CommitView, action Action) (err error) {
+	return commitView.bisectMark("bad", commitView.repoData.BisectBad)
+}
+
+// bisectMark marks the selected commit using the provided function and selects
+// the commit bisect wants tested next, or the first bad commit once bisect has finished
+func (commitView *CommitView) bisectMark(term string, mark func(*Oid) (*BisectStep, error)) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.channels.RunOperation(fmt.Sprintf("Marking commit %v as %v", commit.oid.ShortID(), term), func() (status string, err error) {
+		bisectStep, err := mark(commit.oid)
+		if err != nil {
+			return
+		}
+
+		commitView.onBisectStep(bisectStep)
 
-		if err := commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			commitView.channels.ReportError(err)
-		}
+		return bisectStep.output, nil
+	})
 
-		commitView.channels.UpdateDisplay()
-	}
+	return
 }
 
-// OnActiveChange updates whether this view is currently active
-func (commitView *CommitView) OnActiveChange(active bool) {
-	log.Debugf("CommitView active: %v", active)
+func (commitView *CommitView) onBisectStep(bisectStep *BisectStep) {
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
+	defer commitView.channels.UpdateDisplay()
 
-	commitView.active = active
-}
+	commitView.bisecting = true
+	commitView.bisectStep = bisectStep
 
-// ViewID returns the ViewID for the commit view
-func (commitView *CommitView) ViewID() ViewID {
-	return ViewCommit
-}
+	selectID := bisectStep.nextID
+	if bisectStep.Finished() {
+		selectID = bisectStep.firstBadID
+	}
 
-// RegisterCommitViewListener accepts a listener to be notified when a commit is selected
-func (commitView *CommitView) RegisterCommitViewListener(commitViewListener CommitViewListener) {
-	if commitViewListener == nil {
+	if selectID == "" || commitView.activeRef == nil {
 		return
 	}
 
-	log.Debugf("Registering CommitViewListener %T", commitViewListener)
+	oid, err := commitView.repoData.ResolveOid(selectID)
+	if err != nil {
+		log.Errorf("Unable to resolve bisect commit %v: %v", selectID, err)
+		return
+	}
 
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+	commitIndex, found := commitView.commitIndex(oid)
+	if !found {
+		log.Debugf("Bisect commit %v is not loaded for ref %v", oid, commitView.activeRef.Name())
+		return
+	}
 
-	commitView.commitViewListeners = append(commitView.commitViewListeners, commitViewListener)
+	if err = commitView.jumpToCommit(commitIndex); err != nil {
+		commitView.channels.ReportError(err)
+	}
 }
 
-func (commitView *CommitView) notifyCommitViewListeners(commit *Commit) {
-	log.Debugf("Notifying commit listeners of selected commit %v", commit.commit.Id().String())
-
+func bisectReset(commitView *CommitView, action Action) (err error) {
 	go func() {
-		for _, commitViewListener := range commitView.commitViewListeners {
-			if err := commitViewListener.OnCommitSelected(commit); err != nil {
-				commitView.channels.ReportError(err)
-			}
+		if !<-commitView.channels.Confirm("Reset bisect?") {
+			return
 		}
+
+		commitView.channels.RunOperation("Resetting bisect", func() (status string, err error) {
+			if err = commitView.repoData.BisectReset(); err != nil {
+				return
+			}
+
+			commitView.lock.Lock()
+			commitView.bisecting = false
+			commitView.bisectStep = nil
+			commitView.lock.Unlock()
+
+			commitView.channels.UpdateDisplay()
+
+			return "Bisect reset", nil
+		})
 	}()
+
+	return
 }
 
-func (commitView *CommitView) selectCommit(lineIndex uint) (err error) {
-	commitIndex := lineIndex
-	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+func toggleCommitGraph(commitView *CommitView, action Action) (err error) {
+	commitView.showCommitGraph = !commitView.showCommitGraph
+	log.Debugf("Commit graph display toggled: %v", commitView.showCommitGraph)
+	commitView.channels.UpdateDisplay()
 
-	if commitSetState.commitNum == 0 {
-		return fmt.Errorf("Cannot select commit as there are no commits for ref %v", commitView.activeRef.Name())
+	return
+}
+
+func toggleCommitOrder(commitView *CommitView, action Action) (err error) {
+	commitSortOrder := CsoTopological
+	if commitView.repoData.CommitSetState(commitView.activeRef).loadOptions.sortOrder == CsoTopological {
+		commitSortOrder = CsoDate
 	}
 
-	if commitIndex >= commitSetState.commitNum {
-		return fmt.Errorf("Invalid commitIndex: %v, only %v commits are loaded", commitIndex, commitSetState.commitNum)
+	if err = commitView.reloadCommits(func() error {
+		return commitView.repoData.SetCommitSortOrder(commitView.activeRef, commitSortOrder)
+	}); err != nil {
+		return
 	}
 
-	selectedCommit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
-	if err != nil {
+	commitView.channels.ReportStatus("Loading commits for ref %v in %v order", commitView.activeRef.Shorthand(), CommitSortOrderDisplayName(commitSortOrder))
+
+	return
+}
+
+func toggleFirstParent(commitView *CommitView, action Action) (err error) {
+	firstParentOnly := !commitView.repoData.CommitSetState(commitView.activeRef).loadOptions.firstParentOnly
+
+	if err = commitView.reloadCommits(func() error {
+		return commitView.repoData.SetFirstParentOnly(commitView.activeRef, firstParentOnly)
+	}); err != nil {
 		return
 	}
 
-	commitView.ViewPos().SetActiveRowIndex(lineIndex)
-	commitView.notifyCommitViewListeners(selectedCommit)
+	if firstParentOnly {
+		commitView.channels.ReportStatus("Loading first parent commits for ref %v", commitView.activeRef.Shorthand())
+	} else {
+		commitView.channels.ReportStatus("Loading all commits for ref %v", commitView.activeRef.Shorthand())
+	}
 
 	return
 }
 
-func (commitView *CommitView) createCommitViewListenerView(commit *Commit) {
-	createViewArgs := CreateViewArgs{
-		viewID:   ViewDiff,
-		viewArgs: []interface{}{commit.oid.String()},
-		registerViewListener: func(observer interface{}) (err error) {
-			if observer == nil {
-				return fmt.Errorf("Invalid CommitViewListener: %v", observer)
-			}
+func toggleHideMerges(commitView *CommitView, action Action) (err error) {
+	hideMerges := !commitView.repoData.CommitSetState(commitView.activeRef).loadOptions.hideMerges
 
-			if commitViewListener, ok := observer.(CommitViewListener); ok {
-				commitView.RegisterCommitViewListener(commitViewListener)
-			} else {
-				err = fmt.Errorf("Observer is not a CommitViewListener but has type %T", observer)
-			}
+	if err = commitView.reloadCommits(func() error {
+		return commitView.repoData.SetHideMerges(commitView.activeRef, hideMerges)
+	}); err != nil {
+		return
+	}
 
-			return
-		},
+	if hideMerges {
+		commitView.channels.ReportStatus("Loading commits for ref %v excluding merges", commitView.activeRef.Shorthand())
+	} else {
+		commitView.channels.ReportStatus("Loading all commits for ref %v", commitView.activeRef.Shorthand())
 	}
 
-	commitView.channels.DoAction(Action{
-		ActionType: ActionSplitView,
-		Args: []interface{}{
-			ActionSplitViewArgs{
-				CreateViewArgs: createViewArgs,
-				orientation:    CoDynamic,
-			},
-		},
+	return
+}
+
+// reloadActiveRefCommits reloads refs and status from the repository and then reloads
+// the commits of the active ref so changes made outside of grv are displayed
+func reloadActiveRefCommits(commitView *CommitView, action Action) (err error) {
+	if commitView.activeRef == nil {
+		return
+	}
+
+	repoData := commitView.repoData
+	refName := commitView.activeRef.Name()
+
+	commitView.channels.ReportStatus("Reloading refs and commits for ref %v", commitView.activeRef.Shorthand())
+
+	if err = repoData.LoadStatus(); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(func(refs []Ref) (err error) {
+		commitView.lock.Lock()
+		defer commitView.lock.Unlock()
+
+		if commitView.activeRef == nil || commitView.activeRef.Name() != refName {
+			log.Debugf("Active ref changed from %v while reloading refs", refName)
+			return
+		}
+
+		if head := repoData.Head(); head != nil && head.Name() == refName {
+			commitView.activeRef = head
+		} else if ref, refErr := repoData.Ref(refName); refErr == nil {
+			commitView.activeRef = ref
+		}
+
+		log.Debugf("Reloading commits for ref %v:%v", refName, commitView.activeRef.Oid())
+
+		return commitView.reloadCommits(func() error {
+			return repoData.ReloadCommits(commitView.activeRef)
+		})
 	})
+
+	return
 }
 
-// ViewPos returns the current view position
-func (commitView *CommitView) ViewPos() ViewPos {
+// reloadCommits calls reload to reload the commits of the active ref and restores
+// the selected commit once the commits have been loaded
+func (commitView *CommitView) reloadCommits(reload func() error) (err error) {
 	refViewData := commitView.refViewData[commitView.activeRef.Name()]
-	return refViewData.viewPos
+	refViewData.pendingOid = nil
+
+	if selectedCommit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex()); err == nil {
+		refViewData.pendingOid = selectedCommit.oid
+	}
+
+	if err = reload(); err != nil {
+		return
+	}
+
+	refViewData.viewPos.SetActiveRowIndex(0)
+	refViewData.commitGraph.Clear()
+	refViewData.clearRowCache()
+
+	commitView.refreshTask.start()
+	commitView.channels.UpdateDisplay()
+
+	return
 }
 
-// OnSearchMatch updates the view position when there is a search match
-func (commitView *CommitView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+func toggleRelativeDate(commitView *CommitView, action Action) (err error) {
+	if commitView.dateFormat == CdfRelative {
+		commitView.setDateFormat(CdfShort)
+	} else {
+		commitView.setDateFormat(CdfRelative)
+	}
 
-	viewPos := commitView.ViewPos()
+	return
+}
 
-	if viewPos != startPos {
-		log.Debugf("Selected ref has changed since search started")
+func cycleAllCommitDateFormats(commitView *CommitView, action Action) (err error) {
+	commitView.setDateFormat(commitView.dateFormat.Next())
+	return
+}
+
+// setDateFormat sets the date format of all rows and discards any per row formats
+func (commitView *CommitView) setDateFormat(dateFormat CommitDateFormat) {
+	commitView.dateFormat = dateFormat
+	commitView.rowDateFormats = make(map[*Oid]CommitDateFormat)
+
+	log.Debugf("Commit date format set to: %v", dateFormat)
+	commitView.channels.UpdateDisplay()
+}
+
+// cycleSelectedCommitDateFormat cycles the date format of the selected row only.
+// The row reverts to the format of all other rows once the cycle returns to it
+func cycleSelectedCommitDateFormat(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
 		return
 	}
 
-	commitView.selectCommit(matchLineIndex)
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	dateFormat, ok := commitView.rowDateFormats[commit.oid]
+	if !ok {
+		dateFormat = commitView.dateFormat
+	}
+
+	if dateFormat = dateFormat.Next(); dateFormat == commitView.dateFormat {
+		delete(commitView.rowDateFormats, commit.oid)
+	} else {
+		commitView.rowDateFormats[commit.oid] = dateFormat
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
 }
 
-// Line returns the rendered line at the index provided
-func (commitView *CommitView) Line(lineIndex uint) (line string) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+func toggleCommitter(commitView *CommitView, action Action) (err error) {
+	commitView.showCommitter = !commitView.showCommitter
 
-	lineNumber := commitView.lineNumber()
+	for _, refViewData := range commitView.refViewData {
+		refViewData.clearRowCache()
+	}
 
-	if lineIndex >= lineNumber {
-		log.Errorf("Invalid lineIndex: %v", lineIndex)
+	log.Debugf("Showing committer toggled: %v", commitView.showCommitter)
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleSearchMessageBody(commitView *CommitView, action Action) (err error) {
+	commitView.searchMessageBodies = !commitView.searchMessageBodies
+
+	if commitView.searchMessageBodies {
+		commitView.channels.ReportStatus("Search includes commit message bodies")
+	} else {
+		commitView.channels.ReportStatus("Search excludes commit message bodies")
+	}
+
+	return
+}
+
+func toggleSummaryWrap(commitView *CommitView, action Action) (err error) {
+	commitView.wrapSummary = !commitView.wrapSummary
+	log.Debugf("Commit summary wrap toggled: %v", commitView.wrapSummary)
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleCommitMinimap(commitView *CommitView, action Action) (err error) {
+	commitView.showMinimap = !commitView.showMinimap
+	log.Debugf("Commit minimap toggled: %v", commitView.showMinimap)
+
+	if commitView.showMinimap && commitView.viewDimension.cols < cvMinimapMinCols {
+		commitView.channels.ReportStatus("Minimap is hidden when the view is narrower than %v columns", cvMinimapMinCols)
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// markCommit marks the selected commit so it can be compared against another commit.
+// Marking the already marked commit clears the mark
+func markCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
 		return
 	}
 
-	refViewData, ok := commitView.refViewData[commitView.activeRef.Name()]
-	if !ok {
-		log.Errorf("Not refViewData for ref %v", commitView.activeRef.Name())
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
 		return
 	}
 
-	tableFormatter := refViewData.tableFormatter
-	tableFormatter.Clear()
+	if commitView.markedOid != nil && commitView.markedOid.Equal(commit.oid) {
+		commitView.markedOid = nil
+		commitView.channels.ReportStatus("Cleared commit mark")
+	} else {
+		commitView.markedOid = commit.oid
+		commitView.channels.ReportStatus("Marked commit %v", commit.oid.ShortID())
+	}
 
-	commitIndex := lineIndex
-	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitIndex)
-	if err != nil {
-		log.Errorf("Error when retrieving commit during search: %v", err)
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// diffAgainstMarkedCommit displays the diff from the marked commit to the selected commit
+// and clears the mark
+func diffAgainstMarkedCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
 		return
 	}
 
-	if err = commitView.renderCommit(tableFormatter, 0, commit); err != nil {
-		log.Errorf("Error when rendering commit: %v", err)
+	if commitView.markedOid == nil {
+		commitView.channels.ReportStatus("No commit is marked")
 		return
 	}
 
-	line, err = tableFormatter.RowString(0)
+	markedCommit, err := commitView.repoData.Commit(commitView.markedOid)
+	if err != nil {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
 	if err != nil {
-		log.Errorf("Error when retrieving row string: %v", err)
 		return
 	}
 
+	commitView.markedOid = nil
+	commitView.notifyCommitRangeListeners(markedCommit, commit)
+	commitView.channels.UpdateDisplay()
+
 	return
 }
 
-// LineNumber returns the total number of rendered lines the commit view has
-func (commitView *CommitView) LineNumber() (lineNumber uint) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+func clearCommitMark(commitView *CommitView, action Action) (err error) {
+	if commitView.markedOid == nil {
+		commitView.channels.ReportStatus("No commit is marked")
+		return
+	}
 
-	return commitView.lineNumber()
+	commitView.markedOid = nil
+	commitView.channels.ReportStatus("Cleared commit mark")
+	commitView.channels.UpdateDisplay()
+
+	return
 }
 
-func (commitView *CommitView) lineNumber() (lineNumber uint) {
-	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
-	lineNum := commitSetState.commitNum
+// notifyCommitRangeListeners provides the commits to each listener able to compare them.
+// If there are no such listeners a diff view is created to display the comparison
+func (commitView *CommitView) notifyCommitRangeListeners(fromCommit, toCommit *Commit) {
+	var commitRangeListeners []CommitRangeListener
 
-	return lineNum
+	for _, commitViewListener := range commitView.commitViewListeners {
+		if commitRangeListener, ok := commitViewListener.(CommitRangeListener); ok {
+			commitRangeListeners = append(commitRangeListeners, commitRangeListener)
+		}
+	}
+
+	if len(commitRangeListeners) == 0 {
+		commitView.createCommitViewListenerView(ViewDiff, fromCommit, toCommit)
+		return
+	}
+
+	log.Debugf("Notifying commit range listeners of commits %v..%v", fromCommit.oid, toCommit.oid)
+
+	go func() {
+		for _, commitRangeListener := range commitRangeListeners {
+			if err := commitRangeListener.OnCommitRangeSelected(fromCommit, toCommit); err != nil {
+				commitView.channels.ReportError(err)
+			}
+		}
+	}()
 }
 
-// HandleEvent reacts to an event
-func (commitView *CommitView) HandleEvent(event Event) (err error) {
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+func copyCommitID(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitID := commit.oid.String()
 
-	switch event.EventType {
-	case ViewRemovedEvent:
-		commitView.removeCommitViewListeners(event.Args)
+	if err = CopyToClipboard(commitID); err != nil {
+		return
 	}
 
+	log.Debugf("Copied commit id %v to clipboard", commitID)
+	commitView.channels.ReportStatus("Copied commit %v to clipboard", commit.oid.ShortID())
+
 	return
 }
 
-func (commitView *CommitView) removeCommitViewListeners(views []interface{}) {
-	for _, view := range views {
-		if commitViewListener, ok := view.(CommitViewListener); ok {
-			commitView.removeCommitViewListener(commitViewListener)
-		}
-	}
+func copyCommitSummary(commitView *CommitView, action Action) (err error) {
+	return commitView.copyCommitText("summary", func(commit *Commit) string {
+		return commit.commit.Summary()
+	})
 }
 
-func (commitView *CommitView) removeCommitViewListener(commitViewListener CommitViewListener) {
-	for index, listener := range commitView.commitViewListeners {
-		if commitViewListener == listener {
-			log.Debugf("Removing CommitViewListener %T", commitViewListener)
-			commitView.commitViewListeners = append(commitView.commitViewListeners[:index], commitView.commitViewListeners[index+1:]...)
-			break
-		}
-	}
+func copyCommitMessage(commitView *CommitView, action Action) (err error) {
+	return commitView.copyCommitText("message", func(commit *Commit) string {
+		return commit.commit.Message()
+	})
 }
 
-// HandleAction checks if commit view supports this action and if it does executes it
-func (commitView *CommitView) HandleAction(action Action) (err error) {
-	log.Debugf("CommitView handling action %v", action)
-	commitView.lock.Lock()
-	defer commitView.lock.Unlock()
+func (commitView *CommitView) copyCommitText(description string, commitText func(*Commit) string) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
 
-	if commitView.activeRef == nil {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
 		return
 	}
 
-	if handler, ok := commitView.handlers[action.ActionType]; ok {
-		err = handler(commitView, action)
-	} else {
-		_, err = commitView.viewSearch.HandleAction(action)
+	if err = CopyToClipboard(commitText(commit)); err != nil {
+		return
 	}
 
+	log.Debugf("Copied %v of commit %v to clipboard", description, commit.oid)
+	commitView.channels.ReportStatus("Copied %v of commit %v to clipboard", description, commit.oid.ShortID())
+
 	return
 }
 
-func moveUpCommit(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
+func showCommitInPager(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
 
-	if viewPos.MoveLineUp() {
-		log.Debug("Moving up one commit")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
 	}
 
-	return
-}
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = cvPager
+	}
 
-func moveDownCommit(commitView *CommitView, action Action) (err error) {
-	lineNumber := commitView.lineNumber()
-	viewPos := commitView.ViewPos()
+	log.Debugf("Showing commit %v using pager %v", commit.oid, pager)
 
-	if viewPos.MoveLineDown(lineNumber) {
-		log.Debug("Moving down one commit")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
-	}
+	commitView.channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command: "git",
+			args:    []string{"--git-dir", commitView.repoData.Path(), "show", commit.oid.String()},
+			env:     []string{"GIT_PAGER=" + pager},
+		}},
+	})
 
 	return
 }
 
-func moveUpCommitPage(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
+// interactiveRebase suspends the UI and runs git rebase -i onto the parent of the selected commit.
+// The todo list is edited using the editor git is configured to use
+func interactiveRebase(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
 
-	if viewPos.MovePageUp(commitView.viewDimension.rows - 2) {
-		log.Debug("Moving up one page")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	repoData := commitView.repoData
+	workdir := repoData.Workdir()
+	if workdir == "" {
+		return fmt.Errorf("Unable to rebase: Repository has no working tree")
 	}
 
-	return
-}
+	if repoData.RebaseInProgress() {
+		commitView.channels.ReportStatus("A rebase is already in progress")
+		return
+	}
 
-func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
-	lineNumber := commitView.lineNumber()
-	viewPos := commitView.ViewPos()
+	if status := repoData.Status(); status != nil && (len(status.Entries(StStaged)) > 0 || len(status.Entries(StUnstaged)) > 0) {
+		commitView.channels.ReportStatus("Cannot rebase: working tree has uncommitted changes")
+		return
+	}
 
-	if viewPos.MovePageDown(commitView.viewDimension.rows-2, lineNumber) {
-		log.Debug("Moving down one page")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	commit, err := repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
 	}
 
-	return
-}
+	upstream := commit.oid.String() + "^"
+	if repoData.CommitParentCount(commit) == 0 {
+		upstream = "--root"
+	}
 
-func moveUpCommitHalfPage(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
+	log.Debugf("Starting interactive rebase onto %v", upstream)
 
-	if viewPos.MovePageUp(commitView.viewDimension.rows/2 - 2) {
-		log.Debug("Moving up one half page")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
-	}
+	commitView.channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command: "git",
+			args:    []string{"--git-dir", repoData.Path(), "--work-tree", workdir, "rebase", "-i", upstream},
+			onExit:  commitView.onRebaseExit,
+		}},
+	})
 
 	return
 }
 
-func moveDownCommitHalfPage(commitView *CommitView, action Action) (err error) {
-	lineNumber := commitView.lineNumber()
-	viewPos := commitView.ViewPos()
+// onRebaseExit reloads refs and status once git rebase has exited and reports
+// if the rebase stopped, e.g. due to conflicts
+func (commitView *CommitView) onRebaseExit() {
+	repoData := commitView.repoData
+	repoData.LoadRefs(nil)
 
-	if viewPos.MovePageDown(commitView.viewDimension.rows/2-2, lineNumber) {
-		log.Debug("Moving down one half page")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	if err := repoData.LoadStatus(); err != nil {
+		commitView.channels.ReportError(err)
 	}
 
-	return
+	if repoData.RebaseInProgress() {
+		commitView.channels.ReportStatus("Rebase stopped. Resolve any conflicts then run git rebase --continue or git rebase --abort")
+	}
 }
 
-func scrollCommitViewRight(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
-	viewPos.MovePageRight(commitView.viewDimension.cols)
-	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
-	commitView.channels.UpdateDisplay()
+// amendCommit opens the message of the selected commit in $EDITOR and amends
+// the commit with the edited message once the editor exits.
+// Only the HEAD commit can be amended and the index must not contain staged changes
+func amendCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
 
-	return
-}
+	repoData := commitView.repoData
+	if repoData.Workdir() == "" {
+		return fmt.Errorf("Unable to amend commit: Repository has no working tree")
+	}
 
-func scrollCommitViewLeft(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
+	commit, err := repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
 
-	if viewPos.MovePageLeft(commitView.viewDimension.cols) {
-		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
-		commitView.channels.UpdateDisplay()
+	if !commitView.isHeadCommit(commit) {
+		commitView.channels.ReportStatus("Only the HEAD commit can be amended")
+		return
 	}
 
-	return
-}
+	if status := repoData.Status(); status != nil && len(status.Entries(StStaged)) > 0 {
+		commitView.channels.ReportStatus("Cannot amend commit: index has staged changes")
+		return
+	}
 
-func moveToFirstCommit(commitView *CommitView, action Action) (err error) {
-	viewPos := commitView.ViewPos()
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = cvEditor
+	}
 
-	if viewPos.MoveToFirstLine() {
-		log.Debug("Moving up to first commit")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	tempDir, err := ioutil.TempDir("", "grv")
+	if err != nil {
+		return
 	}
 
-	return
-}
+	removeTempDir := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Errorf("Unable to remove temporary directory %v: %v", tempDir, err)
+		}
+	}
 
-func moveToLastCommit(commitView *CommitView, action Action) (err error) {
-	lineNumber := commitView.lineNumber()
-	viewPos := commitView.ViewPos()
+	messageFile := filepath.Join(tempDir, cvCommitMessageFile)
+	message := commit.commit.Message()
 
-	if viewPos.MoveToLastLine(lineNumber) {
-		log.Debug("Moving to last commit")
-		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
-			return
-		}
-		commitView.channels.UpdateDisplay()
+	if err = ioutil.WriteFile(messageFile, []byte(message), 0600); err != nil {
+		removeTempDir()
+		return
 	}
 
+	log.Debugf("Editing message of commit %v using %v", commit.oid, editor)
+
+	commitView.channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command: scShell,
+			args:    []string{"-c", editor + " " + ShellQuote(messageFile)},
+			onExit: func() {
+				defer removeTempDir()
+				commitView.onAmendEditorExit(messageFile, message)
+			},
+		}},
+	})
+
 	return
 }
 
-func addCommitFilter(commitView *CommitView, action Action) (err error) {
-	if !(len(action.Args) > 0) {
-		return fmt.Errorf("Expected filter query argument")
+// onAmendEditorExit amends the HEAD commit with the edited message if it has changed
+func (commitView *CommitView) onAmendEditorExit(messageFile, originalMessage string) {
+	content, err := ioutil.ReadFile(messageFile)
+	if err != nil {
+		commitView.channels.ReportError(fmt.Errorf("Unable to read commit message: %v", err))
+		return
 	}
 
-	query, ok := action.Args[0].(string)
-	if !ok {
-		return fmt.Errorf("Expected filter query argument to have type string")
+	message := string(content)
+	if strings.TrimSpace(message) == strings.TrimSpace(originalMessage) {
+		commitView.channels.ReportStatus("Commit message unchanged")
+		return
 	}
 
-	commitFilter, errors := CreateCommitFilter(query)
-	if len(errors) > 0 {
-		commitView.channels.ReportErrors(errors)
+	if err = commitView.repoData.AmendCommitMessage(message); err != nil {
+		commitView.channels.ReportError(err)
 		return
-	} else if commitFilter == nil {
-		log.Debugf("Query string does not define commit filter: \"%v\"", query)
+	}
+
+	commitView.channels.ReportStatus("Amended commit message")
+}
+
+func showCommitTree(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
 		return
 	}
 
-	if err = commitView.repoData.AddCommitFilter(commitView.activeRef, commitFilter); err != nil {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
 		return
 	}
 
-	commitView.ViewPos().SetActiveRowIndex(0)
+	log.Debugf("Showing tree for commit %v", commit.oid)
+	commitView.createCommitViewListenerView(ViewTree, commit)
 
-	go func() {
-		// TODO: Works in practice, but there is no guarantee the filtered commit set will have
-		// been populated after 250ms. Need an event based mechanism to be notified when a filtered
-		// set has started to be populated so that the first commit can be selected
-		time.Sleep(250 * time.Millisecond)
-		commitView.lock.Lock()
-		defer commitView.lock.Unlock()
+	return
+}
 
-		if err := commitView.selectCommit(commitView.ViewPos().ActiveRowIndex()); err != nil {
-			log.Errorf("Unable to select commit after filter has been applied: %v", err)
-		}
-	}()
+func showAuthorStats(commitView *CommitView, action Action) (err error) {
+	if commitView.activeRef == nil {
+		return
+	}
 
-	commitView.channels.UpdateDisplay()
+	log.Debugf("Showing author statistics for ref %v", commitView.activeRef.Name())
+
+	refName := commitView.activeRef.Name()
+	if _, isHead := commitView.activeRef.(*HEAD); isHead {
+		refName = commitView.activeRef.Oid().String()
+	}
+
+	commitView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: CreateViewArgs{
+					viewID:   ViewStats,
+					viewArgs: []interface{}{refName},
+				},
+				orientation: CoDynamic,
+			},
+		},
+	})
 
 	return
 }
 
-func removeCommitFilter(commitView *CommitView, action Action) (err error) {
-	if err = commitView.repoData.RemoveCommitFilter(commitView.activeRef); err != nil {
-		return
-	}
+// ShellCommandVariables returns the displayed branch and the oid of the selected commit
+func (commitView *CommitView) ShellCommandVariables() (variables map[rune]string, err error) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	variables = make(map[rune]string)
 
-	if err = commitView.selectCommit(0); err != nil {
+	if commitView.activeRef == nil {
 		return
 	}
 
-	commitView.channels.UpdateDisplay()
+	variables['b'] = commitView.activeRef.Shorthand()
+
+	if commitView.lineNumber() > 0 {
+		var commit *Commit
+		if commit, err = commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex()); err != nil {
+			return
+		}
+
+		variables['h'] = commit.oid.String()
+	}
 
 	return
 }
@@ -812,7 +3226,7 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 func centerCommitView(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.CenterActiveRow(commitView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(commitView.pageRows()) {
 		log.Debug("Centering CommitView")
 		commitView.channels.UpdateDisplay()
 	}
@@ -821,6 +3235,10 @@ func centerCommitView(commitView *CommitView, action Action) (err error) {
 }
 
 func selectCommit(commitView *CommitView, action Action) (err error) {
+	if commitView.lineNumber() == 0 {
+		return
+	}
+
 	viewPos := commitView.ViewPos()
 
 	if len(commitView.commitViewListeners) == 0 {
@@ -829,8 +3247,42 @@ func selectCommit(commitView *CommitView, action Action) (err error) {
 			return
 		}
 
-		commitView.createCommitViewListenerView(commit)
+		commitView.createCommitViewListenerView(ViewDiff, commit)
 	}
 
 	return commitView.selectCommit(viewPos.ActiveRowIndex())
 }
+
+func mouseSelectCommit(commitView *CommitView, action Action) (err error) {
+	mouseEvent, err := GetMouseEventFromAction(action)
+	if err != nil {
+		return
+	}
+
+	if mouseEvent.row == 0 || mouseEvent.row > commitView.pageRows() {
+		log.Debugf("Ignoring click on CommitView border at row %v", mouseEvent.row)
+		return
+	}
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	refViewData := commitView.refViewData[commitView.activeRef.Name()]
+	lineIndex := commitView.commitIndexAtRow(refViewData, mouseEvent.row-1)
+
+	if lineIndex >= commitView.lineNumber() {
+		log.Debugf("Ignoring click below the last commit at row %v", mouseEvent.row)
+		return
+	}
+
+	log.Debugf("Selecting commit at index %v from mouse click", lineIndex)
+
+	if err = commitView.selectCommit(lineIndex); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
@@ -0,0 +1,2147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+type MockRepoData struct {
+	mock.Mock
+}
+
+func (repoData *MockRepoData) HandleEvent(event Event) error {
+	args := repoData.Called(event)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) Path() string {
+	args := repoData.Called()
+	return args.String(0)
+}
+
+func (repoData *MockRepoData) Workdir() string {
+	args := repoData.Called()
+	return args.String(0)
+}
+
+func (repoData *MockRepoData) RebaseInProgress() bool {
+	args := repoData.Called()
+	return args.Bool(0)
+}
+
+func (repoData *MockRepoData) LoadHead() error {
+	args := repoData.Called()
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) LoadRefs(onRefsLoaded OnRefsLoaded) {
+	repoData.Called(onRefsLoaded)
+}
+
+func (repoData *MockRepoData) LoadCommits(ref Ref) error {
+	args := repoData.Called(ref)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) SetFirstWindowCommitNum(commitNum uint) {
+	repoData.Called(commitNum)
+}
+
+func (repoData *MockRepoData) Head() Ref {
+	args := repoData.Called()
+	ref, _ := args.Get(0).(Ref)
+	return ref
+}
+
+func (repoData *MockRepoData) Ref(refName string) (Ref, error) {
+	args := repoData.Called(refName)
+	return args.Get(0).(Ref), args.Error(1)
+}
+
+func (repoData *MockRepoData) Branches() (localBranches, remoteBranches []Branch, loading bool) {
+	args := repoData.Called()
+	return args.Get(0).([]Branch), args.Get(1).([]Branch), args.Bool(2)
+}
+
+func (repoData *MockRepoData) Tags() (tags []*Tag, loading bool) {
+	args := repoData.Called()
+	return args.Get(0).([]*Tag), args.Bool(1)
+}
+
+func (repoData *MockRepoData) RefsForCommit(commit *Commit) *CommitRefs {
+	args := repoData.Called(commit)
+	return args.Get(0).(*CommitRefs)
+}
+
+func (repoData *MockRepoData) RefsAtCommit(oid *Oid) []string {
+	args := repoData.Called(oid)
+	return args.Get(0).([]string)
+}
+
+func (repoData *MockRepoData) CommitSetState(ref Ref) CommitSetState {
+	args := repoData.Called(ref)
+	return args.Get(0).(CommitSetState)
+}
+
+func (repoData *MockRepoData) Commits(ref Ref, startIndex, count uint) (<-chan *Commit, error) {
+	args := repoData.Called(ref, startIndex, count)
+	return args.Get(0).(<-chan *Commit), args.Error(1)
+}
+
+func (repoData *MockRepoData) CommitByIndex(ref Ref, index uint) (*Commit, error) {
+	args := repoData.Called(ref, index)
+	return args.Get(0).(*Commit), args.Error(1)
+}
+
+func (repoData *MockRepoData) Commit(oid *Oid) (*Commit, error) {
+	args := repoData.Called(oid)
+	return args.Get(0).(*Commit), args.Error(1)
+}
+
+func (repoData *MockRepoData) CommitByOid(oidStr string) (*Commit, error) {
+	args := repoData.Called(oidStr)
+	return args.Get(0).(*Commit), args.Error(1)
+}
+
+func (repoData *MockRepoData) ResolveOid(prefix string) (*Oid, error) {
+	args := repoData.Called(prefix)
+	return args.Get(0).(*Oid), args.Error(1)
+}
+
+func (repoData *MockRepoData) AheadBehind(ref Ref) (ahead, behind uint, isTrackingBranch bool) {
+	args := repoData.Called(ref)
+	return args.Get(0).(uint), args.Get(1).(uint), args.Bool(2)
+}
+
+func (repoData *MockRepoData) CreateTag(name string, oid *Oid) error {
+	args := repoData.Called(name, oid)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) CreateBranch(name string, oid *Oid) (Ref, error) {
+	args := repoData.Called(name, oid)
+	return args.Get(0).(Ref), args.Error(1)
+}
+
+func (repoData *MockRepoData) Checkout(ref Ref) error {
+	args := repoData.Called(ref)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) Stashes() ([]*StashEntry, error) {
+	args := repoData.Called()
+	return args.Get(0).([]*StashEntry), args.Error(1)
+}
+
+func (repoData *MockRepoData) StashApply(stashEntry *StashEntry) error {
+	args := repoData.Called(stashEntry)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) StashDrop(stashEntry *StashEntry) error {
+	args := repoData.Called(stashEntry)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) BisectStart() error {
+	args := repoData.Called()
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) BisectGood(oid *Oid) (*BisectStep, error) {
+	args := repoData.Called(oid)
+	return args.Get(0).(*BisectStep), args.Error(1)
+}
+
+func (repoData *MockRepoData) BisectBad(oid *Oid) (*BisectStep, error) {
+	args := repoData.Called(oid)
+	return args.Get(0).(*BisectStep), args.Error(1)
+}
+
+func (repoData *MockRepoData) BisectReset() error {
+	args := repoData.Called()
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) AmendCommitMessage(message string) error {
+	args := repoData.Called(message)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) LoadMoreCommits(ref Ref) error {
+	args := repoData.Called(ref)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) CommitParentIDs(commit *Commit) []*Oid {
+	args := repoData.Called(commit)
+	return args.Get(0).([]*Oid)
+}
+
+func (repoData *MockRepoData) CommitParentCount(commit *Commit) uint {
+	args := repoData.Called(commit)
+	return args.Get(0).(uint)
+}
+
+func (repoData *MockRepoData) DiffStat(oid *Oid) (*DiffStat, error) {
+	args := repoData.Called(oid)
+	return args.Get(0).(*DiffStat), args.Error(1)
+}
+
+func (repoData *MockRepoData) CommitModifiesPath(commit *Commit, path string) (bool, error) {
+	args := repoData.Called(commit, path)
+	return args.Bool(0), args.Error(1)
+}
+
+func (repoData *MockRepoData) VerifyCommit(oid *Oid) (SignatureStatus, error) {
+	args := repoData.Called(oid)
+	return args.Get(0).(SignatureStatus), args.Error(1)
+}
+
+func (repoData *MockRepoData) FileContent(path string, oid *Oid) ([]byte, error) {
+	args := repoData.Called(path, oid)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (repoData *MockRepoData) Blame(path string, oid *Oid, followRenames bool) (<-chan *BlameLine, error) {
+	args := repoData.Called(path, oid, followRenames)
+	return args.Get(0).(<-chan *BlameLine), args.Error(1)
+}
+
+func (repoData *MockRepoData) Reflog() (<-chan *ReflogEntry, error) {
+	args := repoData.Called()
+	return args.Get(0).(<-chan *ReflogEntry), args.Error(1)
+}
+
+func (repoData *MockRepoData) Tree(oid *Oid, path string) ([]*TreeEntry, error) {
+	args := repoData.Called(oid, path)
+	return args.Get(0).([]*TreeEntry), args.Error(1)
+}
+
+func (repoData *MockRepoData) AddCommitFilter(ref Ref, commitFilter *CommitFilter) error {
+	args := repoData.Called(ref, commitFilter)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) SetCommitSortOrder(ref Ref, commitSortOrder CommitSortOrder) error {
+	args := repoData.Called(ref, commitSortOrder)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) SetFirstParentOnly(ref Ref, firstParentOnly bool) error {
+	args := repoData.Called(ref, firstParentOnly)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) SetHideMerges(ref Ref, hideMerges bool) error {
+	args := repoData.Called(ref, hideMerges)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) ReloadCommits(ref Ref) error {
+	args := repoData.Called(ref)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) RemoveCommitFilter(ref Ref) error {
+	args := repoData.Called(ref)
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) DiffCommit(commit *Commit, contextLines uint) (*Diff, error) {
+	args := repoData.Called(commit, contextLines)
+	return args.Get(0).(*Diff), args.Error(1)
+}
+
+func (repoData *MockRepoData) DiffRange(fromOid, toOid *Oid, contextLines uint) (*Diff, error) {
+	args := repoData.Called(fromOid, toOid, contextLines)
+	return args.Get(0).(*Diff), args.Error(1)
+}
+
+func (repoData *MockRepoData) DiffFile(statusType StatusType, path string, contextLines uint) (*Diff, error) {
+	args := repoData.Called(statusType, path, contextLines)
+	return args.Get(0).(*Diff), args.Error(1)
+}
+
+func (repoData *MockRepoData) DiffStage(statusType StatusType, contextLines uint) (*Diff, error) {
+	args := repoData.Called(statusType, contextLines)
+	return args.Get(0).(*Diff), args.Error(1)
+}
+
+func (repoData *MockRepoData) LoadStatus() error {
+	args := repoData.Called()
+	return args.Error(0)
+}
+
+func (repoData *MockRepoData) Status() *Status {
+	args := repoData.Called()
+	return args.Get(0).(*Status)
+}
+
+func (repoData *MockRepoData) RegisterStatusListener(statusListener StatusListener) {
+	repoData.Called(statusListener)
+}
+
+func (repoData *MockRepoData) RegisterRefStateListener(refStateListener RefStateListener) {
+	repoData.Called(refStateListener)
+}
+
+func (repoData *MockRepoData) RegisterCommitSetListener(commitSetListener CommitSetListener) {
+	repoData.Called(commitSetListener)
+}
+
+func (repoData *MockRepoData) UnregisterCommitSetListener(commitSetListener CommitSetListener) {
+	repoData.Called(commitSetListener)
+}
+
+func newTestChannels() *Channels {
+	return &Channels{
+		displayCh: make(chan bool, 100),
+		exitCh:    make(chan bool),
+		errorCh:   make(chan error, 100),
+		actionCh:  make(chan Action, 100),
+		eventCh:   make(chan Event, 100),
+	}
+}
+
+func newTestOid(id string, t *testing.T) *Oid {
+	rawOid, err := git.NewOid(id)
+	if err != nil {
+		t.Fatalf("Unable to create oid with Id %v: %v", id, err)
+	}
+
+	return &Oid{oid: rawOid}
+}
+
+func newTestLocalBranch(shorthand string, oid *Oid) *LocalBranch {
+	return &LocalBranch{
+		abstractBranch: &abstractBranch{
+			oid:       oid,
+			name:      "refs/heads/" + shorthand,
+			shorthand: shorthand,
+		},
+	}
+}
+
+func newTestCommitView(repoData RepoData) *CommitView {
+	channels := newTestChannels()
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+	commitView := NewCommitView(repoData, channels, config)
+	commitView.viewDimension = ViewDimension{rows: 12, cols: 80}
+
+	return commitView
+}
+
+// newTestCommitViewWithRef creates a CommitView for the branch master where every commit is the same commit.
+// Expectations which should take precedence over the defaults can be registered by the provided functions
+func newTestCommitViewWithRef(commitSetState CommitSetState, t *testing.T, expectations ...func(*MockRepoData)) (*CommitView, *MockRepoData, *LocalBranch) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	commit := &Commit{oid: oid}
+
+	repoData := &MockRepoData{}
+	for _, expectation := range expectations {
+		expectation(repoData)
+	}
+
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(commitSetState)
+	repoData.On("Commit", mock.Anything).Return(commit, nil)
+	repoData.On("CommitByIndex", mock.Anything, mock.Anything).Return(commit, nil)
+
+	return newTestCommitView(repoData), repoData, newTestLocalBranch("master", oid)
+}
+
+func TestReselectingRefRestoresViewPosition(t *testing.T) {
+	commitView, _, refA := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+	refB := newTestLocalBranch("b", refA.Oid())
+
+	if err := commitView.OnRefSelect(refA); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", refA.Name(), err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if err := commitView.HandleAction(Action{ActionType: ActionNextLine}); err != nil {
+			t.Fatalf("Failed to move down a line: %v", err)
+		}
+	}
+
+	commitView.ViewPos().DetermineViewStartRow(commitView.pageRows(), 100)
+	expected := *commitView.ViewPos().(*ViewPosition)
+
+	if expected.activeRowIndex != 20 {
+		t.Fatalf("Expected active row index to be 20 but found %v", expected.activeRowIndex)
+	}
+
+	if err := commitView.OnRefSelect(refB); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", refB.Name(), err)
+	}
+
+	checkViewPos(NewViewPosition(), commitView.ViewPos().(*ViewPosition), t)
+
+	if err := commitView.OnRefSelect(refA); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", refA.Name(), err)
+	}
+
+	checkViewPos(&expected, commitView.ViewPos().(*ViewPosition), t)
+}
+
+func TestRemovingActiveRefFallsBackToHead(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+	head := newTestLocalBranch("develop", ref.Oid())
+	repoData.On("Head").Return(head)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	commitView.OnRefsChanged(nil, []Ref{ref}, nil)
+
+	if activeRefName := commitView.activeRef.Name(); activeRefName != head.Name() {
+		t.Errorf("Expected active ref to be %v but found %v", head.Name(), activeRefName)
+	}
+
+	if _, exists := commitView.refViewData[ref.Name()]; exists {
+		t.Errorf("Expected view data for removed ref %v to be discarded", ref.Name())
+	}
+
+	if _, exists := commitView.refViewData[head.Name()]; !exists {
+		t.Errorf("Expected view data to exist for %v", head.Name())
+	}
+}
+
+func TestRemovingInactiveRefKeepsActiveRef(t *testing.T) {
+	commitView, repoData, refA := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+	refB := newTestLocalBranch("b", refA.Oid())
+
+	for _, ref := range []Ref{refA, refB} {
+		if err := commitView.OnRefSelect(ref); err != nil {
+			t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+		}
+	}
+
+	commitView.OnRefsChanged(nil, []Ref{refA}, nil)
+
+	if activeRefName := commitView.activeRef.Name(); activeRefName != refB.Name() {
+		t.Errorf("Expected active ref to be %v but found %v", refB.Name(), activeRefName)
+	}
+
+	if _, exists := commitView.refViewData[refA.Name()]; exists {
+		t.Errorf("Expected view data for removed ref %v to be discarded", refA.Name())
+	}
+
+	repoData.AssertNotCalled(t, "Head")
+}
+
+func TestRapidRefReselectsWhileLoadingDoNotBlock(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{loading: true, commitNum: 100}, t)
+	refs := []Ref{ref, newTestLocalBranch("b", ref.Oid())}
+	doneCh := make(chan error)
+
+	go func() {
+		for i := 0; i < 40; i++ {
+			if err := commitView.OnRefSelect(refs[i%len(refs)]); err != nil {
+				doneCh <- err
+				return
+			}
+		}
+
+		commitView.OnCommitsLoaded(refs[1], CommitSetState{commitNum: 1}, nil)
+		commitView.OnCommitsLoaded(refs[1], CommitSetState{commitNum: 1}, nil)
+		commitView.refreshTask.stop()
+
+		doneCh <- nil
+	}()
+
+	select {
+	case err := <-doneCh:
+		if err != nil {
+			t.Errorf("Failed to select ref: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for ref selection to complete")
+	}
+}
+
+func TestHalfPageMovementOnSmallViewDoesNotUnderflow(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	for rows := uint(0); rows < 6; rows++ {
+		commitView.viewDimension.rows = rows
+
+		if err := commitView.HandleAction(Action{ActionType: ActionNextHalfPage}); err != nil {
+			t.Fatalf("Failed to move down half a page: %v", err)
+		}
+
+		if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex >= 10 {
+			t.Fatalf("Expected active row index to be less than 10 for %v rows but found %v", rows, activeRowIndex)
+		}
+
+		if err := commitView.HandleAction(Action{ActionType: ActionPrevHalfPage}); err != nil {
+			t.Fatalf("Failed to move up half a page: %v", err)
+		}
+	}
+}
+
+func TestGotoCommitSelectsLoadedCommit(t *testing.T) {
+	targetOid := newTestOid("8d5a2d0c5a1f4bb9e6e4d68c38c7a8c6a1b0e2f3", t)
+	targetCommit := &Commit{oid: targetOid}
+
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t, func(repoData *MockRepoData) {
+		repoData.On("CommitByIndex", mock.Anything, uint(42)).Return(targetCommit, nil)
+	})
+	repoData.On("ResolveOid", "8d5a2d0").Return(targetOid, nil)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionGotoCommit, Args: []interface{}{"8d5a2d0"}}); err != nil {
+		t.Fatalf("Failed to go to commit: %v", err)
+	}
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 42 {
+		t.Errorf("Expected active row index to be 42 but found %v", activeRowIndex)
+	}
+}
+
+// selectionHistoryRepoData serves a list of commits which can be changed between actions
+type selectionHistoryRepoData struct {
+	*MockRepoData
+	commits []*Commit
+}
+
+func (repoData *selectionHistoryRepoData) CommitSetState(ref Ref) CommitSetState {
+	return CommitSetState{commitNum: uint(len(repoData.commits))}
+}
+
+func (repoData *selectionHistoryRepoData) CommitByIndex(ref Ref, index uint) (*Commit, error) {
+	if index >= uint(len(repoData.commits)) {
+		return nil, fmt.Errorf("Invalid commit index %v", index)
+	}
+
+	return repoData.commits[index], nil
+}
+
+func newSelectionHistoryRepoData(commitNum int, t *testing.T) *selectionHistoryRepoData {
+	repoData := &selectionHistoryRepoData{MockRepoData: &MockRepoData{}}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("Commit", mock.Anything).Return(&Commit{}, nil)
+
+	for commitIndex := 0; commitIndex < commitNum; commitIndex++ {
+		repoData.commits = append(repoData.commits, &Commit{oid: newTestOid(fmt.Sprintf("%040x", commitIndex+1), t)})
+	}
+
+	return repoData
+}
+
+func TestPrevSelectionReturnsToCommitSelectedBeforeJump(t *testing.T) {
+	repoData := newSelectionHistoryRepoData(100, t)
+	targetOid := repoData.commits[42].oid
+	ref := newTestLocalBranch("a", repoData.commits[0].oid)
+	repoData.On("ResolveOid", "8d5a2d0").Return(targetOid, nil)
+
+	commitView := newTestCommitView(repoData)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	actions := []Action{
+		{ActionType: ActionNextLine, Args: []interface{}{uint(5)}},
+		{ActionType: ActionGotoCommit, Args: []interface{}{"8d5a2d0"}},
+		{ActionType: ActionLastLine},
+	}
+
+	for _, action := range actions {
+		if err := commitView.HandleAction(action); err != nil {
+			t.Fatalf("Failed to handle action %v: %v", action.ActionType, err)
+		}
+	}
+
+	for _, expectedRowIndex := range []uint{42, 5} {
+		if err := commitView.HandleAction(Action{ActionType: ActionPrevSelection}); err != nil {
+			t.Fatalf("Failed to return to previous selection: %v", err)
+		}
+
+		if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != expectedRowIndex {
+			t.Errorf("Expected active row index to be %v but found %v", expectedRowIndex, activeRowIndex)
+		}
+	}
+
+	if _, ok := commitView.refViewData[ref.Name()].popSelection(); ok {
+		t.Errorf("Expected selection history to be empty")
+	}
+}
+
+func TestPrevSelectionFollowsCommitToItsCurrentIndex(t *testing.T) {
+	repoData := newSelectionHistoryRepoData(20, t)
+	ref := newTestLocalBranch("a", repoData.commits[0].oid)
+	selectedOid := repoData.commits[5].oid
+
+	commitView := newTestCommitView(repoData)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	for _, action := range []Action{{ActionType: ActionNextLine, Args: []interface{}{uint(5)}}, {ActionType: ActionLastLine}} {
+		if err := commitView.HandleAction(action); err != nil {
+			t.Fatalf("Failed to handle action %v: %v", action.ActionType, err)
+		}
+	}
+
+	newCommits := []*Commit{
+		{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)},
+		{oid: newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)},
+	}
+	repoData.commits = append(newCommits, repoData.commits...)
+
+	if err := commitView.HandleAction(Action{ActionType: ActionPrevSelection}); err != nil {
+		t.Fatalf("Failed to return to previous selection: %v", err)
+	}
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 7 {
+		t.Errorf("Expected commit %v to be selected at its new index 7 but found index %v", selectedOid, activeRowIndex)
+	}
+}
+
+func TestSelectionHistoryIsBounded(t *testing.T) {
+	refViewData := newReferenceViewData(1)
+	var oids []*Oid
+
+	for index := 0; index < cvSelectionHistoryMaxSize+10; index++ {
+		oid := newTestOid(fmt.Sprintf("%040x", index+1), t)
+		oids = append(oids, oid)
+		refViewData.recordSelection(oid)
+	}
+
+	if historySize := len(refViewData.selectionHistory); historySize != cvSelectionHistoryMaxSize {
+		t.Errorf("Expected selection history to contain %v entries but found %v", cvSelectionHistoryMaxSize, historySize)
+	}
+
+	if oid, _ := refViewData.popSelection(); oid != oids[len(oids)-1] {
+		t.Errorf("Expected most recent selection to be %v but found %v", oids[len(oids)-1], oid)
+	}
+}
+
+func TestSelectHeadShowsDetachedHeadAndSelectsItsCommit(t *testing.T) {
+	headOid := newTestOid("8d5a2d0c5a1f4bb9e6e4d68c38c7a8c6a1b0e2f3", t)
+	headCommit := &Commit{oid: headOid}
+	head := &HEAD{oid: headOid}
+
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t, func(repoData *MockRepoData) {
+		repoData.On("CommitByIndex", head, uint(0)).Return(headCommit, nil)
+	})
+	repoData.On("Head").Return(head)
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	commitView.ViewPos().SetActiveRowIndex(42)
+
+	if err := commitView.HandleAction(Action{ActionType: ActionSelectHead}); err != nil {
+		t.Fatalf("Failed to select HEAD: %v", err)
+	}
+
+	commitView.refreshTask.stop()
+
+	if commitView.activeRef != head {
+		t.Fatalf("Expected active ref to be HEAD but found %v", commitView.activeRef.Name())
+	}
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 0 {
+		t.Errorf("Expected active row index to be 0 but found %v", activeRowIndex)
+	}
+
+	if title := commitView.title(CommitSetState{commitNum: 100}); !strings.Contains(title, "(detached at 8d5a2d0)") {
+		t.Errorf("Expected title to show detached HEAD but found: %v", title)
+	}
+}
+
+func TestSignatureStatusIsVerifiedOnceInBackground(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+
+	repoData := &MockRepoData{}
+	repoData.On("VerifyCommit", oid).Return(SsGood, nil)
+
+	commitView := newTestCommitView(repoData)
+
+	if status := commitView.signatureStatuses[oid]; status != SsUnknown {
+		t.Errorf("Expected unverified commit to have status SsUnknown but found %v", status)
+	}
+
+	commitView.queueSignatureVerification(oid)
+	commitView.queueSignatureVerification(oid)
+
+	if len(commitView.unverifiedOids) != 1 {
+		t.Fatalf("Expected commit to be queued for verification once but found %v entries", len(commitView.unverifiedOids))
+	}
+
+	commitView.verifyUnverifiedCommits()
+
+	for i := 0; i < 100; i++ {
+		commitView.lock.Lock()
+		status := commitView.signatureStatuses[oid]
+		commitView.lock.Unlock()
+
+		if status == SsGood {
+			repoData.AssertNumberOfCalls(t, "VerifyCommit", 1)
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	t.Errorf("Expected commit signature to be verified as good")
+}
+
+func TestOnlyRenderedCommitsAreQueuedForSignatureVerification(t *testing.T) {
+	repoDir := newTestRepository(0, t)
+	defer os.RemoveAll(repoDir)
+
+	repo, err := git.OpenRepository(repoDir)
+	if err != nil {
+		t.Fatalf("Unable to open repository: %v", err)
+	}
+	defer repo.Free()
+
+	commits := newBenchmarkCommits(repo, 20, t)
+	ref := newTestLocalBranch("master", commits[0].oid)
+
+	repoData := &benchmarkRepoData{
+		MockRepoData: &MockRepoData{},
+		commits:      commits,
+	}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: uint(len(commits))})
+	repoData.On("Commit", mock.Anything).Return(commits[0], nil)
+	repoData.On("CommitByIndex", mock.Anything, uint(15)).Return(commits[15], nil)
+	repoData.On("RefsForCommit", mock.Anything).Return(&CommitRefs{})
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+	repoData.On("Head").Return(ref)
+	repoData.On("VerifyCommit", mock.Anything).Return(SsGood, nil)
+
+	commitView := newTestCommitView(repoData)
+	commitView.rowFormat = ParseCommitRowFormat("%signature %oid %subject")
+
+	if err = commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	commitView.Line(15)
+
+	if len(commitView.signatureStatuses) != 0 {
+		t.Errorf("Expected searching commits to not queue signature verification")
+	}
+
+	win := NewWindow("commitView", commitView.config)
+	win.Resize(ViewDimension{rows: 12, cols: 80})
+
+	if err = commitView.Render(win); err != nil {
+		t.Fatalf("Failed to render CommitView: %v", err)
+	}
+
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if _, ok := commitView.signatureStatuses[commits[0].oid]; !ok {
+		t.Errorf("Expected rendered commit to be queued for signature verification")
+	}
+
+	if _, ok := commitView.signatureStatuses[commits[15].oid]; ok {
+		t.Errorf("Expected commit outside of the view to not be queued for signature verification")
+	}
+}
+
+// mockRenderWindow records the text of each row and the rows selected when a view is rendered
+type mockRenderWindow struct {
+	rows         uint
+	cols         uint
+	config       Config
+	lines        []*line
+	selectedRows []uint
+}
+
+func newMockRenderWindow(viewDimension ViewDimension, config Config) *mockRenderWindow {
+	win := &mockRenderWindow{
+		rows:   viewDimension.rows,
+		cols:   viewDimension.cols,
+		config: config,
+	}
+
+	win.Clear()
+
+	return win
+}
+
+func (win *mockRenderWindow) ID() string {
+	return "mockRenderWindow"
+}
+
+func (win *mockRenderWindow) Rows() uint {
+	return win.rows
+}
+
+func (win *mockRenderWindow) Cols() uint {
+	return win.cols
+}
+
+func (win *mockRenderWindow) ViewDimensions() ViewDimension {
+	return ViewDimension{rows: win.rows, cols: win.cols}
+}
+
+func (win *mockRenderWindow) Clear() {
+	win.lines = nil
+	win.selectedRows = nil
+
+	for rowIndex := uint(0); rowIndex < win.rows; rowIndex++ {
+		win.lines = append(win.lines, newLine(win.cols))
+	}
+}
+
+func (win *mockRenderWindow) SetRow(rowIndex, startColumn uint, themeComponentID ThemeComponentID, format string, args ...interface{}) error {
+	lineBuilder, err := win.LineBuilder(rowIndex, startColumn)
+	if err != nil {
+		return err
+	}
+
+	lineBuilder.AppendWithStyle(themeComponentID, format, args...)
+
+	return nil
+}
+
+func (win *mockRenderWindow) SetSelectedRow(rowIndex uint, active bool) error {
+	if rowIndex >= win.rows {
+		return fmt.Errorf("Invalid row index: %v >= %v rows", rowIndex, win.rows)
+	}
+
+	win.selectedRows = append(win.selectedRows, rowIndex)
+
+	return nil
+}
+
+func (win *mockRenderWindow) SetCursor(rowIndex, colIndex uint) error {
+	return nil
+}
+
+func (win *mockRenderWindow) SetTitle(themeComponentID ThemeComponentID, format string, args ...interface{}) error {
+	return nil
+}
+
+func (win *mockRenderWindow) SetFooter(themeComponentID ThemeComponentID, format string, args ...interface{}) error {
+	return nil
+}
+
+func (win *mockRenderWindow) ApplyStyle(themeComponentID ThemeComponentID) {}
+
+func (win *mockRenderWindow) Highlight(pattern string, themeComponentID ThemeComponentID) error {
+	return nil
+}
+
+func (win *mockRenderWindow) DrawBorder() {}
+
+func (win *mockRenderWindow) DrawScrollBar(startRow, visibleRows, totalRows uint, themeComponentID ThemeComponentID) {
+}
+
+func (win *mockRenderWindow) SetCell(rowIndex, colIndex uint, codePoint rune, themeComponentID ThemeComponentID) error {
+	return nil
+}
+
+func (win *mockRenderWindow) LineBuilder(rowIndex, startColumn uint) (*LineBuilder, error) {
+	if rowIndex >= win.rows {
+		return nil, fmt.Errorf("Invalid row index: %v >= %v rows", rowIndex, win.rows)
+	}
+
+	return newLineBuilder(win.lines[rowIndex], win.config, startColumn), nil
+}
+
+func (win *mockRenderWindow) row(rowIndex uint) string {
+	return strings.TrimRight(win.lines[rowIndex].String(), " ")
+}
+
+func TestRenderedCommitsStayInRangeWhenScrollingAcrossBoundaries(t *testing.T) {
+	repoDir := newTestRepository(0, t)
+	defer os.RemoveAll(repoDir)
+
+	repo, err := git.OpenRepository(repoDir)
+	if err != nil {
+		t.Fatalf("Unable to open repository: %v", err)
+	}
+	defer repo.Free()
+
+	commits := newBenchmarkCommits(repo, 25, t)
+	commitNum := uint(len(commits))
+	ref := newTestLocalBranch("master", commits[0].oid)
+
+	repoData := &benchmarkRepoData{
+		MockRepoData: &MockRepoData{},
+		commits:      commits,
+	}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: commitNum})
+	repoData.On("Commit", mock.Anything).Return(commits[0], nil)
+	repoData.On("RefsForCommit", mock.Anything).Return(&CommitRefs{})
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+	repoData.On("Head").Return(ref)
+
+	for commitIndex, commit := range commits {
+		repoData.On("CommitByIndex", mock.Anything, uint(commitIndex)).Return(commit, nil)
+	}
+
+	commitView := newTestCommitView(repoData)
+	commitView.rowFormat = ParseCommitRowFormat("%subject")
+
+	if err = commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	win := newMockRenderWindow(commitView.viewDimension, commitView.config)
+	pageRows := commitView.pageRows()
+
+	var actionTypes []ActionType
+	for _, actionType := range []ActionType{ActionNextLine, ActionPrevLine, ActionNextPage, ActionPrevPage, ActionNextHalfPage, ActionPrevHalfPage} {
+		for i := uint(0); i < commitNum+2; i++ {
+			actionTypes = append(actionTypes, actionType)
+		}
+	}
+	actionTypes = append(actionTypes, ActionLastLine, ActionNextLine, ActionFirstLine, ActionPrevLine)
+
+	for _, actionType := range actionTypes {
+		if err = commitView.HandleAction(Action{ActionType: actionType}); err != nil {
+			t.Fatalf("Failed to handle action %v: %v", actionType, err)
+		}
+
+		win.Clear()
+		repoData.startIndexes = nil
+
+		if err = commitView.Render(win); err != nil {
+			t.Fatalf("Failed to render CommitView after %v: %v", actionType, err)
+		}
+
+		viewPos := commitView.ViewPos()
+		viewStartRowIndex, activeRowIndex := viewPos.ViewStartRowIndex(), viewPos.ActiveRowIndex()
+
+		for _, startIndex := range repoData.startIndexes {
+			if startIndex > activeRowIndex || startIndex >= commitNum {
+				t.Fatalf("Commits requested from index %v with active row %v of %v commits after %v", startIndex, activeRowIndex, commitNum, actionType)
+			}
+		}
+
+		selectedRowIndex := activeRowIndex - viewStartRowIndex + 1
+		if len(win.selectedRows) != 1 || win.selectedRows[0] != selectedRowIndex || selectedRowIndex > pageRows {
+			t.Fatalf("Expected row %v to be selected after %v but found %v", selectedRowIndex, actionType, win.selectedRows)
+		}
+
+		for rowIndex := uint(1); rowIndex <= pageRows; rowIndex++ {
+			commitIndex := viewStartRowIndex + rowIndex - 1
+			expectedRow := ""
+
+			if commitIndex < commitNum {
+				expectedRow = commits[commitIndex].commit.Summary()
+			}
+
+			if row := win.row(rowIndex); !strings.HasSuffix(row, expectedRow) || (expectedRow == "" && row != "") {
+				t.Fatalf("Expected row %v to be %q after %v but found %q", rowIndex, expectedRow, actionType, row)
+			}
+		}
+	}
+}
+
+func TestWrappedSummaryRowsBelongToSelectedCommit(t *testing.T) {
+	repoDir := newTestRepository(0, t)
+	defer os.RemoveAll(repoDir)
+
+	repo, err := git.OpenRepository(repoDir)
+	if err != nil {
+		t.Fatalf("Unable to open repository: %v", err)
+	}
+	defer repo.Free()
+
+	commits := newBenchmarkCommits(repo, 20, t)
+	ref := newTestLocalBranch("master", commits[0].oid)
+
+	repoData := &benchmarkRepoData{
+		MockRepoData: &MockRepoData{},
+		commits:      commits,
+	}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: uint(len(commits))})
+	repoData.On("Commit", mock.Anything).Return(commits[0], nil)
+	repoData.On("RefsForCommit", mock.Anything).Return(&CommitRefs{})
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+	repoData.On("Head").Return(ref)
+
+	commitView := newTestCommitView(repoData)
+	commitView.rowFormat = ParseCommitRowFormat("%author %subject")
+	commitView.wrapSummary = true
+	commitView.viewDimension = ViewDimension{rows: 12, cols: 20}
+
+	if err = commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	refViewData := commitView.refViewData[ref.Name()]
+	refViewData.viewPos.SetActiveRowIndex(2)
+
+	win := NewWindow("commitView", commitView.config)
+	win.Resize(commitView.viewDimension)
+	repoData.commitsCalls = 0
+
+	if err = commitView.Render(win); err != nil {
+		t.Fatalf("Failed to render CommitView: %v", err)
+	}
+
+	if repoData.commitsCalls != 1 {
+		t.Errorf("Expected commits to be fetched once per render but they were fetched %v times", repoData.commitsCalls)
+	}
+
+	wrapRows := refViewData.wrapRows
+	if wrapRows == 0 {
+		t.Fatalf("Expected summary of selected commit to wrap")
+	}
+
+	selectedRowIndex := refViewData.viewPos.SelectedRowIndex()
+
+	for rowIndex := uint(0); rowIndex < commitView.pageRows(); rowIndex++ {
+		selected := rowIndex >= selectedRowIndex && rowIndex <= selectedRowIndex+wrapRows
+		highlighted := win.lines[rowIndex+1].cells[0].style.themeComponentID == CmpAllviewInactiveViewSelectedRow
+
+		if selected != highlighted {
+			t.Errorf("Expected row %v to have highlighted %v but found %v", rowIndex, selected, highlighted)
+		}
+
+		expectedCommitIndex := refViewData.viewPos.ViewStartRowIndex() + rowIndex
+		if selected {
+			expectedCommitIndex = refViewData.viewPos.ActiveRowIndex()
+		} else if rowIndex > selectedRowIndex {
+			expectedCommitIndex -= wrapRows
+		}
+
+		if commitIndex := commitView.commitIndexAtRow(refViewData, rowIndex); commitIndex != expectedCommitIndex {
+			t.Errorf("Expected row %v to display commit %v but found %v", rowIndex, expectedCommitIndex, commitIndex)
+		}
+	}
+}
+
+func TestCreateBranchRejectsInvalidNames(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	for _, branchName := range []string{"", "feature..x", "-feature", "feature.lock"} {
+		if err := commitView.HandleAction(Action{ActionType: ActionCreateBranch, Args: []interface{}{branchName}}); err == nil {
+			t.Errorf("Expected error when creating branch with invalid name %q", branchName)
+		}
+	}
+
+	repoData.AssertNotCalled(t, "CreateBranch", mock.Anything, mock.Anything)
+	repoData.AssertNotCalled(t, "CommitByIndex", mock.Anything, mock.Anything)
+}
+
+func TestColorForAuthorIsStable(t *testing.T) {
+	emails := []string{"alice@example.com", "bob@example.com", "carol@example.org", ""}
+
+	for _, email := range emails {
+		color := colorForAuthor(email)
+
+		if color != colorForAuthor(strings.ToUpper(email)) {
+			t.Errorf("Expected email %v to map to the same color regardless of case", email)
+		}
+
+		inPalette := false
+		for _, authorColor := range authorColorComponents {
+			inPalette = inPalette || color == authorColor
+		}
+
+		if !inPalette {
+			t.Errorf("Expected email %v to map to an author color but found %v", email, color)
+		}
+	}
+}
+
+func TestMovingNearLastLoadedCommitLoadsMoreCommits(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 20, moreAvailable: true}, t)
+	repoData.On("LoadMoreCommits", mock.Anything).Return(nil)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionNextLine}); err != nil {
+		t.Fatalf("Failed to move down a line: %v", err)
+	}
+
+	repoData.AssertNotCalled(t, "LoadMoreCommits", mock.Anything)
+
+	if err := commitView.HandleAction(Action{ActionType: ActionLastLine}); err != nil {
+		t.Fatalf("Failed to move to last line: %v", err)
+	}
+
+	repoData.AssertCalled(t, "LoadMoreCommits", ref)
+}
+
+func TestMergeCommitNavigation(t *testing.T) {
+	mergeCommit := &Commit{oid: newTestOid("8d5a2d0c5a1f4bb9e6e4d68c38c7a8c6a1b0e2f3", t)}
+
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 20}, t, func(repoData *MockRepoData) {
+		repoData.On("CommitByIndex", mock.Anything, uint(5)).Return(mergeCommit, nil)
+	})
+	repoData.On("CommitParentCount", mergeCommit).Return(uint(2))
+	repoData.On("CommitParentCount", mock.Anything).Return(uint(1))
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	var navigationTests = []struct {
+		actionType             ActionType
+		expectedActiveRowIndex uint
+	}{
+		{actionType: ActionNextMergeCommit, expectedActiveRowIndex: 5},
+		{actionType: ActionNextMergeCommit, expectedActiveRowIndex: 5},
+		{actionType: ActionLastLine, expectedActiveRowIndex: 19},
+		{actionType: ActionPrevMergeCommit, expectedActiveRowIndex: 5},
+		{actionType: ActionPrevMergeCommit, expectedActiveRowIndex: 5},
+	}
+
+	for _, navigationTest := range navigationTests {
+		if err := commitView.HandleAction(Action{ActionType: navigationTest.actionType}); err != nil {
+			t.Fatalf("Failed to handle action %v: %v", navigationTest.actionType, err)
+		}
+
+		if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != navigationTest.expectedActiveRowIndex {
+			t.Errorf("Expected active row index to be %v but found %v", navigationTest.expectedActiveRowIndex, activeRowIndex)
+		}
+	}
+}
+
+type benchmarkRepoData struct {
+	*MockRepoData
+	commits      []*Commit
+	commitsCalls uint
+	startIndexes []uint
+}
+
+func (repoData *benchmarkRepoData) Commits(ref Ref, startIndex, count uint) (<-chan *Commit, error) {
+	repoData.commitsCalls++
+	repoData.startIndexes = append(repoData.startIndexes, startIndex)
+	commitCh := make(chan *Commit)
+
+	go func() {
+		defer close(commitCh)
+
+		for commitIndex := startIndex; commitIndex < startIndex+count && commitIndex < uint(len(repoData.commits)); commitIndex++ {
+			commitCh <- repoData.commits[commitIndex]
+		}
+	}()
+
+	return commitCh, nil
+}
+
+func newBenchmarkCommits(repo *git.Repository, commitNum int, b testing.TB) (commits []*Commit) {
+	index, err := repo.Index()
+	if err != nil {
+		b.Fatalf("Unable to load index: %v", err)
+	}
+
+	treeID, err := index.WriteTree()
+	if err != nil {
+		b.Fatalf("Unable to write tree: %v", err)
+	}
+
+	tree, err := repo.LookupTree(treeID)
+	if err != nil {
+		b.Fatalf("Unable to lookup tree: %v", err)
+	}
+
+	signature := &git.Signature{
+		Name:  "Test Author",
+		Email: "test@example.com",
+		When:  time.Now(),
+	}
+
+	var parents []*git.Commit
+
+	for i := 0; i < commitNum; i++ {
+		rawOid, err := repo.CreateCommit("HEAD", signature, signature, fmt.Sprintf("Commit number %v", i), tree, parents...)
+		if err != nil {
+			b.Fatalf("Unable to create commit: %v", err)
+		}
+
+		rawCommit, err := repo.LookupCommit(rawOid)
+		if err != nil {
+			b.Fatalf("Unable to lookup commit: %v", err)
+		}
+
+		parents = []*git.Commit{rawCommit}
+		commits = append([]*Commit{{oid: &Oid{oid: rawOid}, commit: rawCommit}}, commits...)
+	}
+
+	return
+}
+
+func benchmarkCommitViewRender(clearRowCache bool, b *testing.B) {
+	repoDir, err := ioutil.TempDir("", "grv-benchmark")
+	if err != nil {
+		b.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(repoDir)
+
+	repo, err := git.InitRepository(repoDir, false)
+	if err != nil {
+		b.Fatalf("Unable to initialise repository: %v", err)
+	}
+	defer repo.Free()
+
+	commits := newBenchmarkCommits(repo, 100, b)
+	ref := newTestLocalBranch("master", commits[0].oid)
+
+	repoData := &benchmarkRepoData{
+		MockRepoData: &MockRepoData{},
+		commits:      commits,
+	}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: uint(len(commits))})
+	repoData.On("Commit", mock.Anything).Return(commits[0], nil)
+	repoData.On("RefsForCommit", mock.Anything).Return(&CommitRefs{})
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+	repoData.On("Head").Return(ref)
+
+	commitView := newTestCommitView(repoData)
+
+	if err = commitView.OnRefSelect(ref); err != nil {
+		b.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	win := NewWindow("commitView", commitView.config)
+	win.Resize(ViewDimension{rows: 60, cols: 200})
+	refViewData := commitView.refViewData[ref.Name()]
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if clearRowCache {
+			refViewData.clearRowCache()
+		}
+
+		if err = commitView.Render(win); err != nil {
+			b.Fatalf("Failed to render CommitView: %v", err)
+		}
+	}
+}
+
+func BenchmarkCommitViewRenderWithRowCache(b *testing.B) {
+	benchmarkCommitViewRender(false, b)
+}
+
+func BenchmarkCommitViewRenderWithoutRowCache(b *testing.B) {
+	benchmarkCommitViewRender(true, b)
+}
+
+func TestRenderOnSmallWindowDoesNotRequestCommits(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	for rows := uint(0); rows < 3; rows++ {
+		win := NewWindow("commitView", commitView.config)
+		win.Resize(ViewDimension{rows: rows, cols: 80})
+
+		if err := commitView.Render(win); err != nil {
+			t.Errorf("Failed to render CommitView with %v rows: %v", rows, err)
+		}
+	}
+
+	repoData.AssertNotCalled(t, "Commits", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRenderWithNoCommitsShowsMessage(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{}, t)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	win := NewWindow("commitView", commitView.config)
+	win.Resize(ViewDimension{rows: 12, cols: 80})
+
+	if err := commitView.Render(win); err != nil {
+		t.Fatalf("Failed to render CommitView: %v", err)
+	}
+
+	if line := win.Line(2); !strings.Contains(line, "No commits for master") {
+		t.Errorf("Expected no commits message but found: %v", line)
+	}
+
+	for _, actionType := range []ActionType{ActionNextLine, ActionPrevLine, ActionNextPage, ActionLastLine, ActionSelect} {
+		if err := commitView.HandleAction(Action{ActionType: actionType}); err != nil {
+			t.Errorf("Action %v failed with no commits: %v", actionType, err)
+		}
+	}
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 0 {
+		t.Errorf("Expected active row index to remain 0 but found %v", activeRowIndex)
+	}
+
+	repoData.AssertNotCalled(t, "Commits", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTitleShowsLoadingStateAndCommitCount(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+
+	repoData := &MockRepoData{}
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+
+	commitView := newTestCommitView(repoData)
+	commitView.activeRef = newTestLocalBranch("master", oid)
+
+	if title := commitView.title(CommitSetState{loading: true, commitNum: 10}); title != "Commits for master (loading...)" {
+		t.Errorf("Unexpected title while loading: %v", title)
+	}
+
+	if title := commitView.title(CommitSetState{commitNum: 25}); title != "Commits for master (25 commits)" {
+		t.Errorf("Unexpected title once loaded: %v", title)
+	}
+}
+
+func TestToggleCommitOrderRestoresSelectedCommit(t *testing.T) {
+	firstOid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	secondOid := newTestOid("5aa3f9ccf8cd7a9e7f2ea0d8b2c1265bff2ac8a1", t)
+	commits := []*Commit{{oid: firstOid}, {oid: secondOid}}
+	ref := newTestLocalBranch("master", firstOid)
+
+	repoData := &MockRepoData{}
+	repoData.On("LoadCommits", ref).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", ref).Return(CommitSetState{commitNum: 2})
+	repoData.On("Commit", firstOid).Return(commits[0], nil)
+	repoData.On("CommitByIndex", ref, uint(0)).Return(commits[0], nil)
+	repoData.On("CommitByIndex", ref, uint(1)).Return(commits[1], nil)
+	repoData.On("SetCommitSortOrder", ref, CsoTopological).Return(nil)
+
+	commitView := newTestCommitView(repoData)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	commitView.HandleAction(Action{ActionType: ActionNextLine})
+
+	if err := commitView.HandleAction(Action{ActionType: ActionToggleCommitOrder}); err != nil {
+		t.Fatalf("Failed to toggle commit order: %v", err)
+	}
+
+	repoData.AssertCalled(t, "SetCommitSortOrder", ref, CsoTopological)
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 0 {
+		t.Errorf("Expected active row index to be reset to 0 while reloading but found %v", activeRowIndex)
+	}
+
+	commitView.OnCommitsLoaded(ref, CommitSetState{commitNum: 2}, nil)
+	commitView.refreshTask.stop()
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 1 {
+		t.Errorf("Expected previously selected commit at index 1 to be selected but found %v", activeRowIndex)
+	}
+}
+
+func TestDisposeStopsRefreshTask(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{loading: true}, t)
+	commitView.Dispose()
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if commitView.refreshTask.ticker == nil {
+		t.Fatalf("Expected refresh task to be running while commits are loading")
+	}
+
+	commitView.Dispose()
+	commitView.Dispose()
+
+	if commitView.refreshTask.ticker != nil {
+		t.Errorf("Expected refresh task to be stopped after disposing of the view")
+	}
+}
+
+func TestFirstWindowIsSizedToTheView(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{loading: true}, t)
+	defer commitView.Dispose()
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	repoData.AssertCalled(t, "SetFirstWindowCommitNum", commitView.pageRows())
+}
+
+func TestOnCommitsLoadedReportsLoadingError(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{loading: true}, t)
+	errorCh := make(chan error, 100)
+	commitView.channels.errorCh = errorCh
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	commitView.OnCommitsLoaded(ref, CommitSetState{}, errors.New("Corrupt object"))
+
+	select {
+	case err := <-errorCh:
+		if expected := "Failed to load commits for ref master: Corrupt object"; err.Error() != expected {
+			t.Errorf("Reported error does not match expected value. Expected: %v, Actual: %v", expected, err)
+		}
+	default:
+		t.Errorf("Expected loading error to be reported")
+	}
+
+	if commitView.loadError == nil {
+		t.Errorf("Expected loading error to be displayed by the view")
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionDismissLoadError}); err != nil {
+		t.Fatalf("Failed to dismiss loading error: %v", err)
+	}
+
+	if commitView.loadError != nil {
+		t.Errorf("Expected loading error to be cleared once dismissed")
+	}
+}
+
+func TestToggleFirstParentReloadsCommitsFollowingFirstParents(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+	repoData.On("SetFirstParentOnly", ref, true).Return(nil)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionToggleFirstParent}); err != nil {
+		t.Fatalf("Failed to toggle first parent mode: %v", err)
+	}
+
+	commitView.refreshTask.stop()
+
+	repoData.AssertCalled(t, "SetFirstParentOnly", ref, true)
+}
+
+func TestToggleHideMergesReloadsCommitsAndRestoresSelectedCommit(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+	repoData.On("SetHideMerges", ref, true).Return(nil)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionToggleHideMerges}); err != nil {
+		t.Fatalf("Failed to toggle hiding merges: %v", err)
+	}
+
+	commitView.refreshTask.stop()
+
+	repoData.AssertCalled(t, "SetHideMerges", ref, true)
+
+	if pendingOid := commitView.refViewData[ref.Name()].pendingOid; pendingOid != ref.Oid() {
+		t.Errorf("Expected selected commit %v to be restored once loaded but found %v", ref.Oid(), pendingOid)
+	}
+}
+
+func TestReloadCommitsReloadsActiveRefOnceRefsAreLoaded(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+	oid := ref.Oid()
+	updatedOid := newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)
+	updatedRef := newTestLocalBranch("master", updatedOid)
+	refsLoaded := make(chan bool)
+
+	repoData.On("LoadStatus").Return(nil)
+	repoData.On("Head").Return(newTestLocalBranch("develop", oid))
+	repoData.On("Ref", "master").Return(updatedRef, nil)
+	repoData.On("ReloadCommits", updatedRef).Return(nil)
+	repoData.On("LoadRefs", mock.Anything).Run(func(args mock.Arguments) {
+		go func() {
+			args.Get(0).(OnRefsLoaded)(nil)
+			close(refsLoaded)
+		}()
+	})
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionReloadCommits}); err != nil {
+		t.Fatalf("Failed to reload commits: %v", err)
+	}
+
+	<-refsLoaded
+
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	commitView.refreshTask.stop()
+
+	repoData.AssertCalled(t, "ReloadCommits", updatedRef)
+
+	if activeOid := commitView.activeRef.Oid(); activeOid != updatedOid {
+		t.Errorf("Expected active ref to point to %v but found %v", updatedOid, activeOid)
+	}
+
+	if pendingOid := commitView.refViewData[ref.Name()].pendingOid; pendingOid != oid {
+		t.Errorf("Expected selected commit %v to be restored once loaded but found %v", oid, pendingOid)
+	}
+}
+
+func TestCommitMessageBody(t *testing.T) {
+	tests := []struct {
+		message      string
+		expectedBody string
+	}{
+		{"Summary only", ""},
+		{"Summary only\n", ""},
+		{"Summary\n\nFirst line of body\nSecond  line\n", "First line of body Second line"},
+	}
+
+	for _, test := range tests {
+		if body := CommitMessageBody(test.message); body != test.expectedBody {
+			t.Errorf("Body for message %q does not match expected value. Expected: %q, Actual: %q", test.message, test.expectedBody, body)
+		}
+	}
+}
+
+func TestParseBisectOutput(t *testing.T) {
+	tests := []struct {
+		output             string
+		expectedBisectStep *BisectStep
+	}{
+		{
+			output: "Bisecting: 12 revisions left to test after this (roughly 4 steps)\n[300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5] Fix rendering\n",
+			expectedBisectStep: &BisectStep{
+				output:        "Bisecting: 12 revisions left to test after this (roughly 4 steps)",
+				nextID:        "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5",
+				revisionsLeft: 12,
+				stepsLeft:     4,
+			},
+		},
+		{
+			output: "Bisecting: 0 revisions left to test after this (roughly 0 steps)\n[300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5] Fix rendering\n",
+			expectedBisectStep: &BisectStep{
+				output: "Bisecting: 0 revisions left to test after this (roughly 0 steps)",
+				nextID: "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5",
+			},
+		},
+		{
+			output: "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5 is the first bad commit\ncommit 300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5\n",
+			expectedBisectStep: &BisectStep{
+				output:     "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5 is the first bad commit",
+				firstBadID: "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5",
+			},
+		},
+		{
+			output: "status: waiting for good commit(s), bad commit known\n",
+			expectedBisectStep: &BisectStep{
+				output: "status: waiting for good commit(s), bad commit known",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		if bisectStep := parseBisectOutput(test.output); !reflect.DeepEqual(bisectStep, test.expectedBisectStep) {
+			t.Errorf("Bisect step does not match expected value for output %q. Expected: %+v, Actual: %+v", test.output, test.expectedBisectStep, bisectStep)
+		}
+	}
+}
+
+func TestInteractiveRebaseRequiresCleanWorkingTree(t *testing.T) {
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+	repoData.On("Workdir").Return("/tmp/repo")
+	repoData.On("RebaseInProgress").Return(false)
+	repoData.On("Status").Return(&Status{
+		entries: map[StatusType][]*StatusEntry{
+			StUnstaged: {&StatusEntry{}},
+		},
+	})
+
+	actionCh := make(chan Action, 100)
+	commitView.channels.actionCh = actionCh
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionInteractiveRebase}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for {
+		select {
+		case action := <-actionCh:
+			if action.ActionType == ActionRunCommand {
+				t.Errorf("Expected rebase not to be run with uncommitted changes")
+			}
+		default:
+			return
+		}
+	}
+}
+
+func TestAmendCommitRequiresHeadCommit(t *testing.T) {
+	headOid := newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)
+
+	commitView, repoData, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 100}, t)
+	repoData.On("Workdir").Return("/tmp/repo")
+	repoData.On("Head").Return(newTestLocalBranch("master", headOid))
+	actionCh := make(chan Action, 100)
+	commitView.channels.actionCh = actionCh
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionAmendCommit}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	repoData.AssertNotCalled(t, "AmendCommitMessage", mock.Anything)
+
+	for {
+		select {
+		case action := <-actionCh:
+			if action.ActionType == ActionRunCommand {
+				t.Errorf("Expected editor not to be run for a commit which is not HEAD")
+			}
+		default:
+			return
+		}
+	}
+}
+
+func TestIsHeadCommitComparesCommitWithHeadOid(t *testing.T) {
+	headOid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	otherOid := newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)
+
+	repoData := &MockRepoData{}
+	repoData.On("Head").Return(newTestLocalBranch("master", newTestOid(headOid.String(), t)))
+
+	commitView := newTestCommitView(repoData)
+
+	if !commitView.isHeadCommit(&Commit{oid: headOid}) {
+		t.Errorf("Expected commit %v to be the HEAD commit", headOid)
+	}
+
+	if commitView.isHeadCommit(&Commit{oid: otherOid}) {
+		t.Errorf("Expected commit %v not to be the HEAD commit", otherOid)
+	}
+}
+
+func TestCommitMinimapBucket(t *testing.T) {
+	tests := []struct {
+		trackRows           uint
+		rowIndex            uint
+		commitNum           uint
+		expectedStartCommit uint
+		expectedEndCommit   uint
+	}{
+		{10, 0, 100, 0, 10},
+		{10, 9, 100, 90, 100},
+		{3, 1, 10, 3, 6},
+		{3, 2, 10, 6, 10},
+		{10, 2, 5, 2, 3},
+		{10, 7, 5, 0, 0},
+		{0, 0, 5, 0, 0},
+	}
+
+	for _, test := range tests {
+		startCommit, endCommit := commitMinimapBucket(test.trackRows, test.rowIndex, test.commitNum)
+
+		if startCommit != test.expectedStartCommit || endCommit != test.expectedEndCommit {
+			t.Errorf("Row %v of %v rows for %v commits returned (%v, %v) but expected (%v, %v)",
+				test.rowIndex, test.trackRows, test.commitNum, startCommit, endCommit,
+				test.expectedStartCommit, test.expectedEndCommit)
+		}
+	}
+}
+
+func TestMinimapDensityChar(t *testing.T) {
+	tests := []struct {
+		merges       uint
+		samples      uint
+		expectedChar rune
+	}{
+		{0, 8, '·'},
+		{1, 8, ':'},
+		{4, 8, '+'},
+		{8, 8, '#'},
+		{0, 0, '·'},
+	}
+
+	for _, test := range tests {
+		if char := minimapDensityChar(test.merges, test.samples); char != test.expectedChar {
+			t.Errorf("%v merges in %v samples returned %q but expected %q", test.merges, test.samples, char, test.expectedChar)
+		}
+	}
+}
+
+func TestCommitDecorations(t *testing.T) {
+	oid := newTestOid("9bc8bf12571b27fac1a4b4bbf7a2b1af4d0e2c07", t)
+	otherOid := newTestOid("1c5a6d7e2f0b3c4d5e6f708192a3b4c5d6e7f809", t)
+	master := newTestLocalBranch("master", oid)
+	commitRefs := &CommitRefs{
+		tags: []*Tag{{oid: oid, name: "refs/tags/v1.2", shorthand: "v1.2"}},
+		branches: []Branch{
+			newRemoteBranch(oid, "refs/remotes/origin/master", "origin/master"),
+			master,
+			newTestLocalBranch("feature", oid),
+		},
+	}
+
+	tests := []struct {
+		head                Ref
+		expectedDecorations []string
+	}{
+		{master, []string{"HEAD -> master", "feature", "origin/master", "tag: v1.2"}},
+		{&HEAD{oid: oid}, []string{"HEAD", "master", "feature", "origin/master", "tag: v1.2"}},
+		{newTestLocalBranch("other", otherOid), []string{"master", "feature", "origin/master", "tag: v1.2"}},
+	}
+
+	for _, test := range tests {
+		if decorations := CommitDecorations(test.head, oid, commitRefs); !reflect.DeepEqual(decorations, test.expectedDecorations) {
+			t.Errorf("Decorations with HEAD %v do not match expected value. Expected: %v, Actual: %v",
+				test.head.Name(), test.expectedDecorations, decorations)
+		}
+	}
+
+	if decorations := CommitDecorations(master, otherOid, &CommitRefs{}); len(decorations) > 0 {
+		t.Errorf("Expected no decorations but found %v", decorations)
+	}
+}
+
+type testCommitRangeListener struct {
+	commitRangeCh chan [2]*Commit
+}
+
+func (listener *testCommitRangeListener) OnCommitSelected(commit *Commit) error {
+	return nil
+}
+
+func (listener *testCommitRangeListener) OnCommitRangeSelected(fromCommit, toCommit *Commit) error {
+	listener.commitRangeCh <- [2]*Commit{fromCommit, toCommit}
+	return nil
+}
+
+func TestDiffAgainstMarkedCommitNotifiesCommitRangeListeners(t *testing.T) {
+	markedCommit := &Commit{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)}
+	selectedCommit := &Commit{oid: newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)}
+	ref := newTestLocalBranch("master", selectedCommit.oid)
+
+	repoData := &MockRepoData{}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: 2})
+	repoData.On("Commit", markedCommit.oid).Return(markedCommit, nil)
+	repoData.On("CommitByIndex", ref, uint(0)).Return(markedCommit, nil)
+	repoData.On("CommitByIndex", ref, uint(1)).Return(selectedCommit, nil)
+
+	commitView := newTestCommitView(repoData)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	listener := &testCommitRangeListener{commitRangeCh: make(chan [2]*Commit, 1)}
+	commitView.RegisterCommitViewListener(listener)
+
+	for _, actionType := range []ActionType{ActionDiffAgainstMark, ActionMarkCommit, ActionNextLine, ActionDiffAgainstMark} {
+		if err := commitView.HandleAction(Action{ActionType: actionType}); err != nil {
+			t.Fatalf("Unexpected error handling action %v: %v", actionType, err)
+		}
+	}
+
+	commitRange := <-listener.commitRangeCh
+
+	if commitRange[0] != markedCommit || commitRange[1] != selectedCommit {
+		t.Errorf("Expected diff from %v to %v but found %v to %v",
+			markedCommit.oid, selectedCommit.oid, commitRange[0].oid, commitRange[1].oid)
+	}
+
+	if commitView.markedOid != nil {
+		t.Errorf("Expected commit mark to be cleared but found %v", commitView.markedOid)
+	}
+}
+
+func TestCommitMarkIsOnlyClearedByClearCommitMark(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+	oid := ref.Oid()
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	for _, actionType := range []ActionType{ActionMarkCommit, ActionDismissLoadError} {
+		if err := commitView.HandleAction(Action{ActionType: actionType}); err != nil {
+			t.Fatalf("Unexpected error handling action %v: %v", actionType, err)
+		}
+	}
+
+	if commitView.markedOid != oid {
+		t.Fatalf("Expected commit %v to remain marked after dismissing load error but found %v", oid, commitView.markedOid)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionClearCommitMark}); err != nil {
+		t.Fatalf("Unexpected error clearing commit mark: %v", err)
+	}
+
+	if commitView.markedOid != nil {
+		t.Errorf("Expected commit mark to be cleared but found %v", commitView.markedOid)
+	}
+}
+
+func TestSelectionTracksSelectedCommitAsCommitSetGrows(t *testing.T) {
+	commits := []*Commit{
+		{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)},
+		{oid: newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)},
+		{oid: newTestOid("9bc8bf12571b27fac1a4b4bbf7a2b1af4d0e2c07", t)},
+		{oid: newTestOid("1c5a6d7e2f0b3c4d5e6f708192a3b4c5d6e7f809", t)},
+	}
+	ref := newTestLocalBranch("master", commits[1].oid)
+
+	repoData := &MockRepoData{}
+	setLoadedCommits := func(loadedCommits []*Commit, loading bool) {
+		repoData.ExpectedCalls = nil
+		repoData.On("LoadCommits", mock.Anything).Return(nil)
+		repoData.On("SetFirstWindowCommitNum", mock.Anything)
+		repoData.On("Commit", mock.Anything).Return(loadedCommits[0], nil)
+		repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: uint(len(loadedCommits)), loading: loading})
+
+		for commitIndex, commit := range loadedCommits {
+			repoData.On("CommitByIndex", ref, uint(commitIndex)).Return(commit, nil)
+		}
+	}
+
+	setLoadedCommits(commits[1:3], true)
+	commitView := newTestCommitView(repoData)
+	defer commitView.Dispose()
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionNextLine}); err != nil {
+		t.Fatalf("Failed to move down a line: %v", err)
+	}
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 1 {
+		t.Fatalf("Expected active row index to be 1 but found %v", activeRowIndex)
+	}
+
+	setLoadedCommits(commits, true)
+	commitView.OnCommitsUpdated(ref)
+
+	if activeRowIndex := commitView.ViewPos().ActiveRowIndex(); activeRowIndex != 2 {
+		t.Errorf("Expected selection to follow commit %v to row index 2 but found %v", commits[2].oid, activeRowIndex)
+	}
+}
+
+func TestCycleSelectedCommitDateFormatRevertsToGlobalFormat(t *testing.T) {
+	commitView, _, ref := newTestCommitViewWithRef(CommitSetState{commitNum: 1}, t)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	expectedFormats := []CommitDateFormat{CdfRelative, CdfFull}
+
+	for _, expectedFormat := range expectedFormats {
+		if err := commitView.HandleAction(Action{ActionType: ActionCycleDateFormat}); err != nil {
+			t.Fatalf("Unexpected error cycling date format: %v", err)
+		}
+
+		if dateFormat := commitView.rowDateFormats[ref.Oid()]; dateFormat != expectedFormat {
+			t.Errorf("Row date format does not match expected value. Expected: %v, Actual: %v", expectedFormat, dateFormat)
+		}
+	}
+
+	if err := commitView.HandleAction(Action{ActionType: ActionCycleDateFormat}); err != nil {
+		t.Fatalf("Unexpected error cycling date format: %v", err)
+	}
+
+	if dateFormat, ok := commitView.rowDateFormats[ref.Oid()]; ok {
+		t.Errorf("Expected row to revert to the global date format but found %v", dateFormat)
+	}
+
+	if commitView.dateFormat != CdfShort {
+		t.Errorf("Expected global date format to be unchanged but found %v", commitView.dateFormat)
+	}
+}
+
+func TestFormatCommitDate(t *testing.T) {
+	date := time.Date(2017, 6, 30, 14, 5, 0, 0, time.UTC)
+
+	var formatCommitDateTests = []struct {
+		dateFormat   CommitDateFormat
+		expectedDate string
+	}{
+		{dateFormat: CdfShort, expectedDate: "2017-06-30 14:05"},
+		{dateFormat: CdfRelative, expectedDate: FormatRelativeTime(date, date.Add(3*time.Hour))},
+		{dateFormat: CdfFull, expectedDate: "2017-06-30T14:05:00Z"},
+	}
+
+	for _, formatCommitDateTest := range formatCommitDateTests {
+		actualDate := FormatCommitDate(date, formatCommitDateTest.dateFormat, date.Add(3*time.Hour))
+
+		if actualDate != formatCommitDateTest.expectedDate {
+			t.Errorf("FormatCommitDate output does not match expected value. Expected: %v, Actual: %v",
+				formatCommitDateTest.expectedDate, actualDate)
+		}
+	}
+}
+
+func TestCachedCommitDateIsFormattedWhenRendered(t *testing.T) {
+	commit := &Commit{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)}
+	when := time.Date(2017, 6, 30, 14, 5, 0, 0, time.UTC)
+
+	commitView := newTestCommitView(&MockRepoData{})
+	commitView.SetRowFormat("%date")
+
+	refViewData := newReferenceViewData(commitView.columnNum())
+	refViewData.tableFormatter.Resize(1)
+	refViewData.rowCache[commit.oid] = &commitRowCacheEntry{when: when}
+
+	var renderedDateTests = []struct {
+		dateFormat   CommitDateFormat
+		expectedDate string
+	}{
+		{dateFormat: CdfShort, expectedDate: "2017-06-30 14:05"},
+		{dateFormat: CdfFull, expectedDate: "2017-06-30T14:05:00Z"},
+	}
+
+	for _, renderedDateTest := range renderedDateTests {
+		commitView.dateFormat = renderedDateTest.dateFormat
+
+		if err := commitView.renderCommit(refViewData, 0, commit, ""); err != nil {
+			t.Fatalf("Unexpected error rendering commit: %v", err)
+		}
+
+		if rowString, _ := refViewData.tableFormatter.RowString(0); rowString != renderedDateTest.expectedDate+tfSeparator {
+			t.Errorf("Rendered date does not match expected value. Expected: %q, Actual: %q", renderedDateTest.expectedDate+tfSeparator, rowString)
+		}
+	}
+}
+
+func TestBareOidIsDisplayedSeparatelyFromDetachedHead(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	headOid := newTestOid("8d5a2d0c5a1f4bb9e6e4d68c38c7a8c6a1b0e2f3", t)
+	oidRef := NewOidRef(oid)
+	head := &HEAD{oid: headOid}
+
+	repoData := &MockRepoData{}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: 1})
+	repoData.On("Commit", oid).Return(&Commit{oid: oid}, nil)
+	repoData.On("Head").Return(head)
+	repoData.On("AheadBehind", mock.Anything).Return(uint(0), uint(0), false)
+
+	commitView := newTestCommitView(repoData)
+	defer commitView.Dispose()
+
+	if err := commitView.OnRefSelect(oidRef); err != nil {
+		t.Fatalf("Failed to select oid %v: %v", oid, err)
+	}
+
+	if _, ok := commitView.refViewData[oid.String()]; !ok {
+		t.Errorf("Expected view state to be stored under oid %v", oid)
+	}
+
+	if title := commitView.title(CommitSetState{commitNum: 1}); title != "Commits for 300dc7f (1 commits)" {
+		t.Errorf("Title does not match expected value. Actual: %v", title)
+	}
+
+	commitView.OnRefsChanged(nil, []Ref{head}, nil)
+
+	if commitView.activeRef != oidRef {
+		t.Errorf("Expected oid to remain active after detached HEAD was removed but found %v", commitView.activeRef.Name())
+	}
+
+	gitStatusView := NewGitStatusView(repoData, newTestChannels())
+
+	if title := gitStatusView.title(); title != "Status (detached at 8d5a2d0)" {
+		t.Errorf("Expected git status title to show detached HEAD but found: %v", title)
+	}
+}
+
+func TestCommitMessagePreview(t *testing.T) {
+	var commitMessagePreviewTests = []struct {
+		message       string
+		width         uint
+		expectedLines []string
+	}{
+		{
+			message:       "Summary only\n",
+			width:         20,
+			expectedLines: []string{"Summary only"},
+		},
+		{
+			message:       "Summary\n\nThe body is wrapped between words\n\nSecond paragraph\n",
+			width:         12,
+			expectedLines: []string{"The body is", "wrapped", "between", "words", "", "Second", "paragraph"},
+		},
+		{
+			message:       "Summary\n\nabcdefghij\n",
+			width:         4,
+			expectedLines: []string{"abcd", "efgh", "ij"},
+		},
+	}
+
+	for _, commitMessagePreviewTest := range commitMessagePreviewTests {
+		lines := CommitMessagePreview(commitMessagePreviewTest.message, commitMessagePreviewTest.width)
+
+		if !reflect.DeepEqual(lines, commitMessagePreviewTest.expectedLines) {
+			t.Errorf("CommitMessagePreview(%q, %v) does not match expected value. Expected: %q, Actual: %q",
+				commitMessagePreviewTest.message, commitMessagePreviewTest.width, commitMessagePreviewTest.expectedLines, lines)
+		}
+	}
+}
+
+func TestCommitPreviewReducesPageRowsWhenThereIsSpace(t *testing.T) {
+	commitView := newTestCommitView(&MockRepoData{})
+	config := commitView.config.(ConfigSetter)
+
+	var commitPreviewTests = []struct {
+		previewLines      string
+		expectedPageRows  uint
+		expectedPreviewed bool
+	}{
+		{previewLines: "0", expectedPageRows: 10, expectedPreviewed: false},
+		{previewLines: "3", expectedPageRows: 6, expectedPreviewed: true},
+		{previewLines: "10", expectedPageRows: 10, expectedPreviewed: false},
+	}
+
+	for _, commitPreviewTest := range commitPreviewTests {
+		if errs := config.Evaluate("set commitpreview " + commitPreviewTest.previewLines); len(errs) > 0 {
+			t.Fatalf("Failed to set commitpreview: %v", errs)
+		}
+
+		if pageRows := commitView.pageRows(); pageRows != commitPreviewTest.expectedPageRows {
+			t.Errorf("Page rows for preview of %v lines does not match expected value. Expected: %v, Actual: %v",
+				commitPreviewTest.previewLines, commitPreviewTest.expectedPageRows, pageRows)
+		}
+
+		if previewed := commitView.previewRows() > 0; previewed != commitPreviewTest.expectedPreviewed {
+			t.Errorf("Expected preview of %v lines to be displayed: %v", commitPreviewTest.previewLines, commitPreviewTest.expectedPreviewed)
+		}
+	}
+}
+
+func TestConfigChangesAfterSwitchingRepositoryDoNotReachDisposedViews(t *testing.T) {
+	channels := newTestChannels()
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+	head := &HEAD{oid: newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)}
+
+	prevRepoData := &MockRepoData{}
+	prevRepoData.On("RegisterCommitSetListener", mock.Anything).Return()
+	prevRepoData.On("RegisterRefStateListener", mock.Anything).Return()
+	prevRepoData.On("LoadHead").Return(nil)
+	prevRepoData.On("LoadRefs", mock.Anything).Return()
+	prevRepoData.On("Head").Return(head)
+	prevRepoData.On("Branches").Return([]Branch{}, []Branch{}, false)
+	prevRepoData.On("Tags").Return([]*Tag{}, false)
+
+	prevCommitView := NewCommitView(prevRepoData, channels, config)
+	prevRefView := NewRefView(prevRepoData, channels, config)
+
+	if err := prevCommitView.Initialise(); err != nil {
+		t.Fatalf("Failed to initialise CommitView: %v", err)
+	}
+
+	if err := prevRefView.Initialise(); err != nil {
+		t.Fatalf("Failed to initialise RefView: %v", err)
+	}
+
+	prevRefView.refsLoaded = true
+
+	prevCommitView.Dispose()
+	prevRefView.Dispose()
+	callNum := len(prevRepoData.Calls)
+
+	for _, configCommand := range []string{"set refsortorder date", "set defaultref feature", "set shortoidlength 10"} {
+		if errs := config.Evaluate(configCommand); len(errs) > 0 {
+			t.Fatalf("Failed to evaluate %q: %v", configCommand, errs)
+		}
+	}
+
+	if len(prevRepoData.Calls) != callNum {
+		t.Errorf("Expected disposed views to not access the previous repository but found calls: %v", prevRepoData.Calls[callNum:])
+	}
+
+	for _, configVariable := range append(commitViewConfigVariables, refViewConfigVariables...) {
+		if listeners := config.getVariable(configVariable).onChangeListeners; len(listeners) > 0 {
+			t.Errorf("Expected no listeners for %v after disposing of views but found %v", configVariable, len(listeners))
+		}
+	}
+}
+
+func TestActiveRefViewStateIsRetainedWhenHeadCannotBeDisplayed(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	ref := newTestLocalBranch("feature", oid)
+	head := &HEAD{oid: oid}
+
+	var headTests = []struct {
+		head         Ref
+		loadHeadErr  error
+		expectedDesc string
+	}{
+		{head: nil, expectedDesc: "HEAD not loaded"},
+		{head: head, loadHeadErr: fmt.Errorf("load failed"), expectedDesc: "loading HEAD commits failed"},
+	}
+
+	for _, headTest := range headTests {
+		repoData := &MockRepoData{}
+		repoData.On("LoadCommits", ref).Return(nil)
+		repoData.On("LoadCommits", head).Return(headTest.loadHeadErr)
+		repoData.On("SetFirstWindowCommitNum", mock.Anything)
+		repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: 1})
+		repoData.On("Commit", oid).Return(&Commit{oid: oid}, nil)
+		repoData.On("Head").Return(headTest.head)
+
+		commitView := newTestCommitView(repoData)
+
+		if err := commitView.OnRefSelect(ref); err != nil {
+			t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+		}
+
+		commitView.OnRefsChanged(nil, []Ref{ref}, nil)
+
+		if _, ok := commitView.refViewData[commitView.activeRef.Name()]; !ok {
+			t.Errorf("Expected view state of active ref %v to be retained when %v", commitView.activeRef.Name(), headTest.expectedDesc)
+		} else if commitView.ViewPos() == nil {
+			t.Errorf("Expected view position to be available when %v", headTest.expectedDesc)
+		}
+
+		commitView.Dispose()
+	}
+}
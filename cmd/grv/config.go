@@ -13,14 +13,28 @@ import (
 )
 
 const (
-	cfDefaultConfigHomeDir = "/.config"
-	cfGrvConfigDir         = "/grv"
-	cfGrvrcFile            = "/grvrc"
-	cfTabWidthMinValue     = 1
-	cfTabWidthDefaultValue = 8
-	cfClassicThemeName     = "classic"
-	cfColdThemeName        = "cold"
-	cfSolarizedThemeName   = "solarized"
+	cfDefaultConfigHomeDir          = "/.config"
+	cfGrvConfigDir                  = "/grv"
+	cfGrvrcFile                     = "/grvrc"
+	cfTabWidthMinValue              = 1
+	cfTabWidthDefaultValue          = 8
+	cfCommitRefreshRateMinValue     = 10
+	cfCommitRefreshRateDefaultValue = 500
+	cfCommitRowFormatDefaultValue   = "%oid %date %author %subject"
+	cfCommitLoadLimitMinValue       = 0
+	cfCommitLoadLimitDefaultValue   = 0
+	cfShortOidLengthMinValue        = 4
+	cfShortOidLengthMaxValue        = 40
+	cfShortOidLengthDefaultValue    = 7
+	cfCommitPreviewMinValue         = 0
+	cfCommitPreviewMaxValue         = 10
+	cfCommitPreviewDefaultValue     = 0
+	cfRefSortOrderName              = "name"
+	cfRefSortOrderDate              = "date"
+	cfDefaultRefHead                = "HEAD"
+	cfClassicThemeName              = "classic"
+	cfColdThemeName                 = "cold"
+	cfSolarizedThemeName            = "solarized"
 
 	cfAllView       = "All"
 	cfMainView      = "MainView"
@@ -34,6 +48,12 @@ const (
 	cfHelpBarView   = "HelpBarView"
 	cfErrorView     = "ErrorView"
 	cfGitStatusView = "GitStatusView"
+	cfBlameView     = "BlameView"
+	cfHelpView      = "HelpView"
+	cfReflogView    = "ReflogView"
+	cfTreeView      = "TreeView"
+	cfStashView     = "StashView"
+	cfStatsView     = "StatsView"
 )
 
 // ConfigVariable stores a config variable name
@@ -44,6 +64,26 @@ const (
 	CfTabWidth ConfigVariable = "tabwidth"
 	// CfTheme stores the theme variable name
 	CfTheme ConfigVariable = "theme"
+	// CfCommitRefreshRate stores the commit refresh rate variable name
+	CfCommitRefreshRate ConfigVariable = "commitrefreshrate"
+	// CfMouse stores whether mouse support is enabled
+	CfMouse ConfigVariable = "mouse"
+	// CfCommitRowFormat stores the commit view row format
+	CfCommitRowFormat ConfigVariable = "commitrowformat"
+	// CfCommitLoadLimit stores the number of commits loaded before loading pauses until more are required
+	CfCommitLoadLimit ConfigVariable = "commitloadlimit"
+	// CfAuthorColors stores whether commit authors are colored by author email
+	CfAuthorColors ConfigVariable = "authorcolors"
+	// CfCommitDecorations stores whether the refs pointing to each commit are displayed after its summary
+	CfCommitDecorations ConfigVariable = "commitdecorations"
+	// CfShortOidLength stores the number of characters abbreviated commit ids are displayed with
+	CfShortOidLength ConfigVariable = "shortoidlength"
+	// CfCommitPreview stores the number of lines of the selected commit message previewed below the commit view
+	CfCommitPreview ConfigVariable = "commitpreview"
+	// CfRefSortOrder stores whether refs are sorted by name or by commit date
+	CfRefSortOrder ConfigVariable = "refsortorder"
+	// CfDefaultRef stores the name of the ref displayed on startup
+	CfDefaultRef ConfigVariable = "defaultref"
 )
 
 var systemColorValues = map[string]SystemColorValue{
@@ -71,6 +111,12 @@ var viewIDNames = map[string]ViewID{
 	cfHelpBarView:   ViewHelpBar,
 	cfErrorView:     ViewError,
 	cfGitStatusView: ViewGitStatus,
+	cfBlameView:     ViewBlame,
+	cfHelpView:      ViewHelp,
+	cfReflogView:    ViewReflog,
+	cfTreeView:      ViewTree,
+	cfStashView:     ViewStash,
+	cfStatsView:     ViewStats,
 }
 
 var themeComponents = map[string]ThemeComponentID{
@@ -78,6 +124,7 @@ var themeComponents = map[string]ThemeComponentID{
 	cfAllView + ".SearchMatch":             CmpAllviewSearchMatch,
 	cfAllView + ".ActiveViewSelectedRow":   CmpAllviewActiveViewSelectedRow,
 	cfAllView + ".InactiveViewSelectedRow": CmpAllviewInactiveViewSelectedRow,
+	cfAllView + ".Border":                  CmpAllviewBorder,
 
 	cfMainView + ".ActiveView": CmpMainviewActiveView,
 	cfMainView + ".NormalView": CmpMainviewNormalView,
@@ -92,19 +139,42 @@ var themeComponents = map[string]ThemeComponentID{
 	cfRefView + ".TagsHeader":           CmpRefviewTagsHeader,
 	cfRefView + ".Tag":                  CmpRefviewTag,
 
-	cfCommitView + ".Title":        CmpCommitviewTitle,
-	cfCommitView + ".Footer":       CmpCommitviewFooter,
-	cfCommitView + ".ShortOid":     CmpCommitviewShortOid,
-	cfCommitView + ".Date":         CmpCommitviewDate,
-	cfCommitView + ".Author":       CmpCommitviewAuthor,
-	cfCommitView + ".Summary":      CmpCommitviewSummary,
-	cfCommitView + ".Tag":          CmpCommitviewTag,
-	cfCommitView + ".LocalBranch":  CmpCommitviewLocalBranch,
-	cfCommitView + ".RemoteBranch": CmpCommitviewRemoteBranch,
+	cfCommitView + ".Title":            CmpCommitviewTitle,
+	cfCommitView + ".Footer":           CmpCommitviewFooter,
+	cfCommitView + ".LoadError":        CmpCommitviewLoadError,
+	cfCommitView + ".ShortOid":         CmpCommitviewShortOid,
+	cfCommitView + ".Date":             CmpCommitviewDate,
+	cfCommitView + ".Author":           CmpCommitviewAuthor,
+	cfCommitView + ".Graph":            CmpCommitviewGraph,
+	cfCommitView + ".Summary":          CmpCommitviewSummary,
+	cfCommitView + ".Tag":              CmpCommitviewTag,
+	cfCommitView + ".Decoration":       CmpCommitviewDecoration,
+	cfCommitView + ".LocalBranch":      CmpCommitviewLocalBranch,
+	cfCommitView + ".RemoteBranch":     CmpCommitviewRemoteBranch,
+	cfCommitView + ".AuthorColor1":     CmpCommitviewAuthorColor1,
+	cfCommitView + ".AuthorColor2":     CmpCommitviewAuthorColor2,
+	cfCommitView + ".AuthorColor3":     CmpCommitviewAuthorColor3,
+	cfCommitView + ".AuthorColor4":     CmpCommitviewAuthorColor4,
+	cfCommitView + ".AuthorColor5":     CmpCommitviewAuthorColor5,
+	cfCommitView + ".AuthorColor6":     CmpCommitviewAuthorColor6,
+	cfCommitView + ".ScrollBar":        CmpCommitviewScrollBar,
+	cfCommitView + ".Minimap":          CmpCommitviewMinimap,
+	cfCommitView + ".MinimapWindow":    CmpCommitviewMinimapWindow,
+	cfCommitView + ".Preview":          CmpCommitviewPreview,
+	cfCommitView + ".PreviewSeparator": CmpCommitviewPreviewSeparator,
+	cfCommitView + ".SignatureGood":    CmpCommitviewSignatureGood,
+	cfCommitView + ".SignatureBad":     CmpCommitviewSignatureBad,
+	cfCommitView + ".SignatureNone":    CmpCommitviewSignatureNone,
+	cfCommitView + ".DiffStatAdded":    CmpCommitviewDiffStatAdded,
+	cfCommitView + ".DiffStatRemoved":  CmpCommitviewDiffStatRemoved,
+	cfCommitView + ".HeadMarker":       CmpCommitviewHeadMarker,
+	cfCommitView + ".MarkedCommit":     CmpCommitviewMarkedCommit,
 
 	cfDiffView + ".Title":                 CmpDiffviewTitle,
 	cfDiffView + ".Footer":                CmpDiffviewFooter,
 	cfDiffView + ".Normal":                CmpDiffviewDifflineNormal,
+	cfDiffView + ".CommitOid":             CmpDiffviewDifflineDiffCommitOid,
+	cfDiffView + ".CommitParent":          CmpDiffviewDifflineDiffCommitParent,
 	cfDiffView + ".CommitAuthor":          CmpDiffviewDifflineDiffCommitAuthor,
 	cfDiffView + ".CommitAuthorDate":      CmpDiffviewDifflineDiffCommitAuthorDate,
 	cfDiffView + ".CommitCommitter":       CmpDiffviewDifflineDiffCommitCommitter,
@@ -116,8 +186,12 @@ var themeComponents = map[string]ThemeComponentID{
 	cfDiffView + ".UnifiedDiffHeader":     CmpDiffviewDifflineUnifiedDiffHeader,
 	cfDiffView + ".HunkStart":             CmpDiffviewDifflineHunkStart,
 	cfDiffView + ".HunkHeader":            CmpDiffviewDifflineHunkHeader,
+	cfDiffView + ".LineNumber":            CmpDiffviewDifflineLineNumber,
+	cfDiffView + ".Submodule":             CmpDiffviewDifflineSubmodule,
 	cfDiffView + ".AddedLine":             CmpDiffviewDifflineLineAdded,
 	cfDiffView + ".RemovedLine":           CmpDiffviewDifflineLineRemoved,
+	cfDiffView + ".AddedWord":             CmpDiffviewDifflineLineAddedWord,
+	cfDiffView + ".RemovedWord":           CmpDiffviewDifflineLineRemovedWord,
 
 	cfGitStatusView + ".StagedTitle":     CmpGitStatusStagedTitle,
 	cfGitStatusView + ".UnstagedTitle":   CmpGitStatusUnstagedTitle,
@@ -128,6 +202,47 @@ var themeComponents = map[string]ThemeComponentID{
 	cfGitStatusView + ".UntrackedFile":   CmpGitStatusUntrackedFile,
 	cfGitStatusView + ".ConflictedFile":  CmpGitStatusConflictedFile,
 
+	cfBlameView + ".Title":       CmpBlameviewTitle,
+	cfBlameView + ".Footer":      CmpBlameviewFooter,
+	cfBlameView + ".ShortOid":    CmpBlameviewShortOid,
+	cfBlameView + ".RenamedFrom": CmpBlameviewRenamedFrom,
+	cfBlameView + ".Author":      CmpBlameviewAuthor,
+	cfBlameView + ".Date":        CmpBlameviewDate,
+	cfBlameView + ".LineNumber":  CmpBlameviewLineNumber,
+	cfBlameView + ".Line":        CmpBlameviewLine,
+
+	cfReflogView + ".Title":    CmpReflogviewTitle,
+	cfReflogView + ".Footer":   CmpReflogviewFooter,
+	cfReflogView + ".Selector": CmpReflogviewSelector,
+	cfReflogView + ".ShortOid": CmpReflogviewShortOid,
+	cfReflogView + ".Date":     CmpReflogviewDate,
+	cfReflogView + ".Message":  CmpReflogviewMessage,
+
+	cfTreeView + ".Title":     CmpTreeviewTitle,
+	cfTreeView + ".Footer":    CmpTreeviewFooter,
+	cfTreeView + ".Directory": CmpTreeviewDirectory,
+	cfTreeView + ".File":      CmpTreeviewFile,
+
+	cfStashView + ".Title":    CmpStashviewTitle,
+	cfStashView + ".Footer":   CmpStashviewFooter,
+	cfStashView + ".Selector": CmpStashviewSelector,
+	cfStashView + ".Branch":   CmpStashviewBranch,
+	cfStashView + ".Message":  CmpStashviewMessage,
+
+	cfStatsView + ".Title":        CmpStatsviewTitle,
+	cfStatsView + ".Footer":       CmpStatsviewFooter,
+	cfStatsView + ".Author":       CmpStatsviewAuthor,
+	cfStatsView + ".Commits":      CmpStatsviewCommits,
+	cfStatsView + ".LinesAdded":   CmpStatsviewLinesAdded,
+	cfStatsView + ".LinesRemoved": CmpStatsviewLinesRemoved,
+	cfStatsView + ".Date":         CmpStatsviewDate,
+
+	cfHelpView + ".Title":        CmpHelpviewTitle,
+	cfHelpView + ".Footer":       CmpHelpviewFooter,
+	cfHelpView + ".SectionTitle": CmpHelpviewSectionTitle,
+	cfHelpView + ".Key":          CmpHelpviewKey,
+	cfHelpView + ".Description":  CmpHelpviewDescription,
+
 	cfStatusBarView + ".Normal": CmpStatusbarviewNormal,
 
 	cfHelpBarView + ".Special": CmpHelpbarviewSpecial,
@@ -150,6 +265,7 @@ type Config interface {
 	GetFloat(ConfigVariable) float64
 	GetTheme() Theme
 	AddOnChangeListener(ConfigVariable, ConfigVariableOnChangeListener)
+	RemoveOnChangeListener(ConfigVariable, ConfigVariableOnChangeListener)
 	ConfigDir() string
 }
 
@@ -199,8 +315,11 @@ func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuratio
 
 	config.variables = map[ConfigVariable]*ConfigurationVariable{
 		CfTabWidth: {
-			value:     cfTabWidthDefaultValue,
-			validator: tabWidithValidator{},
+			value: cfTabWidthDefaultValue,
+			validator: integerValidator{
+				configVariable: CfTabWidth,
+				minValue:       cfTabWidthMinValue,
+			},
 		},
 		CfTheme: {
 			value: cfSolarizedThemeName,
@@ -208,6 +327,61 @@ func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuratio
 				config: config,
 			},
 		},
+		CfCommitRefreshRate: {
+			value: cfCommitRefreshRateDefaultValue,
+			validator: integerValidator{
+				configVariable: CfCommitRefreshRate,
+				minValue:       cfCommitRefreshRateMinValue,
+			},
+		},
+		CfMouse: {
+			value:     false,
+			validator: booleanValidator{},
+		},
+		CfCommitRowFormat: {
+			value: cfCommitRowFormatDefaultValue,
+		},
+		CfCommitLoadLimit: {
+			value: cfCommitLoadLimitDefaultValue,
+			validator: integerValidator{
+				configVariable: CfCommitLoadLimit,
+				minValue:       cfCommitLoadLimitMinValue,
+			},
+		},
+		CfAuthorColors: {
+			value:     true,
+			validator: booleanValidator{},
+		},
+		CfCommitDecorations: {
+			value:     false,
+			validator: booleanValidator{},
+		},
+		CfShortOidLength: {
+			value: cfShortOidLengthDefaultValue,
+			validator: clampedIntegerValidator{
+				configVariable: CfShortOidLength,
+				minValue:       cfShortOidLengthMinValue,
+				maxValue:       cfShortOidLengthMaxValue,
+			},
+		},
+		CfCommitPreview: {
+			value: cfCommitPreviewDefaultValue,
+			validator: clampedIntegerValidator{
+				configVariable: CfCommitPreview,
+				minValue:       cfCommitPreviewMinValue,
+				maxValue:       cfCommitPreviewMaxValue,
+			},
+		},
+		CfRefSortOrder: {
+			value: cfRefSortOrderName,
+			validator: enumValidator{
+				configVariable: CfRefSortOrder,
+				values:         []string{cfRefSortOrderName, cfRefSortOrderDate},
+			},
+		},
+		CfDefaultRef: {
+			value: cfDefaultRefHead,
+		},
 	}
 
 	return config
@@ -329,6 +503,8 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 		err = config.processAddViewCommand(command, inputSource)
 	case *SplitViewCommand:
 		err = config.processSplitViewCommand(command, inputSource)
+	case *ShellCommand:
+		err = config.processShellCommand(command)
 	default:
 		log.Errorf("Unknown command type %T", command)
 	}
@@ -468,15 +644,37 @@ func (config *Configuration) processMapCommand(mapCommand *MapCommand, inputSour
 		return generateConfigError(inputSource, mapCommand.from, "from keystring cannot be empty")
 	} else if mapCommand.to.value == "" {
 		return generateConfigError(inputSource, mapCommand.to, "to keystring cannot be empty")
+	} else if isActionKey(mapCommand.to.value) && !isValidAction(mapCommand.to.value) {
+		return generateConfigError(inputSource, mapCommand.to, "Invalid action: %v", mapCommand.to.value)
 	}
 
-	config.keyBindings.SetKeystringBinding(viewID, mapCommand.from.value, mapCommand.to.value)
+	if strings.HasPrefix(mapCommand.to.value, shellCmdPrefix) {
+		shellCommand := strings.TrimPrefix(mapCommand.to.value, shellCmdPrefix)
+		if strings.TrimSpace(shellCommand) == "" {
+			return generateConfigError(inputSource, mapCommand.to, "shell command cannot be empty")
+		}
+
+		config.keyBindings.SetShellCommandBinding(viewID, mapCommand.from.value, shellCommand)
+	} else {
+		config.keyBindings.SetKeystringBinding(viewID, mapCommand.from.value, mapCommand.to.value)
+	}
 
 	log.Infof("Mapped \"%v\" to \"%v\" for view %v", mapCommand.from.value, mapCommand.to.value, mapCommand.view.value)
 
 	return
 }
 
+func (config *Configuration) processShellCommand(shellCommand *ShellCommand) (err error) {
+	log.Infof("Processed shell command: %v", shellCommand.command)
+
+	config.channels.DoAction(Action{
+		ActionType: ActionRunShellCommand,
+		Args:       []interface{}{shellCommand.command},
+	})
+
+	return
+}
+
 func (config *Configuration) processQuitCommand() (err error) {
 	log.Info("Processed quit command")
 	config.channels.DoAction(Action{ActionType: ActionExit})
@@ -570,6 +768,18 @@ func (config *Configuration) AddOnChangeListener(configVariable ConfigVariable,
 	variable.onChangeListeners = append(variable.onChangeListeners, listener)
 }
 
+// RemoveOnChangeListener stops the listener being notified when a configuration variable changes value
+func (config *Configuration) RemoveOnChangeListener(configVariable ConfigVariable, listener ConfigVariableOnChangeListener) {
+	variable := config.getVariable(configVariable)
+
+	for index, registeredListener := range variable.onChangeListeners {
+		if registeredListener == listener {
+			variable.onChangeListeners = append(variable.onChangeListeners[:index], variable.onChangeListeners[index+1:]...)
+			return
+		}
+	}
+}
+
 // GetBool returns the boolean value of the specified configuration variable
 func (config *Configuration) GetBool(configVariable ConfigVariable) bool {
 	switch value := config.getVariable(configVariable).value.(type) {
@@ -622,22 +832,79 @@ func (config *Configuration) GetTheme() Theme {
 	return theme
 }
 
-type tabWidithValidator struct{}
+type integerValidator struct {
+	configVariable ConfigVariable
+	minValue       int
+}
 
-func (tabwidthValidator tabWidithValidator) validate(value string) (processedValue interface{}, err error) {
-	var tabWidth int
+func (integerValidator integerValidator) validate(value string) (processedValue interface{}, err error) {
+	var intValue int
 
-	if tabWidth, err = strconv.Atoi(value); err != nil {
-		err = fmt.Errorf("%v must be an integer value greater than %v", CfTabWidth, cfTabWidthMinValue-1)
-	} else if tabWidth < cfTabWidthMinValue {
-		err = fmt.Errorf("%v must be greater than %v", CfTabWidth, cfTabWidthMinValue-1)
+	if intValue, err = strconv.Atoi(value); err != nil {
+		err = fmt.Errorf("%v must be an integer value greater than %v", integerValidator.configVariable, integerValidator.minValue-1)
+	} else if intValue < integerValidator.minValue {
+		err = fmt.Errorf("%v must be greater than %v", integerValidator.configVariable, integerValidator.minValue-1)
 	} else {
-		processedValue = tabWidth
+		processedValue = intValue
+	}
+
+	return
+}
+
+// clampedIntegerValidator accepts any integer value and clamps it to the range [minValue, maxValue]
+type clampedIntegerValidator struct {
+	configVariable ConfigVariable
+	minValue       int
+	maxValue       int
+}
+
+func (clampedIntegerValidator clampedIntegerValidator) validate(value string) (processedValue interface{}, err error) {
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		err = fmt.Errorf("%v must be an integer value between %v and %v", clampedIntegerValidator.configVariable,
+			clampedIntegerValidator.minValue, clampedIntegerValidator.maxValue)
+		return
+	}
+
+	processedValue = MaxInt(clampedIntegerValidator.minValue, MinInt(intValue, clampedIntegerValidator.maxValue))
+
+	return
+}
+
+type booleanValidator struct{}
+
+func (booleanValidator booleanValidator) validate(value string) (processedValue interface{}, err error) {
+	switch value {
+	case "true":
+		processedValue = true
+	case "false":
+		processedValue = false
+	default:
+		err = fmt.Errorf("Expected value true or false but found %v", value)
 	}
 
 	return
 }
 
+// enumValidator accepts only the values it has been provided
+type enumValidator struct {
+	configVariable ConfigVariable
+	values         []string
+}
+
+func (enumValidator enumValidator) validate(value string) (processedValue interface{}, err error) {
+	for _, validValue := range enumValidator.values {
+		if value == validValue {
+			processedValue = value
+			return
+		}
+	}
+
+	err = fmt.Errorf("%v must be one of: %v", enumValidator.configVariable, strings.Join(enumValidator.values, ", "))
+
+	return
+}
+
 type themeValidator struct {
 	config *Configuration
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
 const (
@@ -18,6 +19,7 @@ const (
 	vsplitCommand    = "vsplit"
 	hsplitCommand    = "hsplit"
 	splitCommand     = "split"
+	shellCmdPrefix   = "!"
 )
 
 type commandConstructor func(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error)
@@ -90,12 +92,24 @@ type SplitViewCommand struct {
 
 func (splitViewCommand *SplitViewCommand) configCommand() {}
 
+// ShellCommand represents a command to be run by the shell
+type ShellCommand struct {
+	command string
+}
+
+func (shellCommand *ShellCommand) configCommand() {}
+
 type commandDescriptor struct {
 	tokenTypes  []ConfigTokenType
 	varArgs     bool
 	constructor commandConstructor
 }
 
+var shellCommandDescriptor = &commandDescriptor{
+	varArgs:     true,
+	constructor: shellCommandConstructor,
+}
+
 var commandDescriptors = map[string]*commandDescriptor{
 	setCommand: {
 		tokenTypes:  []ConfigTokenType{CtkWord, CtkWord},
@@ -243,6 +257,10 @@ func (parser *ConfigParser) discardTokensUntilNextCommand() {
 }
 
 func (parser *ConfigParser) parseCommand(commandToken *ConfigToken) (command ConfigCommand, eof bool, err error) {
+	if strings.HasPrefix(commandToken.value, shellCmdPrefix) {
+		return parser.parseVarArgsCommand(shellCommandDescriptor, commandToken)
+	}
+
 	commandDescriptor, ok := commandDescriptors[commandToken.value]
 	if !ok {
 		err = parser.generateParseError(commandToken, "Invalid command \"%v\"", commandToken.value)
@@ -351,6 +369,30 @@ func mapCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, toke
 	}, nil
 }
 
+func shellCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
+	var words []string
+
+	if command := strings.TrimPrefix(commandToken.value, shellCmdPrefix); command != "" {
+		words = append(words, command)
+	}
+
+	for _, token := range tokens {
+		if token.value == "" || strings.ContainsAny(token.value, " \t") {
+			words = append(words, ShellQuote(token.value))
+		} else {
+			words = append(words, token.value)
+		}
+	}
+
+	if len(words) == 0 {
+		return nil, parser.generateParseError(commandToken, "No shell command specified")
+	}
+
+	return &ShellCommand{
+		command: strings.Join(words, " "),
+	}, nil
+}
+
 func quitCommandConstructor(parser *ConfigParser, commandToken *ConfigToken, tokens []*ConfigToken) (ConfigCommand, error) {
 	return &QuitCommand{}, nil
 }
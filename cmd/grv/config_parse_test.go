@@ -154,6 +154,23 @@ func (splitViewCommandValues *SplitViewCommandValues) Equal(command ConfigComman
 		reflect.DeepEqual(splitViewCommandValues.args, otherArgs)
 }
 
+type ShellCommandValues struct {
+	command string
+}
+
+func (shellCommandValues *ShellCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ShellCommand)
+	if !ok {
+		return false
+	}
+
+	return shellCommandValues.command == other.command
+}
+
 func TestParseSingleCommand(t *testing.T) {
 	var singleCommandTests = []struct {
 		input           string
@@ -220,6 +237,12 @@ func TestParseSingleCommand(t *testing.T) {
 				view:        "GitStatusView",
 			},
 		},
+		{
+			input: "!git cherry-pick %h",
+			expectedCommand: &ShellCommandValues{
+				command: "git cherry-pick %h",
+			},
+		},
 	}
 
 	for _, singleCommandTest := range singleCommandTests {
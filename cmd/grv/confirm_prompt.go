@@ -0,0 +1,66 @@
+package main
+
+import (
+	"sync"
+)
+
+// ConfirmPrompt is a yes/no question displayed to the user in the status bar
+// The answer is delivered on the result channel once the user has responded
+type ConfirmPrompt struct {
+	question string
+	resultCh chan bool
+	answered bool
+	lock     sync.Mutex
+}
+
+// NewConfirmPrompt creates a new instance
+func NewConfirmPrompt(question string) *ConfirmPrompt {
+	return &ConfirmPrompt{
+		question: question,
+		resultCh: make(chan bool, 1),
+	}
+}
+
+// Question returns the text the prompt displays
+func (confirmPrompt *ConfirmPrompt) Question() string {
+	return confirmPrompt.question
+}
+
+// Result returns a channel which receives the answer to the prompt
+func (confirmPrompt *ConfirmPrompt) Result() <-chan bool {
+	return confirmPrompt.resultCh
+}
+
+// Answer delivers the provided answer to the prompt
+// Only the first answer is delivered, subsequent answers are ignored
+func (confirmPrompt *ConfirmPrompt) Answer(confirmed bool) {
+	confirmPrompt.lock.Lock()
+	defer confirmPrompt.lock.Unlock()
+
+	if !confirmPrompt.answered {
+		confirmPrompt.answered = true
+		confirmPrompt.resultCh <- confirmed
+	}
+}
+
+// Answered returns true if the prompt has been answered
+func (confirmPrompt *ConfirmPrompt) Answered() bool {
+	confirmPrompt.lock.Lock()
+	defer confirmPrompt.lock.Unlock()
+
+	return confirmPrompt.answered
+}
+
+// ConfirmPromptAnswer determines how a key answers a confirm prompt.
+// "y" confirms while "n" and <Escape> decline. Any other key declines and
+// is not consumed so it can be processed as normal input
+func ConfirmPromptAnswer(key string) (confirmed, consumed bool) {
+	switch key {
+	case "y", "Y":
+		return true, true
+	case "n", "N", "<Escape>":
+		return false, true
+	}
+
+	return false, false
+}
@@ -52,6 +52,7 @@ type ContainerView struct {
 	childViews                  []AbstractView
 	title                       string
 	viewWins                    map[WindowView]*Window
+	visibleViews                map[AbstractView]bool
 	emptyWin                    *Window
 	activeViewIndex             uint
 	handlers                    map[ActionType]containerViewHandler
@@ -65,11 +66,12 @@ type ContainerView struct {
 // NewContainerView creates a new instance
 func NewContainerView(channels *Channels, config Config) *ContainerView {
 	containerView := &ContainerView{
-		config:      config,
-		channels:    channels,
-		orientation: CoVertical,
-		viewID:      ViewContainer,
-		viewWins:    make(map[WindowView]*Window),
+		config:       config,
+		channels:     channels,
+		orientation:  CoVertical,
+		viewID:       ViewContainer,
+		viewWins:     make(map[WindowView]*Window),
+		visibleViews: make(map[AbstractView]bool),
 		handlers: map[ActionType]containerViewHandler{
 			ActionNextView:         nextContainerChildView,
 			ActionPrevView:         prevContainerChildView,
@@ -77,6 +79,9 @@ func NewContainerView(channels *Channels, config Config) *ContainerView {
 			ActionToggleViewLayout: toggleViewOrientation,
 			ActionSplitView:        splitView,
 			ActionRemoveView:       removeView,
+			ActionMouseSelect:      mouseEventContainerChildView,
+			ActionMouseScrollUp:    mouseEventContainerChildView,
+			ActionMouseScrollDown:  mouseEventContainerChildView,
 		},
 	}
 
@@ -267,6 +272,7 @@ func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []
 	}
 
 	childPositions := containerView.childViewPositionCalculator.CalculateChildViewPositions(&viewLayoutData)
+	containerView.visibleViews = make(map[AbstractView]bool)
 
 	for childViewIndex, childView := range containerView.childViews {
 		childPosition := childPositions[childViewIndex]
@@ -274,6 +280,8 @@ func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []
 			continue
 		}
 
+		containerView.visibleViews[childView] = true
+
 		switch view := childView.(type) {
 		case WindowView:
 			var win *Window
@@ -402,6 +410,35 @@ func (containerView *ContainerView) renderEmptyView(viewDimension ViewDimension)
 	return win
 }
 
+// ShellCommandVariables returns the shell command variables provided by the child views.
+// Variables of the active child view take precedence
+func (containerView *ContainerView) ShellCommandVariables() (variables map[rune]string, err error) {
+	containerView.lock.Lock()
+	childViews := append([]AbstractView(nil), containerView.childViews...)
+	var activeChildView AbstractView
+	if !containerView.isEmpty() {
+		activeChildView = containerView.activeChildView()
+	}
+	containerView.lock.Unlock()
+
+	variables = make(map[rune]string)
+
+	for _, childView := range append(childViews, activeChildView) {
+		if variableProvider, ok := childView.(ShellCommandVariableProvider); ok {
+			var childVariables map[rune]string
+			if childVariables, err = variableProvider.ShellCommandVariables(); err != nil {
+				return
+			}
+
+			for variable, value := range childVariables {
+				variables[variable] = value
+			}
+		}
+	}
+
+	return
+}
+
 // ActiveView returns the active child view
 func (containerView *ContainerView) ActiveView() AbstractView {
 	containerView.lock.Lock()
@@ -422,6 +459,16 @@ func (containerView *ContainerView) Title() string {
 	return containerView.title
 }
 
+// Dispose releases the resources held by all child views
+func (containerView *ContainerView) Dispose() {
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	for _, childView := range containerView.childViews {
+		DisposeView(childView)
+	}
+}
+
 // IsEmpty returns true if this container view has no child views
 func (containerView *ContainerView) IsEmpty() bool {
 	containerView.lock.Lock()
@@ -446,6 +493,8 @@ func (containerView *ContainerView) removeActiveChildView() {
 	index := containerView.activeViewIndex
 	log.Debugf("Removing child view %T at index %v", containerView.activeChildView(), index)
 
+	DisposeView(containerView.activeChildView())
+
 	containerView.childViews = append(containerView.childViews[:index], containerView.childViews[index+1:]...)
 	childViewNum := uint(len(containerView.childViews))
 
@@ -550,6 +599,78 @@ func (containerView *ContainerView) setActiveViewAndActivateLastChild(activeView
 	}
 }
 
+// containsPosition returns true if one of the visible child views occupies the provided screen position
+func (containerView *ContainerView) containsPosition(row, col uint) bool {
+	containerView.lock.Lock()
+	defer containerView.lock.Unlock()
+
+	_, found := containerView.childViewIndexAtPosition(row, col)
+	return found
+}
+
+func (containerView *ContainerView) childViewIndexAtPosition(row, col uint) (childViewIndex uint, found bool) {
+	for index, childView := range containerView.childViews {
+		if !containerView.visibleViews[childView] {
+			continue
+		}
+
+		switch view := childView.(type) {
+		case WindowView:
+			win := containerView.viewWins[view]
+			found = row >= win.startRow && row < win.startRow+win.rows &&
+				col >= win.startCol && col < win.startCol+win.cols
+		case *ContainerView:
+			found = view.containsPosition(row, col)
+		}
+
+		if found {
+			childViewIndex = uint(index)
+			return
+		}
+	}
+
+	return
+}
+
+func mouseEventContainerChildView(containerView *ContainerView, action Action) (err error) {
+	mouseEvent, err := GetMouseEventFromAction(action)
+	if err != nil {
+		return
+	}
+
+	childViewIndex, found := containerView.childViewIndexAtPosition(mouseEvent.row, mouseEvent.col)
+	if !found {
+		log.Debugf("No child view found at row:%v,col:%v", mouseEvent.row, mouseEvent.col)
+		return
+	}
+
+	containerView.activeViewIndex = childViewIndex
+
+	switch childView := containerView.activeChildView().(type) {
+	case WindowView:
+		containerView.onActiveChange(true)
+
+		switch action.ActionType {
+		case ActionMouseSelect:
+			win := containerView.viewWins[childView]
+			mouseEvent.row -= win.startRow
+			mouseEvent.col -= win.startCol
+			err = childView.HandleAction(Action{ActionType: ActionMouseSelect, Args: []interface{}{mouseEvent}})
+		case ActionMouseScrollUp:
+			err = childView.HandleAction(Action{ActionType: ActionPrevLine})
+		case ActionMouseScrollDown:
+			err = childView.HandleAction(Action{ActionType: ActionNextLine})
+		}
+	case *ContainerView:
+		err = childView.HandleAction(action)
+		containerView.onActiveChange(true)
+	}
+
+	containerView.channels.UpdateDisplay()
+
+	return
+}
+
 func nextContainerChildView(containerView *ContainerView, action Action) (err error) {
 	if containerView.nextView() {
 		if containerView.activeViewIndex == uint(len(containerView.childViews)-1) {
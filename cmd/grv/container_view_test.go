@@ -0,0 +1,148 @@
+package main
+
+import (
+	"testing"
+)
+
+type testWindowView struct {
+	viewID            ViewID
+	active            bool
+	handledActions    []ActionType
+	renderedDimension ViewDimension
+}
+
+func (windowView *testWindowView) Initialise() error {
+	return nil
+}
+
+func (windowView *testWindowView) HandleEvent(event Event) error {
+	return nil
+}
+
+func (windowView *testWindowView) HandleAction(action Action) error {
+	windowView.handledActions = append(windowView.handledActions, action.ActionType)
+	return nil
+}
+
+func (windowView *testWindowView) OnActiveChange(active bool) {
+	windowView.active = active
+}
+
+func (windowView *testWindowView) ViewID() ViewID {
+	return windowView.viewID
+}
+
+func (windowView *testWindowView) RenderHelpBar(lineBuilder *LineBuilder) error {
+	return nil
+}
+
+func (windowView *testWindowView) Render(win RenderWindow) error {
+	windowView.renderedDimension = win.ViewDimensions()
+	return nil
+}
+
+func newTestContainerView(childViews ...AbstractView) *ContainerView {
+	channels := newTestChannels()
+	containerView := NewContainerView(channels, NewConfiguration(NewKeyBindingManager(), channels))
+	containerView.AddChildViews(childViews...)
+
+	return containerView
+}
+
+func TestWindowViewFactoryCreatesViewsByViewID(t *testing.T) {
+	channels := newTestChannels()
+	windowViewFactory := NewWindowViewFactory(&MockRepoData{}, channels, NewConfiguration(NewKeyBindingManager(), channels))
+
+	for _, viewID := range []ViewID{ViewRef, ViewCommit} {
+		windowView, err := windowViewFactory.CreateWindowView(viewID)
+		if err != nil {
+			t.Fatalf("Failed to create view %v: %v", viewID, err)
+		}
+
+		if windowView.ViewID() != viewID {
+			t.Errorf("Expected view with id %v but found %v", viewID, windowView.ViewID())
+		}
+	}
+
+	if _, err := windowViewFactory.CreateWindowView(ViewContainer); err == nil {
+		t.Errorf("Expected error when creating unsupported view %v", ViewContainer)
+	}
+}
+
+func TestContainerViewRoutesActionsAndActiveChangesToActiveChildView(t *testing.T) {
+	refView := &testWindowView{viewID: ViewRef}
+	commitView := &testWindowView{viewID: ViewCommit}
+	containerView := newTestContainerView(refView, commitView)
+
+	containerView.OnActiveChange(true)
+
+	if !refView.active || commitView.active {
+		t.Errorf("Expected only the first child view to be active")
+	}
+
+	if activeView := containerView.ActiveView(); activeView != refView {
+		t.Errorf("Expected the first child view to be the active view but found %v", activeView.ViewID())
+	}
+
+	if err := containerView.HandleAction(Action{ActionType: ActionNextLine}); err != nil {
+		t.Fatalf("Failed to handle action: %v", err)
+	}
+
+	if len(refView.handledActions) != 1 || len(commitView.handledActions) != 0 {
+		t.Errorf("Expected action to only be handled by the active child view")
+	}
+
+	containerView.OnActiveChange(false)
+
+	if refView.active || commitView.active {
+		t.Errorf("Expected no child views to be active once the container is inactive")
+	}
+}
+
+func TestContainerViewRendersChildViewsSideBySide(t *testing.T) {
+	commitView := &testWindowView{viewID: ViewCommit}
+	diffView := &testWindowView{viewID: ViewDiff}
+	containerView := newTestContainerView(commitView, diffView)
+
+	wins, err := containerView.Render(ViewDimension{rows: 24, cols: 81})
+	if err != nil {
+		t.Fatalf("Failed to render container view: %v", err)
+	}
+
+	if len(wins) != 2 {
+		t.Fatalf("Expected a window for each child view but found %v", len(wins))
+	}
+
+	if expected := (ViewDimension{rows: 24, cols: 40}); commitView.renderedDimension != expected {
+		t.Errorf("Expected commit view to be rendered with dimensions %v but found %v", expected, commitView.renderedDimension)
+	}
+
+	if expected := (ViewDimension{rows: 24, cols: 41}); diffView.renderedDimension != expected {
+		t.Errorf("Expected diff view to be rendered with dimensions %v but found %v", expected, diffView.renderedDimension)
+	}
+}
+
+func TestNextViewMovesFocusBetweenChildViews(t *testing.T) {
+	commitView := &testWindowView{viewID: ViewCommit}
+	diffView := &testWindowView{viewID: ViewDiff}
+	containerView := newTestContainerView(commitView, diffView)
+	containerView.OnActiveChange(true)
+
+	expectedActiveViews := []*testWindowView{diffView, commitView}
+
+	for _, expectedActiveView := range expectedActiveViews {
+		if err := containerView.HandleAction(Action{ActionType: ActionNextView}); err != nil {
+			t.Fatalf("Failed to move to next view: %v", err)
+		}
+
+		for _, childView := range []*testWindowView{commitView, diffView} {
+			if isActive := childView == expectedActiveView; childView.active != isActive {
+				t.Errorf("Expected view %v to have active state %v but found %v", childView.viewID, isActive, childView.active)
+			}
+		}
+
+		if len(commitView.handledActions) != 0 || len(diffView.handledActions) != 0 {
+			t.Errorf("Expected next view action to be handled by the container view")
+		}
+	}
+}
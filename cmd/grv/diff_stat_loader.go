@@ -0,0 +1,221 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	dslWorkerNum = 4
+	dslQueueSize = 256
+)
+
+type diffStatRequest struct {
+	oid        *Oid
+	generation uint
+}
+
+type diffStatResult struct {
+	oid        *Oid
+	diffStat   *DiffStat
+	generation uint
+}
+
+// DiffStatLoader computes the diff stats of commits in the background using a bounded pool of workers.
+// Loaded diff stats are cached and the display is updated as results arrive
+type DiffStatLoader struct {
+	repoData   RepoData
+	channels   *Channels
+	workerNum  uint
+	requestCh  chan diffStatRequest
+	resultCh   chan diffStatResult
+	loadedCh   chan bool
+	cancelCh   chan bool
+	waitGroup  sync.WaitGroup
+	diffStats  map[*Oid]*DiffStat
+	pending    map[*Oid]bool
+	generation uint
+	started    bool
+	stopped    bool
+	lock       sync.Mutex
+}
+
+// NewDiffStatLoader creates a new instance. Workers are started when the first diff stat is requested
+func NewDiffStatLoader(repoData RepoData, channels *Channels, workerNum uint) *DiffStatLoader {
+	return &DiffStatLoader{
+		repoData:  repoData,
+		channels:  channels,
+		workerNum: workerNum,
+		requestCh: make(chan diffStatRequest, dslQueueSize),
+		resultCh:  make(chan diffStatResult, dslQueueSize),
+		loadedCh:  make(chan bool, 1),
+		cancelCh:  make(chan bool),
+		diffStats: make(map[*Oid]*DiffStat),
+		pending:   make(map[*Oid]bool),
+	}
+}
+
+// DiffStat returns the diff stat of the commit with the provided oid if it has been loaded.
+// Otherwise the commit is queued for loading and nil is returned
+func (diffStatLoader *DiffStatLoader) DiffStat(oid *Oid) *DiffStat {
+	diffStatLoader.lock.Lock()
+	defer diffStatLoader.lock.Unlock()
+
+	if diffStat, ok := diffStatLoader.diffStats[oid]; ok {
+		return diffStat
+	}
+
+	if diffStatLoader.stopped || diffStatLoader.pending[oid] {
+		return nil
+	}
+
+	if !diffStatLoader.started {
+		diffStatLoader.start()
+	}
+
+	select {
+	case diffStatLoader.requestCh <- diffStatRequest{oid: oid, generation: diffStatLoader.generation}:
+		diffStatLoader.pending[oid] = true
+	default:
+		log.Debugf("Diff stat queue is full. Commit %v will be queued on a later request", oid)
+	}
+
+	return nil
+}
+
+// DiffStats blocks until the diff stats of all commits with the provided oids have been loaded by the workers.
+// false is returned if loading is cancelled or the loader is stopped first
+func (diffStatLoader *DiffStatLoader) DiffStats(oids []*Oid, cancelCh <-chan bool) (diffStats []*DiffStat, loaded bool) {
+	for {
+		diffStats = diffStats[:0]
+
+		for _, oid := range oids {
+			if diffStat := diffStatLoader.DiffStat(oid); diffStat != nil {
+				diffStats = append(diffStats, diffStat)
+			}
+		}
+
+		if len(diffStats) == len(oids) {
+			return diffStats, true
+		}
+
+		select {
+		case <-diffStatLoader.loadedCh:
+		case <-diffStatLoader.cancelCh:
+			return nil, false
+		case <-cancelCh:
+			return nil, false
+		}
+	}
+}
+
+// Reset discards all queued requests. Requests in progress are not cached when they complete.
+// Diff stats which have already been loaded remain cached
+func (diffStatLoader *DiffStatLoader) Reset() {
+	diffStatLoader.lock.Lock()
+	defer diffStatLoader.lock.Unlock()
+
+	diffStatLoader.generation++
+	diffStatLoader.pending = make(map[*Oid]bool)
+}
+
+// Stop shuts down all workers and waits for them to exit
+func (diffStatLoader *DiffStatLoader) Stop() {
+	diffStatLoader.lock.Lock()
+
+	if diffStatLoader.stopped {
+		diffStatLoader.lock.Unlock()
+		return
+	}
+
+	diffStatLoader.stopped = true
+	close(diffStatLoader.cancelCh)
+	diffStatLoader.lock.Unlock()
+
+	diffStatLoader.waitGroup.Wait()
+	log.Debug("Diff stat workers stopped")
+}
+
+func (diffStatLoader *DiffStatLoader) start() {
+	diffStatLoader.started = true
+	diffStatLoader.waitGroup.Add(int(diffStatLoader.workerNum) + 1)
+
+	for workerIndex := uint(0); workerIndex < diffStatLoader.workerNum; workerIndex++ {
+		go diffStatLoader.processRequests()
+	}
+
+	go diffStatLoader.processResults()
+
+	log.Debugf("Started %v diff stat workers", diffStatLoader.workerNum)
+}
+
+func (diffStatLoader *DiffStatLoader) isCurrentGeneration(generation uint) bool {
+	diffStatLoader.lock.Lock()
+	defer diffStatLoader.lock.Unlock()
+
+	return generation == diffStatLoader.generation
+}
+
+func (diffStatLoader *DiffStatLoader) processRequests() {
+	defer diffStatLoader.waitGroup.Done()
+
+	for {
+		select {
+		case request := <-diffStatLoader.requestCh:
+			if !diffStatLoader.isCurrentGeneration(request.generation) {
+				continue
+			}
+
+			diffStat, err := diffStatLoader.repoData.DiffStat(request.oid)
+			if err != nil {
+				log.Errorf("Unable to load diff stat for commit %v: %v", request.oid, err)
+				diffStat = &DiffStat{}
+			}
+
+			select {
+			case diffStatLoader.resultCh <- diffStatResult{oid: request.oid, diffStat: diffStat, generation: request.generation}:
+			case <-diffStatLoader.cancelCh:
+				return
+			}
+		case <-diffStatLoader.cancelCh:
+			return
+		}
+	}
+}
+
+func (diffStatLoader *DiffStatLoader) processResults() {
+	defer diffStatLoader.waitGroup.Done()
+
+	for {
+		select {
+		case result := <-diffStatLoader.resultCh:
+			if diffStatLoader.storeResult(result) {
+				diffStatLoader.channels.UpdateDisplay()
+
+				select {
+				case diffStatLoader.loadedCh <- true:
+				default:
+				}
+			}
+		case <-diffStatLoader.cancelCh:
+			return
+		}
+	}
+}
+
+// storeResult caches the result if it was requested since the loader was last reset
+func (diffStatLoader *DiffStatLoader) storeResult(result diffStatResult) bool {
+	diffStatLoader.lock.Lock()
+	defer diffStatLoader.lock.Unlock()
+
+	if result.generation != diffStatLoader.generation {
+		log.Debugf("Discarding stale diff stat for commit %v", result.oid)
+		return false
+	}
+
+	delete(diffStatLoader.pending, result.oid)
+	diffStatLoader.diffStats[result.oid] = result.diffStat
+
+	return true
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffStatIsLoadedOnceInBackground(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	expectedDiffStat := &DiffStat{added: 10, removed: 3}
+
+	repoData := &MockRepoData{}
+	repoData.On("DiffStat", oid).Return(expectedDiffStat, nil)
+
+	diffStatLoader := NewDiffStatLoader(repoData, newTestChannels(), dslWorkerNum)
+	defer diffStatLoader.Stop()
+
+	if diffStat := diffStatLoader.DiffStat(oid); diffStat != nil {
+		t.Errorf("Expected diff stat to not be loaded yet but found %v", diffStat)
+	}
+
+	diffStatLoader.DiffStat(oid)
+
+	for i := 0; i < 100; i++ {
+		if diffStat := diffStatLoader.DiffStat(oid); diffStat != nil {
+			if !reflect.DeepEqual(diffStat, expectedDiffStat) {
+				t.Errorf("Diff stat does not match expected value. Expected: %v, Actual: %v", expectedDiffStat, diffStat)
+			}
+
+			repoData.AssertNumberOfCalls(t, "DiffStat", 1)
+			return
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	t.Errorf("Expected diff stat to be loaded")
+}
+
+func TestDiffStatLoaderDiscardsResultsRequestedBeforeReset(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	diffStatLoader := NewDiffStatLoader(&MockRepoData{}, newTestChannels(), dslWorkerNum)
+
+	diffStatLoader.Reset()
+
+	if diffStatLoader.storeResult(diffStatResult{oid: oid, diffStat: &DiffStat{added: 1}, generation: 0}) {
+		t.Errorf("Expected result requested before reset to be discarded")
+	}
+
+	if _, ok := diffStatLoader.diffStats[oid]; ok {
+		t.Errorf("Expected stale result to not be cached")
+	}
+
+	if !diffStatLoader.storeResult(diffStatResult{oid: oid, diffStat: &DiffStat{added: 1}, generation: 1}) {
+		t.Errorf("Expected result requested after reset to be stored")
+	}
+}
+
+func TestDiffStatLoaderStopIsIdempotent(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+
+	repoData := &MockRepoData{}
+	repoData.On("DiffStat", oid).Return(&DiffStat{}, nil)
+
+	diffStatLoader := NewDiffStatLoader(repoData, newTestChannels(), dslWorkerNum)
+	diffStatLoader.DiffStat(oid)
+
+	diffStatLoader.Stop()
+	diffStatLoader.Stop()
+
+	unloadedOid := newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t)
+	diffStatLoader.DiffStat(unloadedOid)
+
+	if diffStatLoader.pending[unloadedOid] {
+		t.Errorf("Expected no requests to be queued after stopping")
+	}
+}
+
+func TestDiffStatsWaitsForAllRequestedDiffStats(t *testing.T) {
+	oids := []*Oid{
+		newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t),
+		newTestOid("8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01", t),
+	}
+	expectedDiffStats := []*DiffStat{{added: 1, removed: 2}, {added: 3, removed: 4}}
+
+	repoData := &MockRepoData{}
+	for index, oid := range oids {
+		repoData.On("DiffStat", oid).Return(expectedDiffStats[index], nil)
+	}
+
+	diffStatLoader := NewDiffStatLoader(repoData, newTestChannels(), dslWorkerNum)
+	defer diffStatLoader.Stop()
+
+	diffStats, loaded := diffStatLoader.DiffStats(oids, make(chan bool))
+	if !loaded {
+		t.Fatalf("Expected diff stats to be loaded")
+	}
+
+	if !reflect.DeepEqual(diffStats, expectedDiffStats) {
+		t.Errorf("Diff stats do not match expected values. Expected: %v, Actual: %v", expectedDiffStats, diffStats)
+	}
+}
+
+func TestDiffStatsReturnsWhenCancelled(t *testing.T) {
+	diffStatLoader := NewDiffStatLoader(&MockRepoData{}, newTestChannels(), dslWorkerNum)
+	diffStatLoader.Stop()
+
+	cancelCh := make(chan bool)
+	close(cancelCh)
+
+	if _, loaded := diffStatLoader.DiffStats([]*Oid{newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)}, cancelCh); loaded {
+		t.Errorf("Expected diff stats to not be loaded after cancelling")
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -17,6 +22,8 @@ type diffLineType int
 const (
 	dltUnset diffLineType = iota
 	dltNormal
+	dltDiffCommitOid
+	dltDiffCommitParent
 	dltDiffCommitAuthor
 	dltDiffCommitAuthorDate
 	dltDiffCommitCommitter
@@ -32,11 +39,21 @@ const (
 )
 
 const (
-	dvDateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+	dvDateFormat          = "Mon Jan 2 15:04:05 2006 -0700"
+	dvDefaultContextLines = 3
+	dvMaxContextLines     = 20
+	dvSubmoduleMode       = "160000"
+	dvSubprojectCommit    = "Subproject commit "
 )
 
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+var hunkNewStartRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)`)
+var hunkRangesRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
 var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
 	dltNormal:                  CmpDiffviewDifflineNormal,
+	dltDiffCommitOid:           CmpDiffviewDifflineDiffCommitOid,
+	dltDiffCommitParent:        CmpDiffviewDifflineDiffCommitParent,
 	dltDiffCommitAuthor:        CmpDiffviewDifflineDiffCommitAuthor,
 	dltDiffCommitAuthorDate:    CmpDiffviewDifflineDiffCommitAuthorDate,
 	dltDiffCommitCommitter:     CmpDiffviewDifflineDiffCommitCommitter,
@@ -52,8 +69,41 @@ var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
 }
 
 type diffLineData struct {
-	line     string
-	lineType diffLineType
+	line          string
+	lineType      diffLineType
+	changedSpans  []wordDiffSpan
+	oldLineNumber int
+	newLineNumber int
+	submodule     *diffSubmoduleChange
+}
+
+// diffSubmoduleChange contains the commits a submodule was changed from and to.
+// The old or new id is empty if the submodule was added or removed
+type diffSubmoduleChange struct {
+	path  string
+	oldID string
+	newID string
+}
+
+func (submoduleChange *diffSubmoduleChange) description() string {
+	switch {
+	case submoduleChange.oldID == "":
+		return fmt.Sprintf("Submodule %v added at %v", submoduleChange.path, shortSubmoduleID(submoduleChange.newID))
+	case submoduleChange.newID == "":
+		return fmt.Sprintf("Submodule %v removed (was at %v)", submoduleChange.path, shortSubmoduleID(submoduleChange.oldID))
+	}
+
+	return fmt.Sprintf("Submodule %v %v → %v", submoduleChange.path,
+		shortSubmoduleID(submoduleChange.oldID), shortSubmoduleID(submoduleChange.newID))
+}
+
+func shortSubmoduleID(id string) string {
+	return id[0:MinInt(rdlShortOidLen, len(id))]
+}
+
+func (diffLine *diffLineData) diffLineType() diffLineType {
+	diffLine.determineDiffLineType()
+	return diffLine.lineType
 }
 
 func (diffLine *diffLineData) getThemeComponentID() ThemeComponentID {
@@ -61,6 +111,17 @@ func (diffLine *diffLineData) getThemeComponentID() ThemeComponentID {
 	return diffLineThemeComponentID[diffLine.lineType]
 }
 
+// gutterLineNumber returns the line number displayed alongside the line. Removed lines
+// display their line number in the old file and all other lines within a hunk their
+// line number in the new file. Zero is returned for lines outside of a hunk
+func (diffLine *diffLineData) gutterLineNumber() int {
+	if diffLine.newLineNumber == 0 {
+		return diffLine.oldLineNumber
+	}
+
+	return diffLine.newLineNumber
+}
+
 func (diffLine *diffLineData) determineDiffLineType() {
 	if diffLine.lineType != dltUnset {
 		return
@@ -89,9 +150,12 @@ func (diffLine *diffLineData) determineDiffLineType() {
 	diffLine.lineType = lineType
 }
 
+type diffLinesLoader func() ([]*diffLineData, error)
+
 type diffLines struct {
-	lines   []*diffLineData
-	viewPos ViewPos
+	lines     []*diffLineData
+	viewPos   ViewPos
+	loadLines diffLinesLoader
 }
 
 type diffID string
@@ -106,6 +170,9 @@ type DiffView struct {
 	viewDimension ViewDimension
 	handlers      map[ActionType]diffViewHandler
 	active        bool
+	wordDiff      bool
+	lineNumbers   bool
+	contextLines  uint
 	viewSearch    *ViewSearch
 	lock          sync.Mutex
 }
@@ -113,23 +180,33 @@ type DiffView struct {
 // NewDiffView creates a new diff view instance
 func NewDiffView(repoData RepoData, channels *Channels) *DiffView {
 	diffView := &DiffView{
-		repoData: repoData,
-		channels: channels,
-		viewPos:  NewViewPosition(),
-		diffs:    make(map[diffID]*diffLines),
+		repoData:     repoData,
+		channels:     channels,
+		viewPos:      NewViewPosition(),
+		diffs:        make(map[diffID]*diffLines),
+		wordDiff:     true,
+		contextLines: dvDefaultContextLines,
 		handlers: map[ActionType]diffViewHandler{
-			ActionPrevLine:     moveUpDiffLine,
-			ActionNextLine:     moveDownDiffLine,
-			ActionPrevPage:     moveUpDiffPage,
-			ActionNextPage:     moveDownDiffPage,
-			ActionPrevHalfPage: moveUpDiffHalfPage,
-			ActionNextHalfPage: moveDownDiffHalfPage,
-			ActionScrollRight:  scrollDiffViewRight,
-			ActionScrollLeft:   scrollDiffViewLeft,
-			ActionFirstLine:    moveToFirstDiffLine,
-			ActionLastLine:     moveToLastDiffLine,
-			ActionCenterView:   centerDiffView,
-			ActionSelect:       selectDiffLine,
+			ActionPrevLine:              moveUpDiffLine,
+			ActionNextLine:              moveDownDiffLine,
+			ActionPrevPage:              moveUpDiffPage,
+			ActionNextPage:              moveDownDiffPage,
+			ActionPrevHalfPage:          moveUpDiffHalfPage,
+			ActionNextHalfPage:          moveDownDiffHalfPage,
+			ActionScrollRight:           scrollDiffViewRight,
+			ActionScrollLeft:            scrollDiffViewLeft,
+			ActionScrollToFirstColumn:   scrollDiffViewToFirstColumn,
+			ActionFirstLine:             moveToFirstDiffLine,
+			ActionLastLine:              moveToLastDiffLine,
+			ActionCenterView:            centerDiffView,
+			ActionSelect:                selectDiffLine,
+			ActionToggleWordDiff:        toggleWordDiff,
+			ActionCopyPatch:             copyDiffPatch,
+			ActionCopyHunk:              copyDiffHunk,
+			ActionIncreaseDiffContext:   increaseDiffContext,
+			ActionDecreaseDiffContext:   decreaseDiffContext,
+			ActionToggleDiffLineNumbers: toggleDiffLineNumbers,
+			ActionOpenSubmodule:         openSubmodule,
 		},
 	}
 
@@ -169,22 +246,33 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 	lineIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
 
+	var gutterWidth int
+	if diffView.lineNumbers {
+		gutterWidth = lineNumberGutterWidth(diffLines.lines[lineIndex:MinUint(lineIndex+rows, lineNum)])
+	}
+
 	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
 		diffLine := diffLines.lines[lineIndex]
 		themeComponentID := diffLine.getThemeComponentID()
 
-		if diffLine.lineType == dltHunkStart {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		if gutterWidth > 0 {
+			renderLineNumberGutter(lineBuilder, diffLine, gutterWidth)
+		}
+
+		if diffLine.submodule != nil {
+			renderSubmoduleLine(lineBuilder, diffLine, themeComponentID)
+		} else if diffLine.lineType == dltHunkStart {
 			lineParts := strings.SplitAfter(diffLine.line, "@@")
 
 			if len(lineParts) != 3 {
 				return fmt.Errorf("Unable to display hunk header line: %v", diffLine.line)
 			}
 
-			var lineBuilder *LineBuilder
-			if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
-				return
-			}
-
 			lineBuilder.
 				AppendWithStyle(themeComponentID, " %v", strings.Join(lineParts[:2], "")).
 				AppendWithStyle(CmpDiffviewDifflineHunkHeader, "%v", lineParts[2])
@@ -199,11 +287,6 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 			filePart := diffLine.line[0:sepIndex]
 			changePart := diffLine.line[sepIndex+1:]
 
-			var lineBuilder *LineBuilder
-			if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
-				return
-			}
-
 			lineBuilder.AppendWithStyle(CmpDiffviewDifflineDiffStatsFile, " %v |", filePart)
 
 			for _, char := range changePart {
@@ -216,8 +299,10 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 					lineBuilder.Append("%c", char)
 				}
 			}
-		} else if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, " %v", diffLines.lines[lineIndex].line); err != nil {
-			return
+		} else if diffView.wordDiff && len(diffLine.changedSpans) > 0 {
+			renderWordDiffLine(lineBuilder, diffLine, themeComponentID)
+		} else {
+			lineBuilder.AppendWithStyle(themeComponentID, " %v", diffLine.line)
 		}
 
 		lineIndex++
@@ -233,7 +318,12 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 		return
 	}
 
-	if err = win.SetFooter(CmpDiffviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+	footer := fmt.Sprintf("Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum)
+	if startColumn > 1 {
+		footer = fmt.Sprintf("%v, Column %v", footer, startColumn)
+	}
+
+	if err = win.SetFooter(CmpDiffviewFooter, "%v", footer); err != nil {
 		return
 	}
 
@@ -246,6 +336,57 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 	return
 }
 
+// renderSubmoduleLine renders the file header of a submodule as a summary of the change
+// and the commit lines of the submodule with abbreviated ids
+func renderSubmoduleLine(lineBuilder *LineBuilder, diffLine *diffLineData, themeComponentID ThemeComponentID) {
+	if diffLine.lineType == dltGitDiffHeader {
+		lineBuilder.AppendWithStyle(CmpDiffviewDifflineSubmodule, " %v", diffLine.submodule.description())
+		return
+	}
+
+	id := strings.TrimPrefix(diffLine.line[1:], dvSubprojectCommit)
+	lineBuilder.AppendWithStyle(themeComponentID, " %v%v at %v", diffLine.line[0:1], diffLine.submodule.path, shortSubmoduleID(id))
+}
+
+// renderWordDiffLine renders an added or removed line with the changed spans highlighted
+func renderWordDiffLine(lineBuilder *LineBuilder, diffLine *diffLineData, themeComponentID ThemeComponentID) {
+	wordThemeComponentID := CmpDiffviewDifflineLineAddedWord
+	if diffLine.lineType == dltLineRemoved {
+		wordThemeComponentID = CmpDiffviewDifflineLineRemovedWord
+	}
+
+	lineBuilder.AppendWithStyle(themeComponentID, " ")
+	position := 0
+
+	for _, span := range diffLine.changedSpans {
+		lineBuilder.
+			AppendWithStyle(themeComponentID, "%v", diffLine.line[position:span.start]).
+			AppendWithStyle(wordThemeComponentID, "%v", diffLine.line[span.start:span.end])
+		position = span.end
+	}
+
+	lineBuilder.AppendWithStyle(themeComponentID, "%v", diffLine.line[position:])
+}
+
+// lineNumberGutterWidth returns the number of digits of the largest line number of the provided lines
+func lineNumberGutterWidth(lines []*diffLineData) (width int) {
+	for _, diffLine := range lines {
+		if lineNumber := diffLine.gutterLineNumber(); lineNumber > 0 {
+			width = MaxInt(width, len(strconv.Itoa(lineNumber)))
+		}
+	}
+
+	return
+}
+
+func renderLineNumberGutter(lineBuilder *LineBuilder, diffLine *diffLineData, gutterWidth int) {
+	if lineNumber := diffLine.gutterLineNumber(); lineNumber > 0 {
+		lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineNumber, " %*v", gutterWidth, lineNumber)
+	} else {
+		lineBuilder.Append(" %*v", gutterWidth, "")
+	}
+}
+
 func (diffView *DiffView) renderEmptyView(win RenderWindow) (err error) {
 	viewPos := diffView.viewPos
 	startColumn := viewPos.ViewStartColumn()
@@ -318,19 +459,39 @@ func (diffView *DiffView) OnCommitSelected(commit *Commit) (err error) {
 		return
 	}
 
-	lines, err := diffView.generateDiffLinesForCommit(commit)
-	if err != nil {
+	if err = diffView.storeDiff(diffID, func() ([]*diffLineData, error) {
+		return diffView.generateDiffLinesForCommit(commit)
+	}); err != nil {
 		return
 	}
 
-	diffLines := &diffLines{
-		lines:   lines,
-		viewPos: NewViewPosition(),
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+// OnCommitRangeSelected loads/fetches the diff between the provided commits and refreshes the display
+func (diffView *DiffView) OnCommitRangeSelected(fromCommit, toCommit *Commit) (err error) {
+	log.Debugf("DiffView loading diff between commits %v and %v", fromCommit.oid, toCommit.oid)
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffID := diffID(fmt.Sprintf("%v..%v", fromCommit.oid.ShortID(), toCommit.oid.ShortID()))
+
+	if diffLines, ok := diffView.diffs[diffID]; ok {
+		diffView.activeDiff = diffID
+		diffView.viewPos = diffLines.viewPos
+		diffView.channels.UpdateDisplay()
+		return
+	}
+
+	if err = diffView.storeDiff(diffID, func() ([]*diffLineData, error) {
+		return diffView.generateDiffLinesForRange(fromCommit, toCommit)
+	}); err != nil {
+		return
 	}
 
-	diffView.activeDiff = diffID
-	diffView.diffs[diffID] = diffLines
-	diffView.viewPos = diffLines.viewPos
 	diffView.channels.UpdateDisplay()
 
 	return
@@ -345,14 +506,15 @@ func (diffView *DiffView) OnFileSelected(statusType StatusType, path string) {
 
 	// Reload diff each time as staged or unstaged files diffs are liable
 	// to change frequently
-	diff, err := diffView.repoData.DiffFile(statusType, path)
-	if err != nil {
-		log.Errorf("Unable to load file diff: %v", err)
-		return
-	}
+	if err := diffView.storeDiff(diffID(path), func() (lines []*diffLineData, err error) {
+		diff, err := diffView.repoData.DiffFile(statusType, path, diffView.contextLines)
+		if err != nil {
+			return
+		}
 
-	if err = diffView.storeDiff(diffID(path), diff); err != nil {
-		log.Errorf("Unable to store file diff: %v", err)
+		return diffView.generateDiffLinesForDiff(diff)
+	}); err != nil {
+		log.Errorf("Unable to load file diff: %v", err)
 		return
 	}
 
@@ -368,15 +530,16 @@ func (diffView *DiffView) OnStageGroupSelected(statusType StatusType) {
 
 	// Reload diff each time as staged or unstaged files diffs are liable
 	// to change frequently
-	diff, err := diffView.repoData.DiffStage(statusType)
-	if err != nil {
-		log.Errorf("Unable to load diff for stage %v: %v", statusType, err)
-		return
-	}
-
 	id := fmt.Sprintf("%v files", strings.ToLower(StatusTypeDisplayName(statusType)))
-	if err = diffView.storeDiff(diffID(id), diff); err != nil {
-		log.Errorf("Unable to store stage diff: %v", err)
+	if err := diffView.storeDiff(diffID(id), func() (lines []*diffLineData, err error) {
+		diff, err := diffView.repoData.DiffStage(statusType, diffView.contextLines)
+		if err != nil {
+			return
+		}
+
+		return diffView.generateDiffLinesForDiff(diff)
+	}); err != nil {
+		log.Errorf("Unable to load diff for stage %v: %v", statusType, err)
 		return
 	}
 
@@ -394,15 +557,18 @@ func (diffView *DiffView) OnNoEntrySelected() {
 	diffView.channels.UpdateDisplay()
 }
 
-func (diffView *DiffView) storeDiff(diffID diffID, diff *Diff) (err error) {
-	lines, err := diffView.generateDiffLinesForDiff(diff)
+// storeDiff loads the lines of a diff and makes it the active diff.
+// The loader is retained so the diff can be reloaded when display options change
+func (diffView *DiffView) storeDiff(diffID diffID, loadLines diffLinesLoader) (err error) {
+	lines, err := loadLines()
 	if err != nil {
 		return
 	}
 
 	diffLines := &diffLines{
-		lines:   lines,
-		viewPos: NewViewPosition(),
+		lines:     lines,
+		viewPos:   NewViewPosition(),
+		loadLines: loadLines,
 	}
 
 	diffView.diffs[diffID] = diffLines
@@ -416,6 +582,18 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 	author := commit.commit.Author()
 	committer := commit.commit.Committer()
 
+	lines = append(lines, &diffLineData{
+		line:     fmt.Sprintf("Commit:\t%v", commit.oid.String()),
+		lineType: dltDiffCommitOid,
+	})
+
+	for _, parentID := range diffView.repoData.CommitParentIDs(commit) {
+		lines = append(lines, &diffLineData{
+			line:     fmt.Sprintf("Parent:\t%v", parentID.String()),
+			lineType: dltDiffCommitParent,
+		})
+	}
+
 	lines = append(lines,
 		&diffLineData{
 			line:     fmt.Sprintf("Author:\t%v <%v>", author.Name, author.Email),
@@ -451,11 +629,50 @@ func (diffView *DiffView) generateDiffLinesForCommit(commit *Commit) (lines []*d
 		lineType: dltNormal,
 	})
 
-	diff, err := diffView.repoData.DiffCommit(commit)
+	diff, err := diffView.repoData.DiffCommit(commit, diffView.contextLines)
+	if err != nil {
+		return
+	}
+
+	diffContent, err := diffView.generateDiffLinesForDiff(diff)
+	if err != nil {
+		return
+	}
+
+	lines = append(lines, diffContent...)
+
+	return
+}
+
+func (diffView *DiffView) generateDiffLinesForRange(fromCommit, toCommit *Commit) (lines []*diffLineData, err error) {
+	lines = append(lines,
+		&diffLineData{
+			line:     fmt.Sprintf("From:\t%v %v", fromCommit.oid.String(), fromCommit.commit.Summary()),
+			lineType: dltDiffCommitOid,
+		},
+		&diffLineData{
+			line:     fmt.Sprintf("To:\t%v %v", toCommit.oid.String(), toCommit.commit.Summary()),
+			lineType: dltDiffCommitOid,
+		},
+		&diffLineData{
+			lineType: dltNormal,
+		},
+	)
+
+	diff, err := diffView.repoData.DiffRange(fromCommit.oid, toCommit.oid, diffView.contextLines)
 	if err != nil {
 		return
 	}
 
+	if diff.diffText.Len() == 0 {
+		lines = append(lines, &diffLineData{
+			line:     "No differences",
+			lineType: dltNormal,
+		})
+
+		return
+	}
+
 	diffContent, err := diffView.generateDiffLinesForDiff(diff)
 	if err != nil {
 		return
@@ -489,6 +706,7 @@ func (diffView *DiffView) generateDiffLinesForDiff(diff *Diff) (lines []*diffLin
 	}
 
 	scanner = bufio.NewScanner(bytes.NewReader(diff.diffText.Bytes()))
+	diffTextStart := len(lines)
 
 	for scanner.Scan() {
 		lines = append(lines, &diffLineData{
@@ -496,6 +714,10 @@ func (diffView *DiffView) generateDiffLinesForDiff(diff *Diff) (lines []*diffLin
 		})
 	}
 
+	annotateWordDiffs(lines[diffTextStart:])
+	annotateLineNumbers(lines[diffTextStart:])
+	annotateSubmoduleChanges(lines[diffTextStart:])
+
 	return
 }
 
@@ -577,6 +799,16 @@ func (diffView *DiffView) LineNumber() (lineNumber uint) {
 	return lineNum
 }
 
+// pageRows returns the number of diff lines visible in the view
+// excluding the rows used by the border
+func (diffView *DiffView) pageRows() uint {
+	if diffView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return diffView.viewDimension.rows - 2
+}
+
 func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
 	diffLines, ok := diffView.diffs[diffView.activeDiff]
 	if !ok {
@@ -586,8 +818,8 @@ func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MoveLineDown(lineNum) {
-		log.Debugf("Moving down one line in diff view")
+	if viewPos.MoveLinesDown(ActionCount(action), lineNum) {
+		log.Debugf("Moving down %v lines in diff view", ActionCount(action))
 		diffView.channels.UpdateDisplay()
 	}
 
@@ -597,8 +829,8 @@ func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
 func moveUpDiffLine(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MoveLineUp() {
-		log.Debugf("Moving up one line in diff view")
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v lines in diff view", ActionCount(action))
 		diffView.channels.UpdateDisplay()
 	}
 
@@ -614,7 +846,7 @@ func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageDown(diffView.viewDimension.rows-2, lineNum) {
+	if viewPos.MovePageDown(diffView.pageRows(), lineNum) {
 		log.Debugf("Moving down one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -625,7 +857,7 @@ func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
 func moveUpDiffPage(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageUp(diffView.viewDimension.rows - 2) {
+	if viewPos.MovePageUp(diffView.pageRows()) {
 		log.Debugf("Moving up one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -642,7 +874,7 @@ func moveDownDiffHalfPage(diffView *DiffView, action Action) (err error) {
 	lineNum := uint(len(diffLines.lines))
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageDown(diffView.viewDimension.rows/2-2, lineNum) {
+	if viewPos.MovePageDown(diffView.pageRows()/2, lineNum) {
 		log.Debugf("Moving down one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -653,7 +885,7 @@ func moveDownDiffHalfPage(diffView *DiffView, action Action) (err error) {
 func moveUpDiffHalfPage(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageUp(diffView.viewDimension.rows/2 - 2) {
+	if viewPos.MovePageUp(diffView.pageRows() / 2) {
 		log.Debugf("Moving up one page in diff view")
 		diffView.channels.UpdateDisplay()
 	}
@@ -663,9 +895,35 @@ func moveUpDiffHalfPage(diffView *DiffView, action Action) (err error) {
 
 func scrollDiffViewRight(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
+	prevViewStartColumn := viewPos.ViewStartColumn()
+
 	viewPos.MovePageRight(diffView.viewDimension.cols)
-	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
-	diffView.channels.UpdateDisplay()
+	viewPos.ClampViewStartColumn(diffView.longestVisibleLineWidth())
+
+	if viewPos.ViewStartColumn() != prevViewStartColumn {
+		log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// longestVisibleLineWidth returns the width of the longest line currently displayed.
+// Each line is rendered with a single leading space which is included in the width
+func (diffView *DiffView) longestVisibleLineWidth() (longestWidth uint) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	lineNum := uint(len(diffLines.lines))
+	startLineIndex := diffView.viewPos.ViewStartRowIndex()
+	endLineIndex := MinUint(startLineIndex+diffView.pageRows(), lineNum)
+
+	for lineIndex := startLineIndex; lineIndex < endLineIndex; lineIndex++ {
+		lineWidth := uint(utf8.RuneCountInString(diffLines.lines[lineIndex].line)) + 1
+		longestWidth = MaxUint(longestWidth, lineWidth)
+	}
 
 	return
 }
@@ -681,6 +939,15 @@ func scrollDiffViewLeft(diffView *DiffView, action Action) (err error) {
 	return
 }
 
+func scrollDiffViewToFirstColumn(diffView *DiffView, action Action) (err error) {
+	if diffView.viewPos.MoveToFirstColumn() {
+		log.Debugf("Scrolling to first column")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
 func moveToFirstDiffLine(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
@@ -712,7 +979,7 @@ func moveToLastDiffLine(diffView *DiffView, action Action) (err error) {
 func centerDiffView(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.CenterActiveRow(diffView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(diffView.pageRows()) {
 		log.Debug("Centering DiffView")
 		diffView.channels.UpdateDisplay()
 	}
@@ -727,6 +994,10 @@ func selectDiffLine(diffView *DiffView, action Action) (err error) {
 	}
 
 	lineIndex := diffView.viewPos.ActiveRowIndex()
+	if lineIndex >= uint(len(diffLines.lines)) {
+		return
+	}
+
 	diffLine := diffLines.lines[lineIndex]
 
 	if diffLine.lineType != dltDiffStatsFile {
@@ -759,3 +1030,480 @@ func selectDiffLine(diffView *DiffView, action Action) (err error) {
 
 	return centerDiffView(diffView, action)
 }
+
+func toggleWordDiff(diffView *DiffView, action Action) (err error) {
+	diffView.wordDiff = !diffView.wordDiff
+	log.Debugf("Word diff highlighting enabled: %v", diffView.wordDiff)
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleDiffLineNumbers(diffView *DiffView, action Action) (err error) {
+	diffView.lineNumbers = !diffView.lineNumbers
+	log.Debugf("Diff line numbers enabled: %v", diffView.lineNumbers)
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func copyDiffPatch(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	patch := diffPatch(diffLines.lines)
+	if patch == "" {
+		return fmt.Errorf("No patch to copy")
+	}
+
+	if err = CopyToClipboard(patch); err != nil {
+		return
+	}
+
+	log.Debugf("Copied patch of %v lines to clipboard", strings.Count(patch, "\n"))
+	diffView.channels.ReportStatus("Copied patch to clipboard")
+
+	return
+}
+
+func copyDiffHunk(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	hunk, err := diffHunk(diffLines.lines, diffView.viewPos.ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	if err = CopyToClipboard(hunk); err != nil {
+		return
+	}
+
+	log.Debugf("Copied hunk of %v lines to clipboard", strings.Count(hunk, "\n"))
+	diffView.channels.ReportStatus("Copied hunk to clipboard")
+
+	return
+}
+
+// openSubmodule opens the repository of the submodule the selected line belongs to
+func openSubmodule(diffView *DiffView, action Action) (err error) {
+	diffLines, ok := diffView.diffs[diffView.activeDiff]
+	if !ok {
+		return
+	}
+
+	submoduleChange := submoduleChangeAt(diffLines.lines, diffView.viewPos.ActiveRowIndex())
+	if submoduleChange == nil {
+		diffView.channels.ReportStatus("No submodule selected")
+		return
+	}
+
+	workdir := diffView.repoData.Workdir()
+	if workdir == "" {
+		return fmt.Errorf("Unable to open submodule %v: Repository has no working tree", submoduleChange.path)
+	}
+
+	submodulePath := filepath.Join(workdir, submoduleChange.path)
+
+	if _, statErr := os.Stat(filepath.Join(submodulePath, ".git")); statErr != nil {
+		log.Debugf("Unable to find repository for submodule %v: %v", submoduleChange.path, statErr)
+		diffView.channels.ReportStatus("Submodule %v is not initialized", submoduleChange.path)
+		return
+	}
+
+	log.Infof("Opening submodule %v", submodulePath)
+
+	diffView.channels.DoAction(Action{
+		ActionType: ActionOpenRepository,
+		Args:       []interface{}{submodulePath},
+	})
+
+	return
+}
+
+func increaseDiffContext(diffView *DiffView, action Action) (err error) {
+	if diffView.contextLines < dvMaxContextLines {
+		err = diffView.setContextLines(diffView.contextLines + 1)
+	}
+
+	return
+}
+
+func decreaseDiffContext(diffView *DiffView, action Action) (err error) {
+	if diffView.contextLines > 0 {
+		err = diffView.setContextLines(diffView.contextLines - 1)
+	}
+
+	return
+}
+
+// setContextLines sets the number of context lines displayed around changes and reloads
+// the active diff. The line previously selected or the nearest line to it remains selected.
+// Cached diffs of other commits are discarded as they were generated with the previous value
+func (diffView *DiffView) setContextLines(contextLines uint) (err error) {
+	diffView.contextLines = contextLines
+	log.Debugf("Setting diff context lines to %v", contextLines)
+
+	activeDiffLines, ok := diffView.diffs[diffView.activeDiff]
+	diffView.diffs = make(map[diffID]*diffLines)
+
+	if ok {
+		position := newDiffLinePosition(activeDiffLines.lines, activeDiffLines.viewPos.ActiveRowIndex())
+
+		if activeDiffLines.lines, err = activeDiffLines.loadLines(); err != nil {
+			diffView.activeDiff = diffID("")
+			return
+		}
+
+		activeDiffLines.viewPos.SetActiveRowIndex(position.find(activeDiffLines.lines))
+		diffView.diffs[diffView.activeDiff] = activeDiffLines
+	}
+
+	diffView.channels.ReportStatus("Displaying %v lines of context", contextLines)
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+// diffLinePosition identifies a line in a diff independently of the number of context
+// lines displayed using the file the line belongs to and its line number in the new file
+type diffLinePosition struct {
+	lineIndex  uint
+	fileHeader string
+	lineNumber int
+}
+
+func newDiffLinePosition(lines []*diffLineData, lineIndex uint) (position diffLinePosition) {
+	position.lineIndex = lineIndex
+
+	if lineIndex >= uint(len(lines)) {
+		return
+	}
+
+	hunkStart := -1
+
+	for index := int(lineIndex); index >= 0; index-- {
+		lineType := lines[index].diffLineType()
+
+		if lineType == dltHunkStart && hunkStart == -1 {
+			hunkStart = index
+		} else if lineType == dltGitDiffHeader {
+			position.fileHeader = lines[index].line
+			break
+		}
+	}
+
+	if position.fileHeader == "" || hunkStart == -1 {
+		return
+	}
+
+	lineNumbers := diffNewLineNumbers(lines[hunkStart : lineIndex+1])
+	position.lineNumber = lineNumbers[len(lineNumbers)-1]
+
+	return
+}
+
+// find returns the index of the line in the provided diff lines nearest to the position.
+// If the file the position refers to is not present the original line index is returned
+func (position diffLinePosition) find(lines []*diffLineData) uint {
+	if len(lines) == 0 {
+		return 0
+	}
+
+	fileStart := -1
+
+	if position.fileHeader != "" {
+		for index, diffLine := range lines {
+			if diffLine.diffLineType() == dltGitDiffHeader && diffLine.line == position.fileHeader {
+				fileStart = index
+				break
+			}
+		}
+	}
+
+	if fileStart == -1 {
+		return MinUint(position.lineIndex, uint(len(lines)-1))
+	}
+
+	fileEnd := fileStart + 1
+	for fileEnd < len(lines) && lines[fileEnd].diffLineType() != dltGitDiffHeader {
+		fileEnd++
+	}
+
+	hunkStart := fileStart + 1
+	for hunkStart < fileEnd && lines[hunkStart].diffLineType() != dltHunkStart {
+		hunkStart++
+	}
+
+	if position.lineNumber == 0 || hunkStart == fileEnd {
+		return uint(fileStart)
+	}
+
+	nearestIndex, nearestDistance := hunkStart, -1
+
+	for offset, lineNumber := range diffNewLineNumbers(lines[hunkStart:fileEnd]) {
+		distance := lineNumber - position.lineNumber
+		if distance < 0 {
+			distance = -distance
+		}
+
+		if nearestDistance == -1 || distance < nearestDistance {
+			nearestIndex, nearestDistance = hunkStart+offset, distance
+		}
+	}
+
+	return uint(nearestIndex)
+}
+
+// annotateLineNumbers stores the line numbers in the old and new file of each line
+// within a hunk. The end of each hunk is determined by the line counts in its header
+func annotateLineNumbers(lines []*diffLineData) {
+	var oldLineNumber, newLineNumber, oldRemaining, newRemaining int
+
+	for _, diffLine := range lines {
+		line := diffLine.line
+
+		if oldRemaining <= 0 && newRemaining <= 0 {
+			if matches := hunkRangesRegex.FindStringSubmatch(line); matches != nil {
+				oldLineNumber, oldRemaining = parseHunkRange(matches[1], matches[2])
+				newLineNumber, newRemaining = parseHunkRange(matches[3], matches[4])
+			}
+
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+"):
+			diffLine.newLineNumber = newLineNumber
+			newLineNumber++
+			newRemaining--
+		case strings.HasPrefix(line, "-"):
+			diffLine.oldLineNumber = oldLineNumber
+			oldLineNumber++
+			oldRemaining--
+		case strings.HasPrefix(line, "\\"):
+		default:
+			diffLine.oldLineNumber = oldLineNumber
+			diffLine.newLineNumber = newLineNumber
+			oldLineNumber++
+			newLineNumber++
+			oldRemaining--
+			newRemaining--
+		}
+	}
+}
+
+// parseHunkRange parses the start line and line count of a hunk range.
+// The line count defaults to one when omitted
+func parseHunkRange(startText, countText string) (start, count int) {
+	start, _ = strconv.Atoi(startText)
+	count = 1
+
+	if countText != "" {
+		count, _ = strconv.Atoi(countText)
+	}
+
+	return
+}
+
+// diffNewLineNumbers returns the line number in the new file of each of the provided
+// lines, which must start with a hunk header. Hunk headers are assigned the line number
+// preceding the hunk and removed lines the line number of the preceding new line
+func diffNewLineNumbers(lines []*diffLineData) (lineNumbers []int) {
+	lineNumber := 0
+
+	for _, diffLine := range lines {
+		switch line := diffLine.line; {
+		case diffLine.diffLineType() == dltHunkStart:
+			if matches := hunkNewStartRegex.FindStringSubmatch(line); matches != nil {
+				start, _ := strconv.Atoi(matches[1])
+				lineNumber = start - 1
+			}
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, "\\"):
+		default:
+			lineNumber++
+		}
+
+		lineNumbers = append(lineNumbers, lineNumber)
+	}
+
+	return
+}
+
+// diffPatch returns the patch text of the diff lines excluding
+// any commit details and diff stats that precede it
+// annotateSubmoduleChanges attaches the old and new commit of each changed submodule to its file
+// header and commit lines. A file is a submodule if its extended header contains the gitlink mode
+func annotateSubmoduleChanges(lines []*diffLineData) {
+	var header *diffLineData
+	inHunk, isSubmodule := false, false
+
+	for _, diffLine := range lines {
+		lineType := diffLine.diffLineType()
+
+		switch {
+		case lineType == dltGitDiffHeader:
+			header = diffLine
+			inHunk, isSubmodule = false, false
+		case header == nil:
+		case lineType == dltHunkStart:
+			inHunk = true
+		case !inHunk:
+			isSubmodule = isSubmodule || strings.HasSuffix(diffLine.line, " "+dvSubmoduleMode)
+		case isSubmodule && (lineType == dltLineRemoved || lineType == dltLineAdded) && strings.HasPrefix(diffLine.line[1:], dvSubprojectCommit):
+			if header.submodule == nil {
+				header.submodule = &diffSubmoduleChange{path: diffHeaderPath(header.line)}
+			}
+
+			id := strings.TrimPrefix(diffLine.line[1:], dvSubprojectCommit)
+
+			if lineType == dltLineRemoved {
+				header.submodule.oldID = id
+			} else {
+				header.submodule.newID = id
+			}
+
+			diffLine.submodule = header.submodule
+		}
+	}
+}
+
+// diffHeaderPath returns the new path of the file from a diff --git header line
+func diffHeaderPath(headerLine string) string {
+	if index := strings.LastIndex(headerLine, " b/"); index != -1 {
+		return headerLine[index+3:]
+	}
+
+	return strings.TrimPrefix(headerLine, "diff --git ")
+}
+
+// submoduleChangeAt returns the submodule change of the file containing the line at the provided index
+func submoduleChangeAt(lines []*diffLineData, lineIndex uint) *diffSubmoduleChange {
+	if lineIndex >= uint(len(lines)) {
+		return nil
+	}
+
+	for index := int(lineIndex); index >= 0; index-- {
+		diffLine := lines[index]
+
+		if diffLine.submodule != nil {
+			return diffLine.submodule
+		} else if diffLine.diffLineType() == dltGitDiffHeader {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func diffPatch(lines []*diffLineData) string {
+	for lineIndex, diffLine := range lines {
+		if diffLine.diffLineType() == dltGitDiffHeader {
+			return joinDiffLines(lines[lineIndex:])
+		}
+	}
+
+	return ""
+}
+
+// diffHunk returns a patch containing the hunk at the provided line index
+// preceded by the header of the file the hunk belongs to.
+// The extent of the hunk is determined by the line counts in its @@ header
+func diffHunk(lines []*diffLineData, lineIndex uint) (hunk string, err error) {
+	if lineIndex >= uint(len(lines)) {
+		return "", fmt.Errorf("No hunk selected")
+	}
+
+	fileStart, hunkStart := -1, -1
+
+	for index := int(lineIndex); index >= 0 && fileStart == -1; index-- {
+		switch lines[index].diffLineType() {
+		case dltHunkStart:
+			if hunkStart == -1 {
+				hunkStart = index
+			}
+		case dltGitDiffHeader:
+			fileStart = index
+		}
+	}
+
+	if fileStart == -1 || hunkStart == -1 {
+		return "", fmt.Errorf("No hunk selected")
+	}
+
+	oldLines, newLines, ok := parseHunkHeader(lines[hunkStart].line)
+	if !ok {
+		return "", fmt.Errorf("Unable to parse hunk header: %v", lines[hunkStart].line)
+	}
+
+	hunkEnd := hunkStart + 1
+
+	for ; hunkEnd < len(lines) && (oldLines > 0 || newLines > 0); hunkEnd++ {
+		switch line := lines[hunkEnd].line; {
+		case strings.HasPrefix(line, "-"):
+			oldLines--
+		case strings.HasPrefix(line, "+"):
+			newLines--
+		case strings.HasPrefix(line, "\\"):
+		default:
+			oldLines--
+			newLines--
+		}
+	}
+
+	if hunkEnd < len(lines) && strings.HasPrefix(lines[hunkEnd].line, "\\") {
+		hunkEnd++
+	}
+
+	if int(lineIndex) >= hunkEnd {
+		return "", fmt.Errorf("No hunk selected")
+	}
+
+	fileHeaderEnd := fileStart + 1
+	for lines[fileHeaderEnd].diffLineType() != dltHunkStart {
+		fileHeaderEnd++
+	}
+
+	return joinDiffLines(lines[fileStart:fileHeaderEnd]) + joinDiffLines(lines[hunkStart:hunkEnd]), nil
+}
+
+// parseHunkHeader returns the number of old and new lines a hunk
+// contains as specified in its @@ header. Omitted counts default to 1
+func parseHunkHeader(line string) (oldLines, newLines int, ok bool) {
+	matches := hunkHeaderRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return
+	}
+
+	counts := []int{1, 1}
+
+	for index, match := range matches[1:] {
+		if match == "" {
+			continue
+		}
+
+		count, err := strconv.Atoi(match)
+		if err != nil {
+			return
+		}
+
+		counts[index] = count
+	}
+
+	return counts[0], counts[1], true
+}
+
+func joinDiffLines(lines []*diffLineData) string {
+	var buffer bytes.Buffer
+
+	for _, diffLine := range lines {
+		buffer.WriteString(diffLine.line)
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String()
+}
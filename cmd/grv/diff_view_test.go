@@ -0,0 +1,264 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestDiffLines(lines ...string) (diffLines []*diffLineData) {
+	for _, line := range lines {
+		diffLines = append(diffLines, &diffLineData{line: line})
+	}
+
+	return
+}
+
+func TestDiffHunk(t *testing.T) {
+	lines := append([]*diffLineData{
+		{line: "Commit:\tabc", lineType: dltDiffCommitOid},
+		{lineType: dltNormal},
+	}, newTestDiffLines(
+		"diff --git a/file b/file",
+		"index 1111111..2222222 100644",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -1,2 +1,2 @@",
+		" context",
+		"--- removed line resembling a header",
+		"+added",
+		"@@ -10 +10,0 @@",
+		"-last",
+		"\\ No newline at end of file",
+	)...)
+
+	fileHeader := "diff --git a/file b/file\nindex 1111111..2222222 100644\n--- a/file\n+++ b/file\n"
+
+	var diffHunkTests = []struct {
+		lineIndex     uint
+		expectedHunk  string
+		expectedError bool
+	}{
+		{lineIndex: 0, expectedError: true},
+		{lineIndex: 3, expectedError: true},
+		{lineIndex: 6, expectedHunk: fileHeader + "@@ -1,2 +1,2 @@\n context\n--- removed line resembling a header\n+added\n"},
+		{lineIndex: 9, expectedHunk: fileHeader + "@@ -1,2 +1,2 @@\n context\n--- removed line resembling a header\n+added\n"},
+		{lineIndex: 12, expectedHunk: fileHeader + "@@ -10 +10,0 @@\n-last\n\\ No newline at end of file\n"},
+	}
+
+	for _, diffHunkTest := range diffHunkTests {
+		hunk, err := diffHunk(lines, diffHunkTest.lineIndex)
+
+		if diffHunkTest.expectedError {
+			if err == nil {
+				t.Errorf("Expected error for line index %v but found hunk %q", diffHunkTest.lineIndex, hunk)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for line index %v: %v", diffHunkTest.lineIndex, err)
+		} else if hunk != diffHunkTest.expectedHunk {
+			t.Errorf("Hunk for line index %v does not match expected value. Expected: %q, Actual: %q",
+				diffHunkTest.lineIndex, diffHunkTest.expectedHunk, hunk)
+		}
+	}
+}
+
+func TestDiffPatchExcludesCommitDetails(t *testing.T) {
+	lines := append([]*diffLineData{
+		{line: "Commit:\tabc", lineType: dltDiffCommitOid},
+		{line: "diff --git in a commit message", lineType: dltDiffCommitMessage},
+		{line: " file | 1 +", lineType: dltDiffStatsFile},
+	}, newTestDiffLines(
+		"diff --git a/file b/file",
+		"@@ -0,0 +1 @@",
+		"+added",
+	)...)
+
+	expectedPatch := "diff --git a/file b/file\n@@ -0,0 +1 @@\n+added\n"
+
+	if patch := diffPatch(lines); patch != expectedPatch {
+		t.Errorf("Patch does not match expected value. Expected: %q, Actual: %q", expectedPatch, patch)
+	}
+}
+
+func TestDiffLinePositionFindsNearestLineWhenContextChanges(t *testing.T) {
+	lessContext := newTestDiffLines(
+		"diff --git a/file b/file",
+		"index 1111111..2222222 100644",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -4,3 +4,3 @@",
+		" four",
+		"-five",
+		"+FIVE",
+		" six",
+		"diff --git a/other b/other",
+	)
+
+	moreContext := newTestDiffLines(
+		"diff --git a/file b/file",
+		"index 1111111..2222222 100644",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -2,7 +2,7 @@",
+		" two",
+		" three",
+		" four",
+		"-five",
+		"+FIVE",
+		" six",
+		" seven",
+		" eight",
+		"diff --git a/other b/other",
+	)
+
+	var diffLinePositionTests = []struct {
+		lineIndex         uint
+		expectedLineIndex uint
+	}{
+		{lineIndex: 1, expectedLineIndex: 0},
+		{lineIndex: 5, expectedLineIndex: 7},
+		{lineIndex: 7, expectedLineIndex: 9},
+		{lineIndex: 8, expectedLineIndex: 10},
+		{lineIndex: 9, expectedLineIndex: 13},
+	}
+
+	for _, diffLinePositionTest := range diffLinePositionTests {
+		position := newDiffLinePosition(lessContext, diffLinePositionTest.lineIndex)
+
+		if lineIndex := position.find(moreContext); lineIndex != diffLinePositionTest.expectedLineIndex {
+			t.Errorf("Line index %v mapped to %v but expected %v",
+				diffLinePositionTest.lineIndex, lineIndex, diffLinePositionTest.expectedLineIndex)
+		}
+	}
+
+	position := newDiffLinePosition(moreContext, 12)
+
+	if lineIndex := position.find(lessContext); lineIndex != 8 {
+		t.Errorf("Expected line outside of the reduced context to map to nearest line 8 but found %v", lineIndex)
+	}
+}
+
+func TestAnnotateLineNumbers(t *testing.T) {
+	lines := newTestDiffLines(
+		"diff --git a/file b/file",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -8,3 +8,4 @@ func example() {",
+		" context",
+		"--- removed line resembling a header",
+		"+added",
+		"+++ added line resembling a header",
+		" context",
+		"@@ -20 +21,0 @@",
+		"-last",
+		"\\ No newline at end of file",
+	)
+
+	annotateLineNumbers(lines)
+
+	expectedGutterLineNumbers := []int{0, 0, 0, 0, 8, 9, 9, 10, 11, 0, 20, 0}
+
+	for lineIndex, diffLine := range lines {
+		if lineNumber := diffLine.gutterLineNumber(); lineNumber != expectedGutterLineNumbers[lineIndex] {
+			t.Errorf("Line %q has line number %v but expected %v", diffLine.line, lineNumber, expectedGutterLineNumbers[lineIndex])
+		}
+	}
+
+	if gutterWidth := lineNumberGutterWidth(lines); gutterWidth != 2 {
+		t.Errorf("Expected gutter width 2 but found %v", gutterWidth)
+	}
+}
+
+func TestAnnotateSubmoduleChanges(t *testing.T) {
+	lines := newTestDiffLines(
+		"diff --git a/lib/dep b/lib/dep",
+		"index 1a2b3c4..5d6e7f8 160000",
+		"--- a/lib/dep",
+		"+++ b/lib/dep",
+		"@@ -1 +1 @@",
+		"-Subproject commit 1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+		"+Subproject commit 5d6e7f8091a2b3c4d1a2b3c4d5e6f708192a3b4c",
+		"diff --git a/vendor/new b/vendor/new",
+		"new file mode 160000",
+		"index 0000000..9f8e7d6",
+		"--- /dev/null",
+		"+++ b/vendor/new",
+		"@@ -0,0 +1 @@",
+		"+Subproject commit 9f8e7d6c5b4a39281706f5e4d3c2b1a098765432",
+		"diff --git a/file b/file",
+		"index 1111111..2222222 100644",
+		"--- a/file",
+		"+++ b/file",
+		"@@ -1 +1 @@",
+		"-Subproject commit 1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+		"+ends with 160000",
+	)
+
+	annotateSubmoduleChanges(lines)
+
+	if description := lines[0].submodule.description(); description != "Submodule lib/dep 1a2b3c4 → 5d6e7f8" {
+		t.Errorf("Unexpected description for changed submodule: %v", description)
+	}
+
+	if lines[5].submodule != lines[0].submodule || lines[6].submodule != lines[0].submodule {
+		t.Errorf("Expected submodule commit lines to share the change of their file header")
+	}
+
+	if description := lines[7].submodule.description(); description != "Submodule vendor/new added at 9f8e7d6" {
+		t.Errorf("Unexpected description for added submodule: %v", description)
+	}
+
+	for _, lineIndex := range []int{14, 19, 20} {
+		if lines[lineIndex].submodule != nil {
+			t.Errorf("Expected line %q of regular file to not be annotated as a submodule", lines[lineIndex].line)
+		}
+	}
+
+	if submodule := submoduleChangeAt(lines, 4); submodule != lines[0].submodule {
+		t.Errorf("Expected hunk header to belong to submodule lib/dep but found %v", submodule)
+	}
+
+	if submodule := submoduleChangeAt(lines, 16); submodule != nil {
+		t.Errorf("Expected no submodule for regular file but found %v", submodule)
+	}
+}
+
+func TestOpenSubmoduleReportsUninitializedSubmodule(t *testing.T) {
+	workdir, err := ioutil.TempDir("", "grv-submodule")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(workdir)
+
+	repoData := &MockRepoData{}
+	repoData.On("Workdir").Return(workdir)
+
+	channels := newTestChannels()
+	actionCh := make(chan Action, 100)
+	channels.actionCh = actionCh
+	diffView := NewDiffView(repoData, channels)
+	diffView.activeDiff = "test"
+	diffView.diffs["test"] = &diffLines{
+		lines: newTestDiffLines(
+			"diff --git a/lib/dep b/lib/dep",
+			"index 1a2b3c4..5d6e7f8 160000",
+			"@@ -1 +1 @@",
+			"-Subproject commit 1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d",
+			"+Subproject commit 5d6e7f8091a2b3c4d1a2b3c4d5e6f708192a3b4c",
+		),
+	}
+	annotateSubmoduleChanges(diffView.diffs["test"].lines)
+
+	if err := openSubmodule(diffView, Action{ActionType: ActionOpenSubmodule}); err != nil {
+		t.Fatalf("Unexpected error opening submodule: %v", err)
+	}
+
+	select {
+	case action := <-actionCh:
+		if action.ActionType != ActionShowStatus || action.Args[0] != "Submodule lib/dep is not initialized" {
+			t.Errorf("Expected uninitialized submodule status but found %v", action)
+		}
+	default:
+		t.Errorf("Expected status to be reported for uninitialized submodule")
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"time"
+)
+
+// FrameTimer signals when the current frame interval has elapsed
+type FrameTimer interface {
+	Reset(duration time.Duration)
+	C() <-chan time.Time
+}
+
+type systemFrameTimer struct {
+	timer *time.Timer
+}
+
+func newSystemFrameTimer() *systemFrameTimer {
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+
+	return &systemFrameTimer{
+		timer: timer,
+	}
+}
+
+// Reset restarts the timer so that it fires after the provided duration
+func (systemFrameTimer *systemFrameTimer) Reset(duration time.Duration) {
+	systemFrameTimer.timer.Reset(duration)
+}
+
+// C returns the channel the timer fires on
+func (systemFrameTimer *systemFrameTimer) C() <-chan time.Time {
+	return systemFrameTimer.timer.C
+}
+
+// FrameLimiter coalesces display refresh requests so that
+// at most one render is performed per frame interval
+type FrameLimiter struct {
+	frameInterval time.Duration
+	requestCh     <-chan bool
+	timer         FrameTimer
+	frameDue      bool
+}
+
+// NewFrameLimiter creates a new instance which schedules frames using a system timer
+// Requests pending on the provided channel are discarded when a frame is rendered
+func NewFrameLimiter(frameInterval time.Duration, requestCh <-chan bool) *FrameLimiter {
+	return NewFrameLimiterWithTimer(frameInterval, requestCh, newSystemFrameTimer())
+}
+
+// NewFrameLimiterWithTimer creates a new instance which schedules frames using the provided timer
+func NewFrameLimiterWithTimer(frameInterval time.Duration, requestCh <-chan bool, timer FrameTimer) *FrameLimiter {
+	return &FrameLimiter{
+		frameInterval: frameInterval,
+		requestCh:     requestCh,
+		timer:         timer,
+	}
+}
+
+// RequestFrame schedules a frame at the end of the current frame interval
+// Requests made while a frame is already scheduled are merged into that frame
+func (frameLimiter *FrameLimiter) RequestFrame() {
+	if !frameLimiter.frameDue {
+		frameLimiter.timer.Reset(frameLimiter.frameInterval)
+		frameLimiter.frameDue = true
+	}
+}
+
+// FrameCh receives a value when a scheduled frame should be rendered
+func (frameLimiter *FrameLimiter) FrameCh() <-chan time.Time {
+	return frameLimiter.timer.C()
+}
+
+// OnFrame marks the scheduled frame as rendered.
+// Any requests pending on the request channel are discarded as they are satisfied by this frame
+func (frameLimiter *FrameLimiter) OnFrame() {
+	frameLimiter.frameDue = false
+
+	for {
+		select {
+		case <-frameLimiter.requestCh:
+		default:
+			return
+		}
+	}
+}
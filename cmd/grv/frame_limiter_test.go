@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testFrameInterval = 20 * time.Millisecond
+)
+
+type testFrameTimer struct {
+	resets  []time.Duration
+	timerCh chan time.Time
+}
+
+func newTestFrameTimer() *testFrameTimer {
+	return &testFrameTimer{
+		timerCh: make(chan time.Time, 1),
+	}
+}
+
+func (frameTimer *testFrameTimer) Reset(duration time.Duration) {
+	frameTimer.resets = append(frameTimer.resets, duration)
+}
+
+func (frameTimer *testFrameTimer) C() <-chan time.Time {
+	return frameTimer.timerCh
+}
+
+func TestRapidFrameRequestsProduceSingleFrame(t *testing.T) {
+	frameTimer := newTestFrameTimer()
+	frameLimiter := NewFrameLimiterWithTimer(testFrameInterval, make(chan bool), frameTimer)
+
+	for i := 0; i < 100; i++ {
+		frameLimiter.RequestFrame()
+	}
+
+	if len(frameTimer.resets) != 1 || frameTimer.resets[0] != testFrameInterval {
+		t.Errorf("Expected a single frame to be scheduled after %v but found %v", testFrameInterval, frameTimer.resets)
+	}
+}
+
+func TestFrameChIsTimerChannel(t *testing.T) {
+	frameTimer := newTestFrameTimer()
+	frameLimiter := NewFrameLimiterWithTimer(testFrameInterval, make(chan bool), frameTimer)
+
+	frameLimiter.RequestFrame()
+	frameTimer.timerCh <- time.Time{}
+
+	select {
+	case <-frameLimiter.FrameCh():
+	default:
+		t.Errorf("Expected frame to be signalled when the timer fires")
+	}
+}
+
+func TestPendingDisplayRequestsAreDiscardedOnFrame(t *testing.T) {
+	displayCh := make(chan bool, 10)
+	frameLimiter := NewFrameLimiterWithTimer(testFrameInterval, displayCh, newTestFrameTimer())
+
+	for i := 0; i < cap(displayCh); i++ {
+		displayCh <- true
+	}
+
+	frameLimiter.RequestFrame()
+	frameLimiter.OnFrame()
+
+	if pending := len(displayCh); pending != 0 {
+		t.Errorf("Expected no pending display requests after frame but found %v", pending)
+	}
+}
+
+func TestFrameCanBeRequestedAfterFrameRendered(t *testing.T) {
+	frameTimer := newTestFrameTimer()
+	frameLimiter := NewFrameLimiterWithTimer(testFrameInterval, make(chan bool), frameTimer)
+
+	frameLimiter.RequestFrame()
+	frameLimiter.OnFrame()
+	frameLimiter.RequestFrame()
+	frameLimiter.RequestFrame()
+
+	if len(frameTimer.resets) != 2 {
+		t.Errorf("Expected a frame to be scheduled before and after the rendered frame but found %v", frameTimer.resets)
+	}
+}
+
+func TestNoFrameIsScheduledWithoutRequest(t *testing.T) {
+	frameTimer := newTestFrameTimer()
+	frameLimiter := NewFrameLimiterWithTimer(testFrameInterval, make(chan bool), frameTimer)
+
+	frameLimiter.OnFrame()
+
+	if len(frameTimer.resets) != 0 {
+		t.Errorf("Expected no frame to be scheduled but found %v", frameTimer.resets)
+	}
+}
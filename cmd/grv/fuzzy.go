@@ -0,0 +1,117 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	fzConsecutiveBonus = 5
+	fzWordStartBonus   = 3
+)
+
+// FuzzyMatch determines whether the characters of the pattern appear in order within the line.
+// Matching is case insensitive unless the pattern contains an upper case character.
+// Consecutive matched characters and matches at the start of words increase the score
+// while unmatched characters between the first and last match decrease it
+func FuzzyMatch(pattern, line string) (score int, matchIndexes []SearchMatchIndex, matched bool) {
+	patternRunes := []rune(pattern)
+	if len(patternRunes) == 0 {
+		return
+	}
+
+	ignoreCase := !containsUpperCase(pattern)
+
+	for startIndex, char := range line {
+		if !fuzzyRuneEqual(char, patternRunes[0], ignoreCase) {
+			continue
+		}
+
+		candidateScore, candidateIndexes, candidateMatched := fuzzyMatchFrom(patternRunes, line, startIndex, ignoreCase)
+
+		if candidateMatched && (!matched || candidateScore > score) {
+			score, matchIndexes, matched = candidateScore, candidateIndexes, true
+		}
+	}
+
+	return
+}
+
+// fuzzyMatchFrom greedily matches the pattern against the line starting at the provided byte index
+func fuzzyMatchFrom(patternRunes []rune, line string, startIndex int, ignoreCase bool) (score int, matchIndexes []SearchMatchIndex, matched bool) {
+	patternIndex := 0
+	prevMatched := false
+	lastMatchEnd := startIndex
+
+	for byteIndex, char := range line[startIndex:] {
+		byteIndex += startIndex
+
+		if !fuzzyRuneEqual(char, patternRunes[patternIndex], ignoreCase) {
+			prevMatched = false
+			continue
+		}
+
+		score++
+		charEnd := uint(byteIndex + utf8.RuneLen(char))
+
+		if prevMatched {
+			score += fzConsecutiveBonus
+			matchIndexes[len(matchIndexes)-1].ByteEndIndex = charEnd
+		} else {
+			if isFuzzyWordStart(line, byteIndex) {
+				score += fzWordStartBonus
+			}
+
+			matchIndexes = append(matchIndexes, SearchMatchIndex{
+				ByteStartIndex: uint(byteIndex),
+				ByteEndIndex:   charEnd,
+			})
+		}
+
+		prevMatched = true
+		lastMatchEnd = int(charEnd)
+
+		if patternIndex++; patternIndex == len(patternRunes) {
+			matched = true
+			break
+		}
+	}
+
+	if matched {
+		score -= utf8.RuneCountInString(line[startIndex:lastMatchEnd]) - len(patternRunes)
+	}
+
+	return
+}
+
+func fuzzyRuneEqual(char, patternChar rune, ignoreCase bool) bool {
+	if ignoreCase {
+		return unicode.ToLower(char) == unicode.ToLower(patternChar)
+	}
+
+	return char == patternChar
+}
+
+func isFuzzyWordStart(line string, byteIndex int) bool {
+	if byteIndex == 0 {
+		return true
+	}
+
+	prevChar, _ := utf8.DecodeLastRuneInString(line[:byteIndex])
+
+	return !unicode.IsLetter(prevChar) && !unicode.IsDigit(prevChar)
+}
+
+// FuzzyRegexPattern returns a regular expression matching the shortest text
+// containing the characters of the pattern in order
+func FuzzyRegexPattern(pattern string) string {
+	var regexPattern []string
+
+	for _, char := range pattern {
+		regexPattern = append(regexPattern, regexp.QuoteMeta(string(char)))
+	}
+
+	return strings.Join(regexPattern, ".*?")
+}
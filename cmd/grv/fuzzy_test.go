@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	var fuzzyMatchTests = []struct {
+		pattern              string
+		line                 string
+		expectedMatched      bool
+		expectedMatchIndexes []SearchMatchIndex
+	}{
+		{
+			pattern:              "fxbug",
+			line:                 "Fix parser bug",
+			expectedMatched:      true,
+			expectedMatchIndexes: []SearchMatchIndex{{0, 1}, {2, 3}, {11, 14}},
+		},
+		{
+			pattern:              "tst",
+			line:                 "Test line 4: tst",
+			expectedMatched:      true,
+			expectedMatchIndexes: []SearchMatchIndex{{13, 16}},
+		},
+		{
+			pattern:         "abc",
+			line:            "acb",
+			expectedMatched: false,
+		},
+		{
+			pattern:         "Fix",
+			line:            "fix parser bug",
+			expectedMatched: false,
+		},
+		{
+			pattern:         "",
+			line:            "empty pattern",
+			expectedMatched: false,
+		},
+	}
+
+	for _, fuzzyMatchTest := range fuzzyMatchTests {
+		_, matchIndexes, matched := FuzzyMatch(fuzzyMatchTest.pattern, fuzzyMatchTest.line)
+
+		if matched != fuzzyMatchTest.expectedMatched {
+			t.Errorf("FuzzyMatch(%q, %q) matched does not match expected value. Expected: %v, Actual: %v",
+				fuzzyMatchTest.pattern, fuzzyMatchTest.line, fuzzyMatchTest.expectedMatched, matched)
+		} else if matched && !reflect.DeepEqual(matchIndexes, fuzzyMatchTest.expectedMatchIndexes) {
+			t.Errorf("FuzzyMatch(%q, %q) match indexes do not match expected value. Expected: %v, Actual: %v",
+				fuzzyMatchTest.pattern, fuzzyMatchTest.line, fuzzyMatchTest.expectedMatchIndexes, matchIndexes)
+		}
+	}
+}
+
+func TestFuzzyMatchScoresConsecutiveCharactersHigher(t *testing.T) {
+	consecutiveScore, _, _ := FuzzyMatch("ab", "xab")
+	scatteredScore, _, _ := FuzzyMatch("ab", "axb")
+
+	if consecutiveScore <= scatteredScore {
+		t.Errorf("Expected consecutive match score %v to be greater than scattered match score %v", consecutiveScore, scatteredScore)
+	}
+}
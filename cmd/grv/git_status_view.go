@@ -145,7 +145,7 @@ func (gitStatusView *GitStatusView) Render(win RenderWindow) (err error) {
 
 	win.DrawBorder()
 
-	if err = win.SetTitle(CmpCommitviewTitle, "Status"); err != nil {
+	if err = win.SetTitle(CmpCommitviewTitle, "%v", gitStatusView.title()); err != nil {
 		return
 	}
 
@@ -204,13 +204,31 @@ func (gitStatusView *GitStatusView) OnActiveChange(active bool) {
 	}
 }
 
+// title returns the title of the git status view which contains the
+// checked out branch or the commit HEAD is detached at
+func (gitStatusView *GitStatusView) title() string {
+	head := gitStatusView.repoData.Head()
+
+	if head == nil {
+		return "Status"
+	} else if detachedHead := DetachedHeadDescription(head); detachedHead != "" {
+		return fmt.Sprintf("Status (%v)", detachedHead)
+	}
+
+	return fmt.Sprintf("Status (on %v)", head.Shorthand())
+}
+
 // ViewID returns the ViewID for the git status view
 func (gitStatusView *GitStatusView) ViewID() ViewID {
 	return ViewGitStatus
 }
 
-// RenderHelpBar does nothing
-func (gitStatusView *GitStatusView) RenderHelpBar(*LineBuilder) (err error) {
+// RenderHelpBar shows key bindings custom to the git status view
+func (gitStatusView *GitStatusView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(gitStatusView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Show Diff"},
+	})
+
 	return
 }
 
@@ -423,6 +441,16 @@ func (gitStatusView *GitStatusView) lineNumber() uint {
 	return uint(len(gitStatusView.renderedStatus))
 }
 
+// pageRows returns the number of status lines visible in the view
+// excluding the rows used by the border
+func (gitStatusView *GitStatusView) pageRows() uint {
+	if gitStatusView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return gitStatusView.viewDimension.rows - 2
+}
+
 func (gitStatusView *GitStatusView) createGitStatusViewListener() {
 	createViewArgs := CreateViewArgs{
 		viewID: ViewDiff,
@@ -474,19 +502,21 @@ func moveUpGitStatusEntry(gitStatusView *GitStatusView, action Action) (err erro
 	viewPos := gitStatusView.ViewPos()
 	renderedStatus := gitStatusView.renderedStatus
 
-	for viewPos.ActiveRowIndex() > 0 {
-		if !viewPos.MoveLineUp() {
-			return
-		}
+	for count := ActionCount(action); count > 0 && viewPos.ActiveRowIndex() > 0; count-- {
+		for viewPos.ActiveRowIndex() > 0 {
+			if !viewPos.MoveLineUp() {
+				return
+			}
 
-		if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
-			break
+			if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
+				break
+			}
 		}
 	}
 
 	if action.ActionType == ActionPrevLine {
 		gitStatusView.selectEntry(viewPos.ActiveRowIndex())
-		log.Debug("Moved up one status entry")
+		log.Debugf("Moved up %v status entries", ActionCount(action))
 		gitStatusView.channels.UpdateDisplay()
 	}
 
@@ -502,19 +532,21 @@ func moveDownGitStatusEntry(gitStatusView *GitStatusView, action Action) (err er
 		return
 	}
 
-	for viewPos.ActiveRowIndex() < renderedStatusNum-1 {
-		if !viewPos.MoveLineDown(renderedStatusNum) {
-			return
-		}
+	for count := ActionCount(action); count > 0 && viewPos.ActiveRowIndex() < renderedStatusNum-1; count-- {
+		for viewPos.ActiveRowIndex() < renderedStatusNum-1 {
+			if !viewPos.MoveLineDown(renderedStatusNum) {
+				return
+			}
 
-		if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
-			break
+			if renderedStatus[viewPos.ActiveRowIndex()].text != "" {
+				break
+			}
 		}
 	}
 
 	if action.ActionType == ActionNextLine {
 		gitStatusView.selectEntry(viewPos.ActiveRowIndex())
-		log.Debug("Moved down one status entry")
+		log.Debugf("Moved down %v status entries", ActionCount(action))
 		gitStatusView.channels.UpdateDisplay()
 	}
 
@@ -522,7 +554,7 @@ func moveDownGitStatusEntry(gitStatusView *GitStatusView, action Action) (err er
 }
 
 func moveUpGitStatusPage(gitStatusView *GitStatusView, action Action) (err error) {
-	pageSize := gitStatusView.viewDimension.rows - 2
+	pageSize := gitStatusView.pageRows()
 	viewPos := gitStatusView.ViewPos()
 
 	for viewPos.ActiveRowIndex() > 0 && pageSize > 0 {
@@ -544,7 +576,7 @@ func moveUpGitStatusPage(gitStatusView *GitStatusView, action Action) (err error
 }
 
 func moveDownGitStatusPage(gitStatusView *GitStatusView, action Action) (err error) {
-	pageSize := gitStatusView.viewDimension.rows - 2
+	pageSize := gitStatusView.pageRows()
 	viewPos := gitStatusView.ViewPos()
 	renderedStatusNum := gitStatusView.lineNumber()
 
@@ -567,7 +599,7 @@ func moveDownGitStatusPage(gitStatusView *GitStatusView, action Action) (err err
 }
 
 func moveUpGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err error) {
-	halfPageSize := gitStatusView.viewDimension.rows/2 - 2
+	halfPageSize := gitStatusView.pageRows() / 2
 	viewPos := gitStatusView.ViewPos()
 
 	for viewPos.ActiveRowIndex() > 0 && halfPageSize > 0 {
@@ -589,7 +621,7 @@ func moveUpGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err e
 }
 
 func moveDownGitStatusHalfPage(gitStatusView *GitStatusView, action Action) (err error) {
-	halfPageSize := gitStatusView.viewDimension.rows/2 - 2
+	halfPageSize := gitStatusView.pageRows() / 2
 	viewPos := gitStatusView.ViewPos()
 	renderedStatusNum := gitStatusView.lineNumber()
 
@@ -665,7 +697,7 @@ func moveToLastGitStatusEntry(gitStatusView *GitStatusView, action Action) (err
 func centerGitStatusView(gitStatusView *GitStatusView, action Action) (err error) {
 	viewPos := gitStatusView.ViewPos()
 
-	if viewPos.CenterActiveRow(gitStatusView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(gitStatusView.pageRows()) {
 		log.Debug("Centering GitStatusView")
 		gitStatusView.channels.UpdateDisplay()
 	}
@@ -2,9 +2,12 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -83,6 +86,10 @@ type GRV struct {
 	inputBuffer    *InputBuffer
 	input          *InputKeyMapper
 	eventListeners []EventListener
+	confirmPrompt  *ConfirmPrompt
+	keyBindings    KeyBindings
+	repoChangedCh  chan bool
+	lock           sync.Mutex
 }
 
 // UpdateDisplay sends a request to update the display
@@ -135,6 +142,55 @@ func (channels *Channels) ReportEvent(event Event) {
 	}
 }
 
+// Confirm displays the question in the status bar and returns a channel
+// which receives true if the user answers yes and false otherwise.
+// Only the caller waits on the answer, so handlers should receive from
+// the returned channel in a separate goroutine rather than blocking:
+//
+//	resultCh := channels.Confirm("Drop stash?")
+//	go func() {
+//		if <-resultCh {
+//			...
+//		}
+//	}()
+func (channels *Channels) Confirm(question string) <-chan bool {
+	confirmPrompt := NewConfirmPrompt(question)
+
+	channels.DoAction(Action{
+		ActionType: ActionConfirmPrompt,
+		Args:       []interface{}{confirmPrompt},
+	})
+
+	return confirmPrompt.Result()
+}
+
+// RunOperation runs the provided operation in a separate goroutine. While the operation
+// runs a spinner is displayed in the status bar alongside its description. Once complete
+// the status returned by the operation is reported, or its error if it failed
+func (channels *Channels) RunOperation(description string, operation func() (status string, err error)) {
+	runningOperation := &Operation{description: description}
+
+	channels.DoAction(Action{
+		ActionType: ActionOperationStarted,
+		Args:       []interface{}{runningOperation},
+	})
+
+	go func() {
+		status, err := operation()
+
+		channels.DoAction(Action{
+			ActionType: ActionOperationFinished,
+			Args:       []interface{}{runningOperation},
+		})
+
+		if err != nil {
+			channels.ReportError(err)
+		} else {
+			channels.ReportStatus("%v", status)
+		}
+	}()
+}
+
 // ReportStatus updates the status bar with the provided status
 func (channels *Channels) ReportStatus(format string, args ...interface{}) {
 	status := fmt.Sprintf(format, args...)
@@ -161,11 +217,11 @@ func NewGRV() *GRV {
 	channels := grvChannels.Channels()
 
 	repoDataLoader := NewRepoDataLoader(channels)
-	repoData := NewRepositoryData(repoDataLoader, channels)
 	keyBindings := NewKeyBindingManager()
 	config := NewConfiguration(keyBindings, channels)
+	repoData := NewRepositoryData(repoDataLoader, channels, config)
 	ui := NewNCursesDisplay(config)
-	view := NewView(repoData, channels, config)
+	view := NewView(repoData, channels, config, keyBindings)
 
 	return &GRV{
 		repoData:       repoData,
@@ -176,6 +232,8 @@ func NewGRV() *GRV {
 		inputBuffer:    NewInputBuffer(keyBindings),
 		input:          NewInputKeyMapper(ui),
 		eventListeners: []EventListener{view, repoData},
+		keyBindings:    keyBindings,
+		repoChangedCh:  make(chan bool),
 	}
 }
 
@@ -211,11 +269,77 @@ func (grv *GRV) Initialise(repoPath, workTreePath string) (err error) {
 func (grv *GRV) Free() {
 	log.Info("Freeing GRV")
 
+	grv.lock.Lock()
+	defer grv.lock.Unlock()
+
+	grv.view.Dispose()
 	FreeReadLine()
 	grv.ui.Free()
 	grv.repoData.Free()
 }
 
+// OpenRepository replaces the displayed repository with the repository at the provided path.
+// The new repository is loaded before the current one is freed, so if the path is not
+// a git repository an error is returned and the current repository remains displayed
+func (grv *GRV) OpenRepository(repoPath string) (err error) {
+	log.Infof("Opening repository %v", repoPath)
+
+	if strings.HasPrefix(repoPath, "~/") {
+		if home, homeSet := os.LookupEnv("HOME"); homeSet {
+			repoPath = filepath.Join(home, repoPath[2:])
+		}
+	}
+
+	channels := grv.channels.Channels()
+	repoData := NewRepositoryData(NewRepoDataLoader(channels), channels, grv.config)
+
+	if err = repoData.Initialise(repoPath, ""); err != nil {
+		return fmt.Errorf("Unable to open repository %v: %v", repoPath, err)
+	}
+
+	view := NewView(repoData, channels, grv.config, grv.keyBindings)
+	if err = view.Initialise(); err != nil {
+		view.Dispose()
+		repoData.Free()
+		return fmt.Errorf("Unable to open repository %v: %v", repoPath, err)
+	}
+
+	grv.lock.Lock()
+	prevView, prevRepoData := grv.view, grv.repoData
+	grv.view = view
+	grv.repoData = repoData
+	grv.eventListeners = []EventListener{view, repoData}
+	grv.lock.Unlock()
+
+	// Wait for the filesystem monitor to stop using the previous repository before freeing it
+	select {
+	case grv.repoChangedCh <- true:
+	case <-grv.channels.exitCh:
+	}
+
+	prevView.Dispose()
+	prevRepoData.Free()
+
+	channels.ReportStatus("Opened repository %v", repoData.Path())
+	channels.UpdateDisplay()
+
+	return
+}
+
+func (grv *GRV) activeView() *View {
+	grv.lock.Lock()
+	defer grv.lock.Unlock()
+
+	return grv.view
+}
+
+func (grv *GRV) activeRepoData() *RepositoryData {
+	grv.lock.Lock()
+	defer grv.lock.Unlock()
+
+	return grv.repoData
+}
+
 // Suspend prepares GRV to be suspended and sends a SIGTSTP
 // to every process in the process group
 func (grv *GRV) Suspend() {
@@ -238,6 +362,100 @@ func (grv *GRV) Resume() {
 	grv.channels.displayCh <- true
 }
 
+// Resize reinitialises the UI with the current terminal dimensions and
+// schedules a redraw so that views are rendered using the new dimensions
+func (grv *GRV) Resize() {
+	log.Info("Resizing GRV")
+
+	if err := grv.ui.Resize(); err != nil {
+		log.Errorf("Unable to resize display: %v", err)
+	}
+
+	grv.channels.Channels().UpdateDisplay()
+}
+
+// RunCommand suspends the UI and runs the command specified in the
+// action attached to the terminal. The UI is restored once the command exits
+func (grv *GRV) RunCommand(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ActionRunCommandArgs argument")
+	}
+
+	runCommandArgs, ok := action.Args[0].(ActionRunCommandArgs)
+	if !ok {
+		return fmt.Errorf("Expected first argument to have type ActionRunCommandArgs but found %T", action.Args[0])
+	}
+
+	if runCommandArgs.cleanup != nil {
+		defer runCommandArgs.cleanup()
+	}
+
+	if runCommandArgs.onExit != nil {
+		defer runCommandArgs.onExit()
+	}
+
+	log.Infof("Running command: %v %v", runCommandArgs.command, strings.Join(runCommandArgs.args, " "))
+
+	cmd := exec.Command(runCommandArgs.command, runCommandArgs.args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), runCommandArgs.env...)
+
+	var output bytes.Buffer
+	if runCommandArgs.captureOutput {
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+	}
+
+	grv.ui.Suspend()
+	cmdErr := cmd.Run()
+
+	if cmdErr == nil && runCommandArgs.captureOutput {
+		cmdErr = grv.showCommandOutput(&output)
+	}
+
+	grv.Resume()
+
+	if cmdErr != nil {
+		log.Errorf("Command %v failed: %v", runCommandArgs.command, cmdErr)
+		err = fmt.Errorf("Command %v failed: %v", runCommandArgs.command, cmdErr)
+
+		if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); lines[len(lines)-1] != "" {
+			err = fmt.Errorf("%v: %v", err, lines[len(lines)-1])
+		}
+	}
+
+	return
+}
+
+// showCommandOutput displays the captured output of a command.
+// Output consisting of a single line is shown in the status bar, otherwise it is displayed using the pager
+func (grv *GRV) showCommandOutput(output *bytes.Buffer) (err error) {
+	trimmedOutput := strings.TrimSpace(output.String())
+
+	if !strings.Contains(trimmedOutput, "\n") {
+		if trimmedOutput == "" {
+			trimmedOutput = "Command completed"
+		}
+
+		grv.channels.Channels().ReportStatus("%v", trimmedOutput)
+		return
+	}
+
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = cvPager
+	}
+
+	cmd := exec.Command(scShell, "-c", pager)
+	cmd.Stdin = output
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
 // End signals GRV to stop
 func (grv *GRV) End() {
 	log.Info("Stopping GRV")
@@ -256,7 +474,7 @@ func (grv *GRV) Run() {
 	channels := grv.channels
 
 	waitGroup.Add(1)
-	go grv.runInputLoop(&waitGroup, channels.exitCh, channels.inputKeyCh, channels.errorCh)
+	go grv.runInputLoop(&waitGroup, channels.exitCh, channels.inputKeyCh, channels.actionCh, channels.errorCh)
 	waitGroup.Add(1)
 	go grv.runDisplayLoop(&waitGroup, channels.exitCh, channels.displayCh, channels.errorCh)
 	waitGroup.Add(1)
@@ -273,7 +491,7 @@ func (grv *GRV) Run() {
 	log.Info("All loops finished")
 }
 
-func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputKeyCh chan<- string, errorCh chan<- error) {
+func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputKeyCh chan<- string, actionCh chan<- Action, errorCh chan<- error) {
 	defer waitGroup.Done()
 	defer log.Info("Input loop stopping")
 	log.Info("Starting input loop")
@@ -282,6 +500,10 @@ func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputK
 		key, err := grv.input.GetKeyInput()
 		if err != nil {
 			errorCh <- err
+		} else if key == MouseKey {
+			grv.processMouseEvent(actionCh)
+		} else if key == ResizeKey {
+			grv.Resize()
 		} else if key != "" {
 			log.Debugf("Received keypress from UI %v", key)
 
@@ -302,6 +524,34 @@ func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputK
 	}
 }
 
+func (grv *GRV) processMouseEvent(actionCh chan<- Action) {
+	mouseEvent, exists := grv.ui.GetMouseEvent()
+	if !exists {
+		return
+	}
+
+	log.Debugf("Received mouse event from UI %v", mouseEvent)
+
+	var actionType ActionType
+
+	switch mouseEvent.mouseEventType {
+	case MetLeftClick:
+		actionType = ActionMouseSelect
+	case MetScrollUp:
+		actionType = ActionMouseScrollUp
+	case MetScrollDown:
+		actionType = ActionMouseScrollDown
+	default:
+		return
+	}
+
+	select {
+	case actionCh <- Action{ActionType: actionType, Args: []interface{}{mouseEvent}}:
+	default:
+		log.Errorf("Unable to add mouse event %v to action channel", mouseEvent)
+	}
+}
+
 func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, displayCh <-chan bool, errorCh chan error) {
 	defer waitGroup.Done()
 	defer log.Info("Display loop stopping")
@@ -311,33 +561,27 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 	lastErrorReceivedTime := time.Now()
 	channels := &Channels{errorCh: errorCh}
 
-	timer := time.NewTimer(time.Hour)
-	timer.Stop()
-	timerActive := false
+	frameLimiter := NewFrameLimiter(grvMaxDrawFrequency, displayCh)
 
 	for {
 		select {
 		case <-displayCh:
 			log.Debug("Received display refresh request")
-
-			if !timerActive {
-				timer.Reset(grvMaxDrawFrequency)
-				timerActive = true
-			}
-		case <-timer.C:
-			timerActive = false
+			frameLimiter.RequestFrame()
+		case <-frameLimiter.FrameCh():
+			frameLimiter.OnFrame()
 
 			if lastErrorReceivedTime.Before(time.Now().Add(-grvMinErrorDisplay)) {
 				errors = nil
 			} else if errors != nil {
-				grv.view.SetErrors(errors)
+				grv.activeView().SetErrors(errors)
 			}
 
 			log.Debug("Refreshing display - Display refresh request received since last check")
 
 			viewDimension := grv.ui.ViewDimension()
 
-			wins, err := grv.view.Render(viewDimension)
+			wins, err := grv.activeView().Render(viewDimension)
 			if err != nil {
 				channels.ReportError(err)
 				break
@@ -363,11 +607,7 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 			}
 
 			lastErrorReceivedTime = time.Now()
-
-			if !timerActive {
-				timer.Reset(grvMaxDrawFrequency)
-				timerActive = true
-			}
+			frameLimiter.RequestFrame()
 		case _, ok := <-exitCh:
 			if !ok {
 				return
@@ -384,6 +624,10 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 	for {
 		select {
 		case key := <-inputKeyCh:
+			if grv.answerConfirmPrompt(key) {
+				continue
+			}
+
 			grv.inputBuffer.Append(key)
 
 			for {
@@ -404,6 +648,22 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 				grv.End()
 			case ActionSuspend:
 				grv.Suspend()
+			case ActionRunCommand:
+				if err := grv.RunCommand(action); err != nil {
+					errorCh <- err
+				}
+			case ActionConfirmPrompt:
+				if err := grv.showConfirmPrompt(action); err != nil {
+					errorCh <- err
+				}
+			case ActionOpenRepository:
+				if len(action.Args) == 0 {
+					errorCh <- fmt.Errorf("Expected repository path argument")
+				} else if repoPath, ok := action.Args[0].(string); !ok {
+					errorCh <- fmt.Errorf("Expected repository path argument but found %T", action.Args[0])
+				} else if err := grv.OpenRepository(repoPath); err != nil {
+					errorCh <- err
+				}
 			default:
 				if err := grv.view.HandleAction(action); err != nil {
 					errorCh <- err
@@ -418,12 +678,70 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 			}
 		case _, ok := <-exitCh:
 			if !ok {
+				discardPendingActions(actionCh)
 				return
 			}
 		}
 	}
 }
 
+// discardPendingActions drops any actions which have not been handled.
+// Commands which will never be run are cleaned up
+func discardPendingActions(actionCh <-chan Action) {
+	for {
+		select {
+		case action := <-actionCh:
+			if action.ActionType != ActionRunCommand || len(action.Args) == 0 {
+				continue
+			}
+
+			if runCommandArgs, ok := action.Args[0].(ActionRunCommandArgs); ok && runCommandArgs.cleanup != nil {
+				runCommandArgs.cleanup()
+			}
+		default:
+			return
+		}
+	}
+}
+
+// showConfirmPrompt makes the confirm prompt provided in the action the
+// pending prompt. Any prompt that is still pending is declined
+func (grv *GRV) showConfirmPrompt(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected confirm prompt argument")
+	}
+
+	confirmPrompt, ok := action.Args[0].(*ConfirmPrompt)
+	if !ok {
+		return fmt.Errorf("Expected confirm prompt argument but found %T", action.Args[0])
+	}
+
+	if grv.confirmPrompt != nil {
+		grv.confirmPrompt.Answer(false)
+	}
+
+	grv.confirmPrompt = confirmPrompt
+
+	return grv.view.HandleAction(action)
+}
+
+// answerConfirmPrompt answers the pending confirm prompt, if any, with the provided key
+// Returns true if the key was consumed by the prompt
+func (grv *GRV) answerConfirmPrompt(key string) (consumed bool) {
+	if grv.confirmPrompt == nil {
+		return
+	}
+
+	confirmed, consumed := ConfirmPromptAnswer(key)
+	log.Debugf("Confirm prompt %q answered with %v", grv.confirmPrompt.Question(), confirmed)
+
+	grv.confirmPrompt.Answer(confirmed)
+	grv.confirmPrompt = nil
+	grv.channels.Channels().UpdateDisplay()
+
+	return
+}
+
 func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool) {
 	defer waitGroup.Done()
 	defer log.Info("Signal handler loop stopping")
@@ -445,11 +763,7 @@ func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bo
 			case syscall.SIGCONT:
 				grv.Resume()
 			case syscall.SIGWINCH:
-				if err := grv.ui.Resize(); err != nil {
-					log.Errorf("Unable to resize display: %v", err)
-				}
-
-				grv.channels.displayCh <- true
+				grv.Resize()
 			}
 		case _, ok := <-exitCh:
 			if !ok {
@@ -464,15 +778,29 @@ func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-cha
 	defer log.Info("FileSystem Monitor loop stopping")
 	log.Info("FileSystem loop starting")
 
+	for grv.monitorRepository(grv.activeRepoData(), exitCh) {
+		log.Info("Repository changed. Restarting filesystem monitoring")
+	}
+}
+
+// monitorRepository watches the provided repository for changes and reloads its status and refs.
+// Returns true if monitoring stopped because a different repository was opened
+func (grv *GRV) monitorRepository(repoData *RepositoryData, exitCh <-chan bool) (repoChanged bool) {
 	channels := grv.channels.Channels()
 	eventCh := make(chan fs.EventInfo, 1)
-	repoGitDir := grv.repoData.Path()
+	repoGitDir := repoData.Path()
 	repoFilePath := strings.TrimSuffix(repoGitDir, GitRepositoryDirectoryName+"/")
 	watchDir := repoFilePath + "..."
 
 	if err := fs.Watch(watchDir, eventCh, fs.All); err != nil {
 		log.Errorf("Unable to watch path for filesystem events %v: %v", watchDir, err)
-		return
+
+		select {
+		case <-grv.repoChangedCh:
+			return true
+		case <-exitCh:
+			return false
+		}
 	}
 
 	defer fs.Stop(eventCh)
@@ -516,14 +844,17 @@ func (grv *GRV) runFileSystemMonitorLoop(waitGroup *sync.WaitGroup, exitCh <-cha
 		case <-timer.C:
 			timerActive = false
 
-			if err := grv.repoData.LoadStatus(); err != nil {
+			if err := repoData.LoadStatus(); err != nil {
 				channels.ReportError(err)
 			}
 
 			if gitDirModified {
-				grv.repoData.LoadRefs(nil)
+				repoData.LoadRefs(nil)
 				gitDirModified = false
 			}
+		case <-grv.repoChangedCh:
+			timer.Stop()
+			return true
 		case _, ok := <-exitCh:
 			if !ok {
 				return
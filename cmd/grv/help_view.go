@@ -0,0 +1,441 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	hvKeySeparator = ", "
+	hvIndent       = "  "
+)
+
+var helpViewActionDescriptions = map[ActionType]string{
+	ActionExit:                    "Exit GRV",
+	ActionSuspend:                 "Suspend GRV",
+	ActionOpenRepositoryPrompt:    "Open another repository",
+	ActionPrompt:                  "Open command prompt",
+	ActionSearchPrompt:            "Search forwards",
+	ActionReverseSearchPrompt:     "Search backwards",
+	ActionFilterPrompt:            "Add filter",
+	ActionDateFilterPrompt:        "Add author date range filter",
+	ActionRefNameFilterPrompt:     "Filter refs by name",
+	ActionPathFilterPrompt:        "Show commits which modified a path",
+	ActionGotoCommitPrompt:        "Go to commit by id",
+	ActionCreateTagPrompt:         "Create tag at commit",
+	ActionCreateBranchPrompt:      "Create branch at commit",
+	ActionCheckoutRef:             "Checkout ref",
+	ActionToggleRefSortOrder:      "Toggle sorting refs by name/commit date",
+	ActionCopyRefName:             "Copy full ref name to clipboard",
+	ActionCopyRefShorthand:        "Copy short ref name to clipboard",
+	ActionCopyRefOid:              "Copy ref target commit id to clipboard",
+	ActionNextMergeCommit:         "Move to next merge commit",
+	ActionPrevMergeCommit:         "Move to previous merge commit",
+	ActionNextAuthorCommit:        "Move to next commit by the same author",
+	ActionPrevAuthorCommit:        "Move to previous commit by the same author",
+	ActionDismissLoadError:        "Dismiss commit loading error",
+	ActionBisectStart:             "Start bisect",
+	ActionBisectGood:              "Mark commit as good for bisect",
+	ActionBisectBad:               "Mark commit as bad for bisect",
+	ActionBisectReset:             "Reset bisect",
+	ActionInteractiveRebase:       "Interactive rebase from commit",
+	ActionAmendCommit:             "Amend HEAD commit message",
+	ActionPrevSelection:           "Return to previous selection",
+	ActionShowTree:                "Browse file tree of commit",
+	ActionTreeParentDirectory:     "Move to parent directory",
+	ActionOpenFileInEditor:        "Open file at revision in editor",
+	ActionShowFileHistory:         "Show commits which modified entry",
+	ActionToggleFollowRenames:     "Toggle following renames in blame",
+	ActionStashDrop:               "Drop stash",
+	ActionToggleStatsSortOrder:    "Toggle sorting authors by commit count or lines changed",
+	ActionShowAuthorStats:         "Show author statistics for the selected ref",
+	ActionSearchFindNext:          "Move to next search match",
+	ActionSearchFindPrev:          "Move to previous search match",
+	ActionClearSearch:             "Clear search",
+	ActionToggleFuzzySearch:       "Toggle fuzzy search and ref filtering",
+	ActionNextLine:                "Move down one line",
+	ActionPrevLine:                "Move up one line",
+	ActionNextPage:                "Move one page down",
+	ActionPrevPage:                "Move one page up",
+	ActionNextHalfPage:            "Move half page down",
+	ActionPrevHalfPage:            "Move half page up",
+	ActionScrollRight:             "Scroll right",
+	ActionScrollLeft:              "Scroll left",
+	ActionScrollToFirstColumn:     "Scroll to first column",
+	ActionFirstLine:               "Move to first line",
+	ActionLastLine:                "Move to last line",
+	ActionSelect:                  "Select item",
+	ActionNextView:                "Move to next view",
+	ActionPrevView:                "Move to previous view",
+	ActionFullScreenView:          "Toggle current view full screen",
+	ActionToggleViewLayout:        "Toggle views layout",
+	ActionRemoveFilter:            "Remove filter",
+	ActionCenterView:              "Center view",
+	ActionToggleCommitGraph:       "Toggle commit graph",
+	ActionToggleRelativeDate:      "Toggle relative commit dates",
+	ActionCycleDateFormat:         "Cycle the date format of the selected commit",
+	ActionCycleAllDateFormats:     "Cycle the date format of all commits",
+	ActionToggleCommitter:         "Toggle showing committer/author",
+	ActionToggleSearchMessageBody: "Toggle searching commit message bodies",
+	ActionToggleSummaryWrap:       "Toggle wrapping of selected commit summary",
+	ActionToggleCommitOrder:       "Toggle date/topological commit order",
+	ActionToggleFirstParent:       "Toggle following only first parents",
+	ActionToggleHideMerges:        "Toggle hiding merge commits",
+	ActionToggleCommitMinimap:     "Toggle the commit minimap",
+	ActionMarkCommit:              "Mark or unmark the selected commit for comparison",
+	ActionDiffAgainstMark:         "Diff the marked commit against the selected commit",
+	ActionClearCommitMark:         "Clear the commit mark",
+	ActionReloadCommits:           "Reload refs and commits",
+	ActionSelectHead:              "Select the commit HEAD points to",
+	ActionToggleWordDiff:          "Toggle highlighting of changed words",
+	ActionCopyPatch:               "Copy patch to clipboard",
+	ActionCopyHunk:                "Copy selected hunk to clipboard",
+	ActionIncreaseDiffContext:     "Show more context lines around changes",
+	ActionDecreaseDiffContext:     "Show fewer context lines around changes",
+	ActionToggleDiffLineNumbers:   "Toggle displaying line numbers alongside diff lines",
+	ActionOpenSubmodule:           "Open the repository of the selected submodule",
+	ActionCopyCommitID:            "Copy commit id to clipboard",
+	ActionCopyCommitSummary:       "Copy commit summary to clipboard",
+	ActionCopyCommitMessage:       "Copy full commit message to clipboard",
+	ActionShowCommitInPager:       "Show commit in pager",
+	ActionNextTab:                 "Move to next tab",
+	ActionPrevTab:                 "Move to previous tab",
+	ActionNewTab:                  "Add new tab",
+	ActionRemoveTab:               "Remove tab",
+	ActionRemoveView:              "Close view (or close tab if empty)",
+	ActionToggleHelp:              "Toggle key binding help",
+}
+
+type helpViewHandler func(*HelpView, Action) error
+
+type helpViewLine struct {
+	sectionTitle string
+	keys         string
+	description  string
+}
+
+// HelpView displays the key bindings available in each view
+type HelpView struct {
+	channels      *Channels
+	keyBindings   KeyBindings
+	lines         []helpViewLine
+	keyColWidth   uint
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]helpViewHandler
+	active        bool
+	lock          sync.Mutex
+}
+
+// NewHelpView creates a new instance of the help view
+func NewHelpView(channels *Channels, keyBindings KeyBindings) *HelpView {
+	return &HelpView{
+		channels:    channels,
+		keyBindings: keyBindings,
+		viewPos:     NewViewPosition(),
+		handlers: map[ActionType]helpViewHandler{
+			ActionPrevLine:     moveUpHelpLine,
+			ActionNextLine:     moveDownHelpLine,
+			ActionPrevPage:     moveUpHelpPage,
+			ActionNextPage:     moveDownHelpPage,
+			ActionPrevHalfPage: moveUpHelpHalfPage,
+			ActionNextHalfPage: moveDownHelpHalfPage,
+			ActionFirstLine:    moveToFirstHelpLine,
+			ActionLastLine:     moveToLastHelpLine,
+			ActionCenterView:   centerHelpView,
+		},
+	}
+}
+
+// Initialise does nothing
+func (helpView *HelpView) Initialise() (err error) {
+	log.Info("Initialising HelpView")
+	return
+}
+
+// Refresh regenerates the key binding help from the current key bindings
+// This ensures bindings added through configuration are displayed
+func (helpView *HelpView) Refresh() {
+	helpView.lock.Lock()
+	defer helpView.lock.Unlock()
+
+	var viewIDs []ViewID
+	viewNames := make(map[ViewID]string)
+
+	for viewName, viewID := range viewIDNames {
+		viewIDs = append(viewIDs, viewID)
+		viewNames[viewID] = viewName
+	}
+
+	sort.Slice(viewIDs, func(i, j int) bool {
+		return viewIDs[i] < viewIDs[j]
+	})
+
+	var lines []helpViewLine
+	keyColWidth := uint(0)
+
+	for _, viewID := range viewIDs {
+		actionKeystrings := helpView.keyBindings.ActionKeystrings(viewID)
+		if len(actionKeystrings) == 0 {
+			continue
+		}
+
+		var actionTypes []ActionType
+		for actionType := range actionKeystrings {
+			actionTypes = append(actionTypes, actionType)
+		}
+
+		sort.Slice(actionTypes, func(i, j int) bool {
+			return actionTypes[i] < actionTypes[j]
+		})
+
+		if len(lines) > 0 {
+			lines = append(lines, helpViewLine{})
+		}
+
+		lines = append(lines, helpViewLine{sectionTitle: viewNames[viewID]})
+
+		for _, actionType := range actionTypes {
+			keys := strings.Join(actionKeystrings[actionType], hvKeySeparator)
+			keyColWidth = MaxUint(keyColWidth, uint(len([]rune(keys))))
+
+			lines = append(lines, helpViewLine{
+				keys:        keys,
+				description: actionDescription(actionType),
+			})
+		}
+	}
+
+	helpView.lines = lines
+	helpView.keyColWidth = keyColWidth
+	helpView.viewPos = NewViewPosition()
+}
+
+func actionDescription(actionType ActionType) string {
+	if description, ok := helpViewActionDescriptions[actionType]; ok {
+		return description
+	}
+
+	for actionKey, keyActionType := range actionKeys {
+		if keyActionType == actionType {
+			return actionKey
+		}
+	}
+
+	return ""
+}
+
+// Render generates and writes the help view to the provided window
+func (helpView *HelpView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering HelpView")
+	helpView.lock.Lock()
+	defer helpView.lock.Unlock()
+
+	helpView.viewDimension = win.ViewDimensions()
+
+	rows := helpView.pageRows()
+	if rows == 0 {
+		return
+	}
+
+	lineNum := helpView.lineNumber()
+	viewPos := helpView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, 1); err != nil {
+			return
+		}
+
+		line := helpView.lines[lineIndex]
+		lineBuilder.Append(" ")
+
+		if line.sectionTitle != "" {
+			lineBuilder.AppendWithStyle(CmpHelpviewSectionTitle, "%v", line.sectionTitle)
+		} else if line.keys != "" {
+			lineBuilder.
+				AppendWithStyle(CmpHelpviewKey, "%v%-*v", hvIndent, int(helpView.keyColWidth), line.keys).
+				AppendWithStyle(CmpHelpviewDescription, "%v%v", hvIndent, line.description)
+		}
+
+		lineIndex++
+	}
+
+	if lineNum > 0 {
+		if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, helpView.active); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpHelpviewTitle, "Key Bindings"); err != nil {
+		return
+	}
+
+	if err = win.SetFooter(CmpHelpviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+		return
+	}
+
+	return
+}
+
+// RenderHelpBar shows key bindings custom to the help view
+func (helpView *HelpView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(helpView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionToggleHelp, message: "Close Help"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the help view is the active view or not
+func (helpView *HelpView) OnActiveChange(active bool) {
+	log.Debugf("HelpView active: %v", active)
+	helpView.lock.Lock()
+	defer helpView.lock.Unlock()
+
+	helpView.active = active
+}
+
+// ViewID returns the help views ID
+func (helpView *HelpView) ViewID() ViewID {
+	return ViewHelp
+}
+
+// HandleEvent does nothing
+func (helpView *HelpView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// HandleAction checks if the help view supports the provided action and executes it if so
+func (helpView *HelpView) HandleAction(action Action) (err error) {
+	log.Debugf("HelpView handling action %v", action)
+	helpView.lock.Lock()
+	defer helpView.lock.Unlock()
+
+	if handler, ok := helpView.handlers[action.ActionType]; ok {
+		err = handler(helpView, action)
+	}
+
+	return
+}
+
+func (helpView *HelpView) lineNumber() uint {
+	return uint(len(helpView.lines))
+}
+
+// pageRows returns the number of help lines visible in the view
+// excluding the rows used by the border
+func (helpView *HelpView) pageRows() uint {
+	if helpView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return helpView.viewDimension.rows - 2
+}
+
+func moveDownHelpLine(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MoveLinesDown(ActionCount(action), helpView.lineNumber()) {
+		log.Debugf("Moving down %v lines in help view", ActionCount(action))
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpHelpLine(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v lines in help view", ActionCount(action))
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownHelpPage(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MovePageDown(helpView.pageRows(), helpView.lineNumber()) {
+		log.Debugf("Moving down one page in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpHelpPage(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MovePageUp(helpView.pageRows()) {
+		log.Debugf("Moving up one page in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownHelpHalfPage(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MovePageDown(helpView.pageRows()/2, helpView.lineNumber()) {
+		log.Debugf("Moving down half a page in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpHelpHalfPage(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MovePageUp(helpView.pageRows() / 2) {
+		log.Debugf("Moving up half a page in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstHelpLine(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first line in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastHelpLine(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.MoveToLastLine(helpView.lineNumber()) {
+		log.Debugf("Moving to last line in help view")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerHelpView(helpView *HelpView, action Action) (err error) {
+	viewPos := helpView.viewPos
+
+	if viewPos.CenterActiveRow(helpView.pageRows()) {
+		log.Debug("Centering HelpView")
+		helpView.channels.UpdateDisplay()
+	}
+
+	return
+}
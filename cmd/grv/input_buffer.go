@@ -4,10 +4,16 @@ import (
 	"strings"
 )
 
+const (
+	ibMaxCount = 100000
+)
+
 // InputBuffer buffers input and maps it to configured actions or key sequences
+// A numeric count typed before a key sequence is passed to the action it maps to
 type InputBuffer struct {
 	buffer      []string
 	keyBindings KeyBindings
+	count       uint
 }
 
 // NewInputBuffer creates a new input buffer instance
@@ -52,6 +58,10 @@ func (inputBuffer *InputBuffer) Process(viewHierarchy ViewHierarchy) (action Act
 
 OuterLoop:
 	for inputBuffer.hasInput() {
+		if len(keyBuffer) == 0 && inputBuffer.processCount(viewHierarchy) {
+			continue
+		}
+
 		keyBuffer = append(keyBuffer, inputBuffer.pop())
 		binding, prefix := keyBindings.Binding(viewHierarchy, strings.Join(keyBuffer, ""))
 
@@ -65,7 +75,7 @@ OuterLoop:
 			isPrefix = true
 		case binding.bindingType == BtAction:
 			if binding.actionType != ActionNone {
-				action = Action{ActionType: binding.actionType}
+				action = binding.Action()
 			} else if isPrefix {
 				inputBuffer.prepend(keyBuffer[1:])
 				keyBuffer = keyBuffer[0:1]
@@ -81,5 +91,40 @@ OuterLoop:
 
 	keystring = strings.Join(keyBuffer, "")
 
+	if keystring != "" {
+		if inputBuffer.count > 0 && countableActions[action.ActionType] {
+			action.Args = []interface{}{inputBuffer.count}
+		}
+
+		inputBuffer.count = 0
+	}
+
 	return
 }
+
+// processCount consumes the next key if it forms part of a numeric count.
+// Digits only form a count if they are not bound to anything and a count
+// cannot start with 0. <Escape> discards a pending count
+func (inputBuffer *InputBuffer) processCount(viewHierarchy ViewHierarchy) bool {
+	key := inputBuffer.buffer[0]
+
+	if key == "<Escape>" && inputBuffer.count > 0 {
+		inputBuffer.pop()
+		inputBuffer.count = 0
+		return true
+	}
+
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' || (key[0] == '0' && inputBuffer.count == 0) {
+		return false
+	}
+
+	if binding, isPrefix := inputBuffer.keyBindings.Binding(viewHierarchy, key); isPrefix ||
+		binding.bindingType != BtAction || binding.actionType != ActionNone {
+		return false
+	}
+
+	inputBuffer.pop()
+	inputBuffer.count = MinUint(inputBuffer.count*10+uint(key[0]-'0'), ibMaxCount)
+
+	return true
+}
@@ -24,6 +24,15 @@ func (keyBindings *MockKeyBindings) SetKeystringBinding(viewID ViewID, keystring
 	keyBindings.Called(viewID, keystring, mappedKeystring)
 }
 
+func (keyBindings *MockKeyBindings) SetShellCommandBinding(viewID ViewID, keystring, shellCommand string) {
+	keyBindings.Called(viewID, keystring, shellCommand)
+}
+
+func (keyBindings *MockKeyBindings) ActionKeystrings(viewID ViewID) map[ActionType][]string {
+	args := keyBindings.Called(viewID)
+	return args.Get(0).(map[ActionType][]string)
+}
+
 func checkProcessResult(expectedAction Action, expectedKeystring string, actualAction Action, actualKeystring string, t *testing.T) {
 	if !reflect.DeepEqual(expectedAction, actualAction) {
 		t.Errorf("Returned action does not match expected value. Expected: %v, Actual: %v", expectedAction, actualAction)
@@ -115,3 +124,48 @@ func TestPotentialPrefixMatchIsReturnedAsSeparateKeysWhenFullInputDoesNotMatchBi
 	action, keyString = inputBuffer.Process(viewHierarchy)
 	checkProcessResult(Action{ActionType: ActionNone}, "b", action, keyString, t)
 }
+
+func TestNumericCountIsPassedToCountableAction(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "2").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+	keyBindings.On("Binding", viewHierarchy, "G").Return(newActionBinding(ActionLastLine), false)
+
+	inputBuffer.Append("1")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("2j")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine, Args: []interface{}{uint(12)}}, "j", action, keyString, t)
+
+	inputBuffer.Append("j")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+
+	inputBuffer.Append("2Gj")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionLastLine}, "G", action, keyString, t)
+
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+}
+
+func TestEscapeDiscardsNumericCount(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "5").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("5<Escape>j")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine}, "j", action, keyString, t)
+}
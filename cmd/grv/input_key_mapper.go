@@ -13,6 +13,10 @@ import (
 const (
 	ikmEscapeKey = 0x1B
 	ikmCtrlMask  = 0x1F
+	// MouseKey is the key string returned when a mouse event has occurred
+	MouseKey = "<Mouse>"
+	// ResizeKey is the key string returned when the terminal has been resized
+	ResizeKey = "<Resize>"
 )
 
 var keyMap = map[gc.Key]string{
@@ -115,8 +119,8 @@ var keyMap = map[gc.Key]string{
 	gc.KEY_SUNDO:     "<S-Undo>",
 	gc.KEY_SUSPEND:   "<Suspend>",
 	gc.KEY_UNDO:      "<Undo>",
-	gc.KEY_MOUSE:     "<Mouse>",
-	gc.KEY_RESIZE:    "<Resize>",
+	gc.KEY_MOUSE:     MouseKey,
+	gc.KEY_RESIZE:    ResizeKey,
 	gc.KEY_MAX:       "<Max>",
 }
 
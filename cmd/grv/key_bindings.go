@@ -1,9 +1,16 @@
 package main
 
 import (
+	"sort"
+	"strings"
+
 	pt "github.com/tchap/go-patricia/patricia"
 )
 
+const (
+	kbActionKeyPrefix = "<grv-"
+)
+
 // ActionType represents an action to be performed
 type ActionType int
 
@@ -16,12 +23,23 @@ const (
 	ActionSearchPrompt
 	ActionReverseSearchPrompt
 	ActionFilterPrompt
+	ActionDateFilterPrompt
+	ActionRefNameFilterPrompt
+	ActionPathFilterPrompt
+	ActionGotoCommitPrompt
+	ActionCreateTagPrompt
+	ActionCreateBranchPrompt
+	ActionOpenRepositoryPrompt
 	ActionSearch
 	ActionReverseSearch
 	ActionSearchFindNext
 	ActionSearchFindPrev
 	ActionClearSearch
+	ActionToggleFuzzySearch
 	ActionShowStatus
+	ActionConfirmPrompt
+	ActionOperationStarted
+	ActionOperationFinished
 	ActionNextLine
 	ActionPrevLine
 	ActionNextPage
@@ -30,6 +48,7 @@ const (
 	ActionPrevHalfPage
 	ActionScrollRight
 	ActionScrollLeft
+	ActionScrollToFirstColumn
 	ActionFirstLine
 	ActionLastLine
 	ActionSelect
@@ -38,8 +57,68 @@ const (
 	ActionFullScreenView
 	ActionToggleViewLayout
 	ActionAddFilter
+	ActionAddDateFilter
+	ActionAddRefNameFilter
+	ActionUpdateRefNameFilter
+	ActionAddPathFilter
 	ActionRemoveFilter
+	ActionGotoCommit
+	ActionCreateTag
+	ActionCreateBranch
+	ActionOpenRepository
+	ActionCheckoutRef
+	ActionToggleRefSortOrder
+	ActionCopyRefName
+	ActionCopyRefShorthand
+	ActionCopyRefOid
+	ActionNextMergeCommit
+	ActionPrevMergeCommit
+	ActionNextAuthorCommit
+	ActionPrevAuthorCommit
+	ActionDismissLoadError
+	ActionBisectStart
+	ActionBisectGood
+	ActionBisectBad
+	ActionBisectReset
+	ActionInteractiveRebase
+	ActionAmendCommit
+	ActionPrevSelection
+	ActionShowTree
+	ActionTreeParentDirectory
+	ActionOpenFileInEditor
+	ActionShowFileHistory
+	ActionToggleFollowRenames
+	ActionStashDrop
+	ActionToggleStatsSortOrder
+	ActionShowAuthorStats
 	ActionCenterView
+	ActionToggleCommitGraph
+	ActionToggleRelativeDate
+	ActionCycleDateFormat
+	ActionCycleAllDateFormats
+	ActionToggleCommitter
+	ActionToggleSearchMessageBody
+	ActionToggleSummaryWrap
+	ActionToggleCommitOrder
+	ActionToggleFirstParent
+	ActionToggleHideMerges
+	ActionToggleCommitMinimap
+	ActionMarkCommit
+	ActionDiffAgainstMark
+	ActionClearCommitMark
+	ActionReloadCommits
+	ActionSelectHead
+	ActionToggleWordDiff
+	ActionCopyPatch
+	ActionCopyHunk
+	ActionIncreaseDiffContext
+	ActionDecreaseDiffContext
+	ActionToggleDiffLineNumbers
+	ActionOpenSubmodule
+	ActionCopyCommitID
+	ActionCopyCommitSummary
+	ActionCopyCommitMessage
+	ActionShowCommitInPager
 	ActionNextTab
 	ActionPrevTab
 	ActionNewTab
@@ -47,6 +126,12 @@ const (
 	ActionAddView
 	ActionSplitView
 	ActionRemoveView
+	ActionToggleHelp
+	ActionMouseSelect
+	ActionMouseScrollUp
+	ActionMouseScrollDown
+	ActionRunCommand
+	ActionRunShellCommand
 )
 
 // Action represents a type of actions and its arguments to be executed
@@ -73,45 +158,150 @@ type ActionSplitViewArgs struct {
 	orientation ContainerOrientation
 }
 
+// ActionRunCommandArgs contains arguments the ActionRunCommand action requires
+// If captureOutput is set the output of the command is displayed once it has exited
+// If onExit is set it is called once the command has exited
+// If cleanup is set it is called once the command has exited or if the command is never run
+type ActionRunCommandArgs struct {
+	command       string
+	args          []string
+	env           []string
+	captureOutput bool
+	onExit        func()
+	cleanup       func()
+}
+
+// countableActions can be preceded by a numeric count to repeat them
+var countableActions = map[ActionType]bool{
+	ActionNextLine: true,
+	ActionPrevLine: true,
+}
+
+// ActionCount returns the number of times the action should be repeated
+// This is the numeric count typed before the action or 1 if no count was typed
+func ActionCount(action Action) uint {
+	if len(action.Args) > 0 {
+		if count, ok := action.Args[0].(uint); ok && count > 0 {
+			return count
+		}
+	}
+
+	return 1
+}
+
 var actionKeys = map[string]ActionType{
-	"<grv-nop>":                   ActionNone,
-	"<grv-exit>":                  ActionExit,
-	"<grv-suspend>":               ActionSuspend,
-	"<grv-prompt>":                ActionPrompt,
-	"<grv-search-prompt>":         ActionSearchPrompt,
-	"<grv-reverse-search-prompt>": ActionReverseSearchPrompt,
-	"<grv-filter-prompt>":         ActionFilterPrompt,
-	"<grv-search>":                ActionSearch,
-	"<grv-reverse-search>":        ActionReverseSearch,
-	"<grv-search-find-next>":      ActionSearchFindNext,
-	"<grv-search-find-prev>":      ActionSearchFindPrev,
-	"<grv-clear-search>":          ActionClearSearch,
-	"<grv-show-status>":           ActionShowStatus,
-	"<grv-next-line>":             ActionNextLine,
-	"<grv-prev-line>":             ActionPrevLine,
-	"<grv-next-page>":             ActionNextPage,
-	"<grv-prev-page>":             ActionPrevPage,
-	"<grv-next-half-page>":        ActionNextHalfPage,
-	"<grv-prev-half-page>":        ActionPrevHalfPage,
-	"<grv-scroll-right>":          ActionScrollRight,
-	"<grv-scroll-left>":           ActionScrollLeft,
-	"<grv-first-line>":            ActionFirstLine,
-	"<grv-last-line>":             ActionLastLine,
-	"<grv-select>":                ActionSelect,
-	"<grv-next-view>":             ActionNextView,
-	"<grv-prev-view>":             ActionPrevView,
-	"<grv-full-screen-view>":      ActionFullScreenView,
-	"<grv-toggle-view-layout>":    ActionToggleViewLayout,
-	"<grv-add-filter>":            ActionAddFilter,
-	"<grv-remove-filter>":         ActionRemoveFilter,
-	"<grv-center-view>":           ActionCenterView,
-	"<grv-next-tab>":              ActionNextTab,
-	"<grv-prev-tab>":              ActionPrevTab,
-	"<grv-add-tab>":               ActionNewTab,
-	"<grv-remove-tab>":            ActionRemoveTab,
-	"<grv-add-view>":              ActionAddView,
-	"<grv-split-view>":            ActionSplitView,
-	"<grv-remove-view>":           ActionRemoveView,
+	"<grv-nop>":                        ActionNone,
+	"<grv-exit>":                       ActionExit,
+	"<grv-suspend>":                    ActionSuspend,
+	"<grv-prompt>":                     ActionPrompt,
+	"<grv-search-prompt>":              ActionSearchPrompt,
+	"<grv-reverse-search-prompt>":      ActionReverseSearchPrompt,
+	"<grv-filter-prompt>":              ActionFilterPrompt,
+	"<grv-date-filter-prompt>":         ActionDateFilterPrompt,
+	"<grv-ref-name-filter-prompt>":     ActionRefNameFilterPrompt,
+	"<grv-path-filter-prompt>":         ActionPathFilterPrompt,
+	"<grv-goto-commit-prompt>":         ActionGotoCommitPrompt,
+	"<grv-create-tag-prompt>":          ActionCreateTagPrompt,
+	"<grv-create-branch-prompt>":       ActionCreateBranchPrompt,
+	"<grv-open-repository-prompt>":     ActionOpenRepositoryPrompt,
+	"<grv-search>":                     ActionSearch,
+	"<grv-reverse-search>":             ActionReverseSearch,
+	"<grv-search-find-next>":           ActionSearchFindNext,
+	"<grv-search-find-prev>":           ActionSearchFindPrev,
+	"<grv-clear-search>":               ActionClearSearch,
+	"<grv-toggle-fuzzy-search>":        ActionToggleFuzzySearch,
+	"<grv-show-status>":                ActionShowStatus,
+	"<grv-next-line>":                  ActionNextLine,
+	"<grv-prev-line>":                  ActionPrevLine,
+	"<grv-next-page>":                  ActionNextPage,
+	"<grv-prev-page>":                  ActionPrevPage,
+	"<grv-next-half-page>":             ActionNextHalfPage,
+	"<grv-prev-half-page>":             ActionPrevHalfPage,
+	"<grv-scroll-right>":               ActionScrollRight,
+	"<grv-scroll-left>":                ActionScrollLeft,
+	"<grv-scroll-to-first-column>":     ActionScrollToFirstColumn,
+	"<grv-first-line>":                 ActionFirstLine,
+	"<grv-last-line>":                  ActionLastLine,
+	"<grv-select>":                     ActionSelect,
+	"<grv-next-view>":                  ActionNextView,
+	"<grv-prev-view>":                  ActionPrevView,
+	"<grv-full-screen-view>":           ActionFullScreenView,
+	"<grv-toggle-view-layout>":         ActionToggleViewLayout,
+	"<grv-add-filter>":                 ActionAddFilter,
+	"<grv-add-date-filter>":            ActionAddDateFilter,
+	"<grv-add-ref-name-filter>":        ActionAddRefNameFilter,
+	"<grv-update-ref-name-filter>":     ActionUpdateRefNameFilter,
+	"<grv-add-path-filter>":            ActionAddPathFilter,
+	"<grv-remove-filter>":              ActionRemoveFilter,
+	"<grv-goto-commit>":                ActionGotoCommit,
+	"<grv-create-tag>":                 ActionCreateTag,
+	"<grv-create-branch>":              ActionCreateBranch,
+	"<grv-open-repository>":            ActionOpenRepository,
+	"<grv-checkout-ref>":               ActionCheckoutRef,
+	"<grv-toggle-ref-sort-order>":      ActionToggleRefSortOrder,
+	"<grv-copy-ref-name>":              ActionCopyRefName,
+	"<grv-copy-ref-shorthand>":         ActionCopyRefShorthand,
+	"<grv-copy-ref-oid>":               ActionCopyRefOid,
+	"<grv-next-merge-commit>":          ActionNextMergeCommit,
+	"<grv-prev-merge-commit>":          ActionPrevMergeCommit,
+	"<grv-next-author-commit>":         ActionNextAuthorCommit,
+	"<grv-prev-author-commit>":         ActionPrevAuthorCommit,
+	"<grv-dismiss-load-error>":         ActionDismissLoadError,
+	"<grv-bisect-start>":               ActionBisectStart,
+	"<grv-bisect-good>":                ActionBisectGood,
+	"<grv-bisect-bad>":                 ActionBisectBad,
+	"<grv-bisect-reset>":               ActionBisectReset,
+	"<grv-interactive-rebase>":         ActionInteractiveRebase,
+	"<grv-amend-commit>":               ActionAmendCommit,
+	"<grv-prev-selection>":             ActionPrevSelection,
+	"<grv-show-tree>":                  ActionShowTree,
+	"<grv-tree-parent-directory>":      ActionTreeParentDirectory,
+	"<grv-open-file-in-editor>":        ActionOpenFileInEditor,
+	"<grv-show-file-history>":          ActionShowFileHistory,
+	"<grv-toggle-follow-renames>":      ActionToggleFollowRenames,
+	"<grv-stash-drop>":                 ActionStashDrop,
+	"<grv-toggle-stats-sort-order>":    ActionToggleStatsSortOrder,
+	"<grv-show-author-stats>":          ActionShowAuthorStats,
+	"<grv-toggle-commit-graph>":        ActionToggleCommitGraph,
+	"<grv-toggle-relative-date>":       ActionToggleRelativeDate,
+	"<grv-cycle-date-format>":          ActionCycleDateFormat,
+	"<grv-cycle-all-date-formats>":     ActionCycleAllDateFormats,
+	"<grv-toggle-committer>":           ActionToggleCommitter,
+	"<grv-toggle-search-message-body>": ActionToggleSearchMessageBody,
+	"<grv-toggle-summary-wrap>":        ActionToggleSummaryWrap,
+	"<grv-toggle-commit-order>":        ActionToggleCommitOrder,
+	"<grv-toggle-first-parent>":        ActionToggleFirstParent,
+	"<grv-toggle-hide-merges>":         ActionToggleHideMerges,
+	"<grv-toggle-commit-minimap>":      ActionToggleCommitMinimap,
+	"<grv-mark-commit>":                ActionMarkCommit,
+	"<grv-diff-against-mark>":          ActionDiffAgainstMark,
+	"<grv-clear-commit-mark>":          ActionClearCommitMark,
+	"<grv-reload-commits>":             ActionReloadCommits,
+	"<grv-select-head>":                ActionSelectHead,
+	"<grv-toggle-word-diff>":           ActionToggleWordDiff,
+	"<grv-copy-patch>":                 ActionCopyPatch,
+	"<grv-copy-hunk>":                  ActionCopyHunk,
+	"<grv-increase-diff-context>":      ActionIncreaseDiffContext,
+	"<grv-decrease-diff-context>":      ActionDecreaseDiffContext,
+	"<grv-toggle-diff-line-numbers>":   ActionToggleDiffLineNumbers,
+	"<grv-open-submodule>":             ActionOpenSubmodule,
+	"<grv-copy-commit-id>":             ActionCopyCommitID,
+	"<grv-copy-commit-summary>":        ActionCopyCommitSummary,
+	"<grv-copy-commit-message>":        ActionCopyCommitMessage,
+	"<grv-show-commit-in-pager>":       ActionShowCommitInPager,
+	"<grv-center-view>":                ActionCenterView,
+	"<grv-next-tab>":                   ActionNextTab,
+	"<grv-prev-tab>":                   ActionPrevTab,
+	"<grv-add-tab>":                    ActionNewTab,
+	"<grv-remove-tab>":                 ActionRemoveTab,
+	"<grv-add-view>":                   ActionAddView,
+	"<grv-split-view>":                 ActionSplitView,
+	"<grv-remove-view>":                ActionRemoveView,
+	"<grv-toggle-help>":                ActionToggleHelp,
+	"<grv-mouse-select>":               ActionMouseSelect,
+	"<grv-mouse-scroll-up>":            ActionMouseScrollUp,
+	"<grv-mouse-scroll-down>":          ActionMouseScrollDown,
+	"<grv-run-command>":                ActionRunCommand,
 }
 
 var defaultKeyBindings = map[ActionType]map[ViewID][]string{
@@ -119,7 +309,7 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 		ViewMain: {PromptText},
 	},
 	ActionSearchPrompt: {
-		ViewMain: {SearchPromptText},
+		ViewAll: {SearchPromptText},
 	},
 	ActionReverseSearchPrompt: {
 		ViewMain: {ReverseSearchPromptText},
@@ -127,12 +317,18 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionSuspend: {
 		ViewAll: {"<C-z>"},
 	},
+	ActionOpenRepositoryPrompt: {
+		ViewMain: {"<C-p>"},
+	},
 	ActionSearchFindNext: {
 		ViewAll: {"n"},
 	},
 	ActionSearchFindPrev: {
 		ViewAll: {"N"},
 	},
+	ActionToggleFuzzySearch: {
+		ViewAll: {"<C-x>"},
+	},
 	ActionNextLine: {
 		ViewAll: {"<Down>", "j"},
 	},
@@ -158,10 +354,10 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 		ViewAll: {"<Left>", "h"},
 	},
 	ActionFirstLine: {
-		ViewAll: {"gg"},
+		ViewAll: {"gg", "<Home>"},
 	},
 	ActionLastLine: {
-		ViewAll: {"G"},
+		ViewAll: {"G", "<End>"},
 	},
 	ActionNextView: {
 		ViewAll: {"<Tab>", "<C-w>w", "<C-w><C-w>"},
@@ -182,13 +378,191 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 		ViewCommit: {"<C-q>"},
 		ViewRef:    {"<C-q>"},
 	},
+	ActionDateFilterPrompt: {
+		ViewCommit: {"F"},
+	},
+	ActionRefNameFilterPrompt: {
+		ViewRef: {SearchPromptText},
+	},
+	ActionPathFilterPrompt: {
+		ViewCommit: {"L"},
+	},
+	ActionShowFileHistory: {
+		ViewTree: {"L"},
+	},
 	ActionRemoveFilter: {
 		ViewCommit: {"<C-r>"},
 		ViewRef:    {"<C-r>"},
 	},
+	ActionGotoCommitPrompt: {
+		ViewCommit: {"gc"},
+	},
+	ActionCreateTagPrompt: {
+		ViewCommit: {"t"},
+	},
+	ActionCreateBranchPrompt: {
+		ViewCommit: {"b"},
+	},
+	ActionCheckoutRef: {
+		ViewRef: {"c"},
+	},
+	ActionToggleRefSortOrder: {
+		ViewRef: {"s"},
+	},
+	ActionCopyRefName: {
+		ViewRef: {"y"},
+	},
+	ActionCopyRefShorthand: {
+		ViewRef: {"Y"},
+	},
+	ActionCopyRefOid: {
+		ViewRef: {"<C-y>"},
+	},
+	ActionNextMergeCommit: {
+		ViewCommit: {"]m"},
+	},
+	ActionPrevMergeCommit: {
+		ViewCommit: {"[m"},
+	},
+	ActionNextAuthorCommit: {
+		ViewCommit: {"]a"},
+	},
+	ActionPrevAuthorCommit: {
+		ViewCommit: {"[a"},
+	},
+	ActionDismissLoadError: {
+		ViewCommit: {"<Escape>"},
+	},
+	ActionBisectStart: {
+		ViewCommit: {"Bs"},
+	},
+	ActionBisectGood: {
+		ViewCommit: {"Bg"},
+	},
+	ActionBisectBad: {
+		ViewCommit: {"Bb"},
+	},
+	ActionBisectReset: {
+		ViewCommit: {"Br"},
+	},
+	ActionInteractiveRebase: {
+		ViewCommit: {"E"},
+	},
+	ActionAmendCommit: {
+		ViewCommit: {"M"},
+	},
+	ActionPrevSelection: {
+		ViewCommit: {"<C-o>"},
+	},
+	ActionShowTree: {
+		ViewCommit: {"T"},
+	},
+	ActionTreeParentDirectory: {
+		ViewTree: {"<Backspace>"},
+	},
+	ActionOpenFileInEditor: {
+		ViewTree:  {"e"},
+		ViewBlame: {"e"},
+	},
+	ActionToggleFollowRenames: {
+		ViewBlame: {"M"},
+	},
+	ActionStashDrop: {
+		ViewStash: {"x"},
+	},
+	ActionToggleStatsSortOrder: {
+		ViewStats: {"s"},
+	},
+	ActionShowAuthorStats: {
+		ViewCommit: {"U"},
+	},
 	ActionCenterView: {
 		ViewAll: {"zz"},
 	},
+	ActionToggleCommitGraph: {
+		ViewCommit: {"<C-g>"},
+	},
+	ActionToggleRelativeDate: {
+		ViewCommit: {"D"},
+	},
+	ActionCycleDateFormat: {
+		ViewCommit: {"gd"},
+	},
+	ActionCycleAllDateFormats: {
+		ViewCommit: {"gD"},
+	},
+	ActionToggleCommitter: {
+		ViewCommit: {"A"},
+	},
+	ActionToggleSearchMessageBody: {
+		ViewCommit: {"S"},
+	},
+	ActionToggleSummaryWrap: {
+		ViewCommit: {"W"},
+	},
+	ActionToggleCommitOrder: {
+		ViewCommit: {"o"},
+	},
+	ActionToggleFirstParent: {
+		ViewCommit: {"P"},
+	},
+	ActionToggleHideMerges: {
+		ViewCommit: {"X"},
+	},
+	ActionToggleCommitMinimap: {
+		ViewCommit: {"I"},
+	},
+	ActionMarkCommit: {
+		ViewCommit: {"m"},
+	},
+	ActionDiffAgainstMark: {
+		ViewCommit: {"d"},
+	},
+	ActionClearCommitMark: {
+		ViewCommit: {"C"},
+	},
+	ActionReloadCommits: {
+		ViewCommit: {"R"},
+	},
+	ActionSelectHead: {
+		ViewCommit: {"H"},
+	},
+	ActionToggleWordDiff: {
+		ViewDiff: {"W"},
+	},
+	ActionScrollToFirstColumn: {
+		ViewDiff: {"0"},
+	},
+	ActionCopyPatch: {
+		ViewDiff: {"y"},
+	},
+	ActionCopyHunk: {
+		ViewDiff: {"Y"},
+	},
+	ActionIncreaseDiffContext: {
+		ViewDiff: {"+"},
+	},
+	ActionDecreaseDiffContext: {
+		ViewDiff: {"-"},
+	},
+	ActionToggleDiffLineNumbers: {
+		ViewDiff: {"L"},
+	},
+	ActionOpenSubmodule: {
+		ViewDiff: {"o"},
+	},
+	ActionCopyCommitID: {
+		ViewCommit: {"y"},
+	},
+	ActionCopyCommitSummary: {
+		ViewCommit: {"Y"},
+	},
+	ActionCopyCommitMessage: {
+		ViewCommit: {"<C-y>"},
+	},
+	ActionShowCommitInPager: {
+		ViewCommit: {"p"},
+	},
 	ActionNextTab: {
 		ViewAll: {"gt"},
 	},
@@ -198,6 +572,10 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionRemoveView: {
 		ViewAll: {"q"},
 	},
+	ActionToggleHelp: {
+		ViewAll:  {"<F1>"},
+		ViewHelp: {"<F1>", "<Escape>"},
+	},
 }
 
 // ViewHierarchy is a list of views parent to child
@@ -213,11 +591,13 @@ const (
 )
 
 // Binding is the entity a key sequence is bound to
-// This is either an action or a key sequence
+// This is either an action or a key sequence.
+// Shell command bindings are action bindings which store the command to run
 type Binding struct {
 	bindingType BindingType
 	actionType  ActionType
 	keystring   string
+	command     string
 }
 
 func newActionBinding(actionType ActionType) Binding {
@@ -227,6 +607,25 @@ func newActionBinding(actionType ActionType) Binding {
 	}
 }
 
+// Action returns the action for an action binding
+func (binding Binding) Action() Action {
+	action := Action{ActionType: binding.actionType}
+
+	if binding.command != "" {
+		action.Args = []interface{}{binding.command}
+	}
+
+	return action
+}
+
+func newShellCommandBinding(shellCommand string) Binding {
+	return Binding{
+		bindingType: BtAction,
+		actionType:  ActionRunShellCommand,
+		command:     shellCommand,
+	}
+}
+
 func newKeystringBinding(keystring string) Binding {
 	return Binding{
 		bindingType: BtKeystring,
@@ -240,6 +639,8 @@ type KeyBindings interface {
 	Binding(viewHierarchy ViewHierarchy, keystring string) (binding Binding, isPrefix bool)
 	SetActionBinding(viewID ViewID, keystring string, actionType ActionType)
 	SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string)
+	SetShellCommandBinding(viewID ViewID, keystring, shellCommand string)
+	ActionKeystrings(viewID ViewID) map[ActionType][]string
 }
 
 // KeyBindingManager manages key bindings in grv
@@ -289,6 +690,40 @@ func (keyBindingManager *KeyBindingManager) SetKeystringBinding(viewID ViewID, k
 	viewBindings.Set(pt.Prefix(keystring), newKeystringBinding(mappedKeystring))
 }
 
+// SetShellCommandBinding allows a shell command to be bound to the provided key sequence and view
+func (keyBindingManager *KeyBindingManager) SetShellCommandBinding(viewID ViewID, keystring, shellCommand string) {
+	viewBindings := keyBindingManager.getOrCreateViewBindings(viewID)
+	viewBindings.Set(pt.Prefix(keystring), newShellCommandBinding(shellCommand))
+}
+
+// ActionKeystrings returns the key sequences bound to each action for the provided view
+// Action keys (e.g. <grv-next-line>) are excluded
+func (keyBindingManager *KeyBindingManager) ActionKeystrings(viewID ViewID) map[ActionType][]string {
+	actionKeystrings := make(map[ActionType][]string)
+
+	viewBindings, ok := keyBindingManager.bindings[viewID]
+	if !ok {
+		return actionKeystrings
+	}
+
+	viewBindings.Visit(func(prefix pt.Prefix, item pt.Item) error {
+		binding, ok := item.(Binding)
+		keystring := string(prefix)
+
+		if ok && binding.bindingType == BtAction && binding.actionType != ActionNone && !isActionKey(keystring) {
+			actionKeystrings[binding.actionType] = append(actionKeystrings[binding.actionType], keystring)
+		}
+
+		return nil
+	})
+
+	for _, keystrings := range actionKeystrings {
+		sort.Strings(keystrings)
+	}
+
+	return actionKeystrings
+}
+
 func (keyBindingManager *KeyBindingManager) getOrCreateViewBindings(viewID ViewID) *pt.Trie {
 	viewBindings, ok := keyBindingManager.bindings[viewID]
 	if ok {
@@ -319,6 +754,10 @@ func isValidAction(action string) bool {
 	return valid
 }
 
+func isActionKey(keystring string) bool {
+	return strings.HasPrefix(keystring, kbActionKeyPrefix)
+}
+
 // DefaultKeyBindings returns the default key sequences that are bound to an action for the provided view
 func DefaultKeyBindings(actionType ActionType, viewID ViewID) (keyBindings []string) {
 	viewKeys, ok := defaultKeyBindings[actionType]
@@ -27,6 +27,28 @@ func TestDefaultKeyBindingsAreSetOnCreation(t *testing.T) {
 	checkBinding(binding, isPrefix, expectedBinding, false, t)
 }
 
+func TestVimNavigationKeysAreBoundByDefault(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	vimBindings := map[string]ActionType{
+		"j":  ActionNextLine,
+		"k":  ActionPrevLine,
+		"l":  ActionScrollRight,
+		"h":  ActionScrollLeft,
+		"gg": ActionFirstLine,
+		"G":  ActionLastLine,
+	}
+
+	for keystring, actionType := range vimBindings {
+		binding, isPrefix := keyBindings.Binding(viewHierarchy, keystring)
+		checkBinding(binding, isPrefix, newActionBinding(actionType), false, t)
+	}
+
+	binding, isPrefix := keyBindings.Binding(viewHierarchy, "g")
+	checkBinding(binding, isPrefix, newActionBinding(ActionNone), true, t)
+}
+
 func TestActionBindingCanBeSet(t *testing.T) {
 	keyBindings := NewKeyBindingManager()
 
@@ -78,6 +100,16 @@ func TestParentViewBindingHasPriorityOverChildBinding(t *testing.T) {
 	checkBinding(binding, isPrefix, expectedBinding, false, t)
 }
 
+func TestRefViewFiltersRefNamesInsteadOfSearching(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	binding, isPrefix := keyBindings.Binding(ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewRef}), SearchPromptText)
+	checkBinding(binding, isPrefix, newActionBinding(ActionRefNameFilterPrompt), false, t)
+
+	binding, isPrefix = keyBindings.Binding(ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit}), SearchPromptText)
+	checkBinding(binding, isPrefix, newActionBinding(ActionSearchPrompt), false, t)
+}
+
 func TestViewAllBindingIsAvailableInAllViews(t *testing.T) {
 	keyBindings := NewKeyBindingManager()
 
@@ -109,3 +141,45 @@ func TestDefaultKeyBindingsReturnsBindings(t *testing.T) {
 		}
 	}
 }
+
+func TestActionKeysAreRecognised(t *testing.T) {
+	if !isActionKey("<grv-next-line>") {
+		t.Errorf("Expected <grv-next-line> to be recognised as an action key")
+	}
+
+	if isActionKey("<Down>") {
+		t.Errorf("Expected <Down> not to be recognised as an action key")
+	}
+
+	if isValidAction("<grv-not-an-action>") {
+		t.Errorf("Expected <grv-not-an-action> not to be a valid action")
+	}
+}
+
+func TestActionKeystringsReturnsKeysBoundToActionsInView(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	keyBindings.SetActionBinding(ViewRef, "bbb", ActionFirstLine)
+	keyBindings.SetActionBinding(ViewRef, "aaa", ActionFirstLine)
+	keyBindings.SetKeystringBinding(ViewRef, "ccc", "ddd")
+
+	expectedActionKeystrings := map[ActionType][]string{
+		ActionFirstLine:    {"aaa", "bbb"},
+		ActionFilterPrompt: {"<C-q>"},
+		ActionRemoveFilter: {"<C-r>"},
+	}
+
+	if actionKeystrings := keyBindings.ActionKeystrings(ViewRef); !reflect.DeepEqual(expectedActionKeystrings, actionKeystrings) {
+		t.Errorf("ActionKeystrings result did not match expected result. Expected: %v, Actual: %v", expectedActionKeystrings, actionKeystrings)
+	}
+}
+
+func TestActionKeystringsExcludesActionKeys(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	expectedKeys := []string{"<Down>", "j"}
+
+	if actualKeys := keyBindings.ActionKeystrings(ViewAll)[ActionNextLine]; !reflect.DeepEqual(expectedKeys, actualKeys) {
+		t.Errorf("ActionKeystrings result did not match expected result. Expected: %v, Actual: %v", expectedKeys, actualKeys)
+	}
+}
@@ -61,6 +61,7 @@ type ReadLine struct {
 	promptPoint    int
 	active         bool
 	lastPromptText string
+	inputListener  func(input string)
 	lock           sync.Mutex
 }
 
@@ -137,6 +138,16 @@ func Prompt(prompt string) string {
 	return input
 }
 
+// PromptWithInputListener shows a readline prompt using the prompt text provided
+// The listener is called with the current input each time it changes
+// User input is returned
+func PromptWithInputListener(prompt string, inputListener func(input string)) string {
+	readLineSetInputListener(inputListener)
+	defer readLineSetInputListener(nil)
+
+	return Prompt(prompt)
+}
+
 // PromptState returns current prompt properties
 func PromptState() (string, string, int) {
 	readLine.lock.Lock()
@@ -160,6 +171,14 @@ func readLineSetActive(active bool) {
 	readLine.active = active
 }
 
+func readLineSetInputListener(inputListener func(input string)) {
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	readLine.inputListener = inputListener
+	readLine.promptInput = ""
+}
+
 func readLineSetupPromptHistory(prompt string) {
 	readLine.lock.Lock()
 	defer readLine.lock.Unlock()
@@ -201,12 +220,14 @@ func readLineAddPromptHistory(prompt string, cInput *C.char) {
 //export grvReadlineUpdateDisplay
 func grvReadlineUpdateDisplay() {
 	readLine.lock.Lock()
-	defer readLine.lock.Unlock()
 
 	displayPrompt := C.GoString(C.rl_display_prompt)
 	lineBuffer := C.GoString(C.rl_line_buffer)
 	point := int(C.rl_point)
 
+	inputListener := readLine.inputListener
+	inputChanged := lineBuffer != readLine.promptInput
+
 	readLine.promptText = displayPrompt
 	readLine.promptInput = lineBuffer
 	readLine.promptPoint = point
@@ -214,5 +235,11 @@ func grvReadlineUpdateDisplay() {
 	log.Debugf("ReadLine update display - prompt: %v%v, point: %v",
 		readLine.promptText, readLine.promptInput, readLine.promptPoint)
 
+	readLine.lock.Unlock()
+
+	if inputListener != nil && inputChanged {
+		inputListener(lineBuffer)
+	}
+
 	readLine.channels.UpdateDisplay()
 }
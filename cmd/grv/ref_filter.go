@@ -1,7 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"strings"
+
+	glob "github.com/gobwas/glob"
+)
+
+const (
+	rfGlobCharacters = "*?[{"
 )
 
 // CreateRefFilter creates a ref filter from the provided query
@@ -15,6 +22,45 @@ func CreateRefFilter(query string) (refFilter *RefFilter, errors []error) {
 	return
 }
 
+// CreateRefNameFilter creates a ref filter which matches refs by name.
+// If the pattern contains glob characters then ref names must match the glob,
+// otherwise ref names must contain the pattern
+func CreateRefNameFilter(pattern string) (refFilter *RefFilter, err error) {
+	var matches func(string) bool
+
+	if strings.ContainsAny(pattern, rfGlobCharacters) {
+		refGlob, globErr := glob.Compile(pattern)
+		if globErr != nil {
+			return nil, fmt.Errorf("Invalid ref name glob \"%v\": %v", pattern, globErr)
+		}
+
+		matches = refGlob.Match
+	} else {
+		matches = func(refName string) bool {
+			return strings.Contains(refName, pattern)
+		}
+	}
+
+	refFilter = NewRefFilter(func(inputValue interface{}) bool {
+		return matches(refName(inputValue.(*RenderedRef)))
+	})
+
+	return
+}
+
+// CreateFuzzyRefNameFilter creates a ref filter which matches refs
+// with names containing the characters of the pattern in order
+func CreateFuzzyRefNameFilter(pattern string) *RefFilter {
+	return NewRefFilter(func(inputValue interface{}) bool {
+		_, _, matched := FuzzyMatch(pattern, refName(inputValue.(*RenderedRef)))
+		return matched
+	})
+}
+
+func refName(renderedRef *RenderedRef) string {
+	return refFields["name"].value(renderedRef).(string)
+}
+
 // RefFilter is a wrapper around the raw filter to provide type safety
 type RefFilter struct {
 	filter Filter
@@ -179,3 +179,60 @@ func TestNilRefFilterIsReturnedIfQueryDoesNotDefineFilter(t *testing.T) {
 		t.Errorf("Expected returned filter to be nil but found: %[1]v of type %[1]T", refFilter)
 	}
 }
+
+func TestRefNameFilterMatchesGlobOrSubstring(t *testing.T) {
+	var refNameFilterTests = []struct {
+		pattern       string
+		refName       string
+		expectedMatch bool
+	}{
+		{pattern: "feature/*", refName: "feature/login", expectedMatch: true},
+		{pattern: "feature/*", refName: "bugfix/feature", expectedMatch: false},
+		{pattern: "rel?ase", refName: "release", expectedMatch: true},
+		{pattern: "fix", refName: "bugfix/crash", expectedMatch: true},
+		{pattern: "fix", refName: "master", expectedMatch: false},
+	}
+
+	for _, refNameFilterTest := range refNameFilterTests {
+		refFilter, err := CreateRefNameFilter(refNameFilterTest.pattern)
+		if err != nil {
+			t.Errorf("Unexpected error when creating ref name filter for pattern %v: %v", refNameFilterTest.pattern, err)
+			continue
+		}
+
+		renderedRef := &RenderedRef{
+			renderedRefType: RvLocalBranch,
+			value:           "   " + refNameFilterTest.refName,
+		}
+
+		if actualMatch := refFilter.MatchesFilter(renderedRef); actualMatch != refNameFilterTest.expectedMatch {
+			t.Errorf("Filter output does not match expected value for pattern %v and ref %v. Expected: %v, Actual: %v",
+				refNameFilterTest.pattern, refNameFilterTest.refName, refNameFilterTest.expectedMatch, actualMatch)
+		}
+	}
+}
+
+func TestFuzzyRefNameFilterMatchesCharactersInOrder(t *testing.T) {
+	refFilter := CreateFuzzyRefNameFilter("ftlg")
+
+	for refName, expectedMatch := range map[string]bool{
+		"feature/login": true,
+		"bugfix/crash":  false,
+	} {
+		renderedRef := &RenderedRef{
+			renderedRefType: RvLocalBranch,
+			value:           "   " + refName,
+		}
+
+		if actualMatch := refFilter.MatchesFilter(renderedRef); actualMatch != expectedMatch {
+			t.Errorf("Filter output does not match expected value for ref %v. Expected: %v, Actual: %v",
+				refName, expectedMatch, actualMatch)
+		}
+	}
+}
+
+func TestInvalidRefNameGlobReturnsError(t *testing.T) {
+	if _, err := CreateRefNameFilter("feature/[a"); err == nil {
+		t.Errorf("Expected error for invalid glob pattern")
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -26,6 +28,9 @@ const (
 	RvLoading
 )
 
+// refViewConfigVariables are the config variables the ref view listens for changes to
+var refViewConfigVariables = []ConfigVariable{CfRefSortOrder, CfDefaultRef}
+
 var refToTheme = map[RenderedRefType]ThemeComponentID{
 	RvLocalBranchGroup:  CmpRefviewLocalBranchesHeader,
 	RvRemoteBranchGroup: CmpRefviewRemoteBranchesHeader,
@@ -158,6 +163,9 @@ func (renderedRefList *renderedRefList) Children() (children uint) {
 type RefView struct {
 	channels      *Channels
 	repoData      RepoData
+	config        Config
+	sortByDate    bool
+	refsLoaded    bool
 	refLists      []*refList
 	refListeners  []RefListener
 	active        bool
@@ -166,7 +174,10 @@ type RefView struct {
 	viewDimension ViewDimension
 	handlers      map[ActionType]refViewHandler
 	viewSearch    *ViewSearch
-	lock          sync.Mutex
+	// refNameFilterUpdating is true while the last filter in the chain is
+	// the ref name filter being typed into the ref name filter prompt
+	refNameFilterUpdating bool
+	lock                  sync.Mutex
 }
 
 // RefListener is notified when a reference is selected
@@ -175,10 +186,12 @@ type RefListener interface {
 }
 
 // NewRefView creates a new instance
-func NewRefView(repoData RepoData, channels *Channels) *RefView {
+func NewRefView(repoData RepoData, channels *Channels, config Config) *RefView {
 	refView := &RefView{
 		channels:     channels,
 		repoData:     repoData,
+		config:       config,
+		sortByDate:   config.GetString(CfRefSortOrder) == cfRefSortOrderDate,
 		viewPos:      NewViewPosition(),
 		renderedRefs: newRenderedRefList(),
 		refLists: []*refList{
@@ -200,20 +213,27 @@ func NewRefView(repoData RepoData, channels *Channels) *RefView {
 			},
 		},
 		handlers: map[ActionType]refViewHandler{
-			ActionPrevLine:     moveUpRef,
-			ActionNextLine:     moveDownRef,
-			ActionPrevPage:     moveUpRefPage,
-			ActionNextPage:     moveDownRefPage,
-			ActionPrevHalfPage: moveUpRefHalfPage,
-			ActionNextHalfPage: moveDownRefHalfPage,
-			ActionScrollRight:  scrollRefViewRight,
-			ActionScrollLeft:   scrollRefViewLeft,
-			ActionFirstLine:    moveToFirstRef,
-			ActionLastLine:     moveToLastRef,
-			ActionSelect:       selectRef,
-			ActionAddFilter:    addRefFilter,
-			ActionRemoveFilter: removeRefFilter,
-			ActionCenterView:   centerRefView,
+			ActionPrevLine:            moveUpRef,
+			ActionNextLine:            moveDownRef,
+			ActionPrevPage:            moveUpRefPage,
+			ActionNextPage:            moveDownRefPage,
+			ActionPrevHalfPage:        moveUpRefHalfPage,
+			ActionNextHalfPage:        moveDownRefHalfPage,
+			ActionScrollRight:         scrollRefViewRight,
+			ActionScrollLeft:          scrollRefViewLeft,
+			ActionFirstLine:           moveToFirstRef,
+			ActionLastLine:            moveToLastRef,
+			ActionSelect:              selectRef,
+			ActionAddFilter:           addRefFilter,
+			ActionAddRefNameFilter:    addRefNameFilter,
+			ActionUpdateRefNameFilter: updateRefNameFilter,
+			ActionRemoveFilter:        removeRefFilter,
+			ActionCenterView:          centerRefView,
+			ActionCheckoutRef:         checkoutRef,
+			ActionToggleRefSortOrder:  toggleRefSortOrder,
+			ActionCopyRefName:         copyRefName,
+			ActionCopyRefShorthand:    copyRefShorthand,
+			ActionCopyRefOid:          copyRefOid,
 		},
 	}
 
@@ -222,10 +242,23 @@ func NewRefView(repoData RepoData, channels *Channels) *RefView {
 	return refView
 }
 
+// Dispose stops listening for config changes so the view no longer accesses the repository
+func (refView *RefView) Dispose() {
+	log.Debug("Disposing of RefView")
+
+	for _, configVariable := range refViewConfigVariables {
+		refView.config.RemoveOnChangeListener(configVariable, refView)
+	}
+}
+
 // Initialise loads the HEAD reference along with branches and tags
 func (refView *RefView) Initialise() (err error) {
 	log.Info("Initialising RefView")
 
+	for _, configVariable := range refViewConfigVariables {
+		refView.config.AddOnChangeListener(configVariable, refView)
+	}
+
 	if err = refView.repoData.LoadHead(); err != nil {
 		return
 	}
@@ -236,18 +269,12 @@ func (refView *RefView) Initialise() (err error) {
 		defer refView.lock.Unlock()
 
 		refView.generateRenderedRefs()
+		refView.refsLoaded = true
 
-		renderedRefs := refView.renderedRefs.RenderedRefs()
-		var activeRowIndex uint
-
-		for renderedRefIndex, renderedRef := range renderedRefs {
-			if renderedRef.renderedRefType == RvHead {
-				activeRowIndex = uint(renderedRefIndex)
-				break
-			}
+		if err = refView.selectDefaultRef(); err != nil {
+			return
 		}
 
-		refView.viewPos.SetActiveRowIndex(activeRowIndex)
 		refView.channels.UpdateDisplay()
 
 		refView.repoData.RegisterRefStateListener(refView)
@@ -263,8 +290,52 @@ func (refView *RefView) Initialise() (err error) {
 	return
 }
 
-func getDetachedHeadDisplayValue(oid *Oid) string {
-	return fmt.Sprintf("HEAD detached at %s", oid.String()[0:7])
+// defaultRef returns the ref configured to be displayed on startup.
+// HEAD is returned if the configured ref does not exist
+func (refView *RefView) defaultRef() Ref {
+	head := refView.repoData.Head()
+	refName := refView.config.GetString(CfDefaultRef)
+
+	if refName == "" || refName == cfDefaultRefHead {
+		return head
+	}
+
+	ref, err := refView.repoData.Ref(refName)
+	if err != nil {
+		log.Warnf("Unable to select default ref %v, falling back to HEAD: %v", refName, err)
+		return head
+	}
+
+	return ref
+}
+
+// selectDefaultRef moves the cursor to the default ref and notifies ref listeners
+// if the default ref is not HEAD, which is selected when the ref view is initialised
+func (refView *RefView) selectDefaultRef() (err error) {
+	ref := refView.defaultRef()
+	var activeRowIndex uint
+
+	for renderedRefIndex, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if renderedRef.ref != nil && renderedRef.ref.Name() == ref.Name() {
+			activeRowIndex = uint(renderedRefIndex)
+			break
+		} else if renderedRef.renderedRefType == RvHead {
+			activeRowIndex = uint(renderedRefIndex)
+		}
+	}
+
+	refView.viewPos.SetActiveRowIndex(activeRowIndex)
+
+	if head := refView.repoData.Head(); ref.Name() != head.Name() {
+		log.Debugf("Selecting default ref %v:%v", ref.Name(), ref.Oid())
+		err = refView.notifyRefListeners(ref)
+	}
+
+	return
+}
+
+func getDetachedHeadDisplayValue(head Ref) string {
+	return fmt.Sprintf("HEAD %v", DetachedHeadDescription(head))
 }
 
 func isSelectableRenderedRef(renderedRefType RenderedRefType) bool {
@@ -292,7 +363,9 @@ func (refView *RefView) notifyRefListeners(ref Ref) (err error) {
 		log.Debugf("Notifying RefListeners of selected ref %v", ref.Name())
 
 		for _, refListener := range refListeners {
-			if err = refListener.OnRefSelect(ref); err != nil {
+			if err := refListener.OnRefSelect(ref); err != nil {
+				log.Errorf("Error when notifying RefListener of selected ref %v: %v", ref.Name(), err)
+				refView.channels.ReportError(err)
 				break
 			}
 		}
@@ -414,7 +487,9 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 func (refView *RefView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	RenderKeyBindingHelp(refView.ViewID(), lineBuilder, []ActionMessage{
 		{action: ActionSelect, message: "Select"},
+		{action: ActionCheckoutRef, message: "Checkout"},
 		{action: ActionFilterPrompt, message: "Add Filter"},
+		{action: ActionRefNameFilterPrompt, message: "Filter Names"},
 		{action: ActionRemoveFilter, message: "Remove Filter"},
 	})
 
@@ -485,8 +560,13 @@ func (refView *RefView) generateRenderedRefs() {
 			expandChar = "-"
 		}
 
+		refListName := refList.name
+		if refNum, loaded := refView.refListSize(refList); loaded {
+			refListName = fmt.Sprintf("%v (%v)", refListName, refNum)
+		}
+
 		renderedRefs.Add(&RenderedRef{
-			value:           fmt.Sprintf("  [%v] %v", expandChar, refList.name),
+			value:           fmt.Sprintf("  [%v] %v", expandChar, refListName),
 			refList:         refList,
 			renderedRefType: refList.renderedRefType,
 		})
@@ -518,6 +598,22 @@ func (refView *RefView) generateRenderedRefs() {
 	}
 }
 
+func (refView *RefView) refListSize(refList *refList) (refNum uint, loaded bool) {
+	switch refList.renderedRefType {
+	case RvLocalBranchGroup:
+		localBranches, _, loading := refView.repoData.Branches()
+		refNum, loaded = uint(len(localBranches)), !loading
+	case RvRemoteBranchGroup:
+		_, remoteBranches, loading := refView.repoData.Branches()
+		refNum, loaded = uint(len(remoteBranches)), !loading
+	case RvTagGroup:
+		tags, loading := refView.repoData.Tags()
+		refNum, loaded = uint(len(tags)), !loading
+	}
+
+	return
+}
+
 func generateBranches(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
 	localBranches, remoteBranches, loading := refView.repoData.Branches()
 
@@ -541,7 +637,7 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 
 		if _, isDetached := head.(*HEAD); isDetached {
 			renderedRefs.Add(&RenderedRef{
-				value:           fmt.Sprintf("   %s", getDetachedHeadDisplayValue(head.Oid())),
+				value:           fmt.Sprintf("   %s", getDetachedHeadDisplayValue(head)),
 				renderedRefType: branchRenderedRefType,
 				refNum:          branchNum,
 				ref:             head,
@@ -554,6 +650,10 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 		branches = remoteBranches
 	}
 
+	if refView.sortByDate {
+		branches = refView.sortBranchesByDate(branches)
+	}
+
 	for _, branch := range branches {
 		renderedRefs.Add(&RenderedRef{
 			value:           fmt.Sprintf("   %s", branch.Shorthand()),
@@ -590,6 +690,10 @@ func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSe
 		return
 	}
 
+	if refView.sortByDate {
+		tags = refView.sortTagsByDate(tags)
+	}
+
 	for tagIndex, tag := range tags {
 		renderedRefs.Add(&RenderedRef{
 			value:           fmt.Sprintf("   %s", tag.Shorthand()),
@@ -600,6 +704,95 @@ func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSe
 	}
 }
 
+// sortBranchesByDate returns a copy of the branches ordered by the date
+// of the commit they point to, starting with the most recent
+func (refView *RefView) sortBranchesByDate(branches []Branch) []Branch {
+	sortedBranches := append([]Branch(nil), branches...)
+	commitDates := make(map[Branch]time.Time, len(branches))
+
+	for _, branch := range branches {
+		commitDates[branch] = refView.refCommitDate(branch)
+	}
+
+	sort.SliceStable(sortedBranches, func(i, j int) bool {
+		return commitDates[sortedBranches[i]].After(commitDates[sortedBranches[j]])
+	})
+
+	return sortedBranches
+}
+
+// sortTagsByDate returns a copy of the tags ordered by the date
+// of the commit they point to, starting with the most recent
+func (refView *RefView) sortTagsByDate(tags []*Tag) []*Tag {
+	sortedTags := append([]*Tag(nil), tags...)
+	commitDates := make(map[*Tag]time.Time, len(tags))
+
+	for _, tag := range tags {
+		commitDates[tag] = refView.refCommitDate(tag)
+	}
+
+	sort.SliceStable(sortedTags, func(i, j int) bool {
+		return commitDates[sortedTags[i]].After(commitDates[sortedTags[j]])
+	})
+
+	return sortedTags
+}
+
+// refCommitDate returns the committer date of the commit the ref points to.
+// Refs which don't point to a commit have a zero date so are sorted last
+func (refView *RefView) refCommitDate(ref Ref) (commitDate time.Time) {
+	commit, err := refView.repoData.Commit(ref.Oid())
+	if err != nil {
+		log.Debugf("Unable to load commit for ref %v: %v", ref.Name(), err)
+		return
+	}
+
+	return commit.commit.Committer().When
+}
+
+// resortRefs regenerates the displayed refs using the current sort order.
+// The selected ref remains selected if it is still displayed
+func (refView *RefView) resortRefs() {
+	var selectedRef Ref
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex < uint(len(renderedRefs)) {
+		selectedRef = renderedRefs[activeRowIndex].ref
+	}
+
+	refView.generateRenderedRefs()
+
+	if selectedRef != nil {
+		for renderedRefIndex, renderedRef := range refView.renderedRefs.RenderedRefs() {
+			if renderedRef.ref != nil && renderedRef.ref.Name() == selectedRef.Name() {
+				refView.viewPos.SetActiveRowIndex(uint(renderedRefIndex))
+				break
+			}
+		}
+	}
+
+	refView.channels.UpdateDisplay()
+}
+
+func (refView *RefView) onConfigVariableChange(configVariable ConfigVariable) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	switch configVariable {
+	case CfRefSortOrder:
+		refView.sortByDate = refView.config.GetString(CfRefSortOrder) == cfRefSortOrderDate
+		refView.resortRefs()
+	case CfDefaultRef:
+		if refView.refsLoaded {
+			if err := refView.selectDefaultRef(); err != nil {
+				refView.channels.ReportError(err)
+			}
+
+			refView.channels.UpdateDisplay()
+		}
+	}
+}
+
 func (refView *RefView) createRefListenerView(ref Ref) {
 	createViewArgs := CreateViewArgs{
 		viewID:   ViewCommit,
@@ -739,6 +932,16 @@ func (refView *RefView) HandleAction(action Action) (err error) {
 	return
 }
 
+// pageRows returns the number of refs visible in the view
+// excluding the rows used by the border
+func (refView *RefView) pageRows() uint {
+	if refView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return refView.viewDimension.rows - 2
+}
+
 func moveUpRef(refView *RefView, action Action) (err error) {
 	viewPos := refView.viewPos
 
@@ -746,24 +949,26 @@ func moveUpRef(refView *RefView, action Action) (err error) {
 		return
 	}
 
-	log.Debug("Moving up one ref")
+	log.Debugf("Moving up %v refs", ActionCount(action))
 
 	renderedRefs := refView.renderedRefs.RenderedRefs()
-	startIndex := viewPos.ActiveRowIndex()
-	activeRowIndex := startIndex - 1
+	activeRowIndex := viewPos.ActiveRowIndex()
+
+	for count := ActionCount(action); count > 0 && activeRowIndex > 0; count-- {
+		rowIndex := activeRowIndex - 1
 
-	for activeRowIndex > 0 {
-		renderedRef := renderedRefs[activeRowIndex]
+		for rowIndex > 0 && !isSelectableRenderedRef(renderedRefs[rowIndex].renderedRefType) {
+			rowIndex--
+		}
 
-		if isSelectableRenderedRef(renderedRef.renderedRefType) {
+		if !isSelectableRenderedRef(renderedRefs[rowIndex].renderedRefType) {
 			break
 		}
 
-		activeRowIndex--
+		activeRowIndex = rowIndex
 	}
 
-	renderedRef := renderedRefs[activeRowIndex]
-	if isSelectableRenderedRef(renderedRef.renderedRefType) {
+	if activeRowIndex != viewPos.ActiveRowIndex() {
 		viewPos.SetActiveRowIndex(activeRowIndex)
 		refView.channels.UpdateDisplay()
 	} else {
@@ -782,23 +987,25 @@ func moveDownRef(refView *RefView, action Action) (err error) {
 		return
 	}
 
-	log.Debug("Moving down one ref")
+	log.Debugf("Moving down %v refs", ActionCount(action))
 
-	startIndex := viewPos.ActiveRowIndex()
-	activeRowIndex := startIndex + 1
+	activeRowIndex := viewPos.ActiveRowIndex()
 
-	for activeRowIndex < renderedRefNum-1 {
-		renderedRef := renderedRefs[activeRowIndex]
+	for count := ActionCount(action); count > 0 && activeRowIndex < renderedRefNum-1; count-- {
+		rowIndex := activeRowIndex + 1
+
+		for rowIndex < renderedRefNum-1 && !isSelectableRenderedRef(renderedRefs[rowIndex].renderedRefType) {
+			rowIndex++
+		}
 
-		if isSelectableRenderedRef(renderedRef.renderedRefType) {
+		if !isSelectableRenderedRef(renderedRefs[rowIndex].renderedRefType) {
 			break
 		}
 
-		activeRowIndex++
+		activeRowIndex = rowIndex
 	}
 
-	renderedRef := renderedRefs[activeRowIndex]
-	if isSelectableRenderedRef(renderedRef.renderedRefType) {
+	if activeRowIndex != viewPos.ActiveRowIndex() {
 		viewPos.SetActiveRowIndex(activeRowIndex)
 		refView.channels.UpdateDisplay()
 	} else {
@@ -809,7 +1016,7 @@ func moveDownRef(refView *RefView, action Action) (err error) {
 }
 
 func moveUpRefPage(refView *RefView, action Action) (err error) {
-	pageSize := refView.viewDimension.rows - 2
+	pageSize := refView.pageRows()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex() > 0 && pageSize > 0 {
@@ -826,7 +1033,7 @@ func moveUpRefPage(refView *RefView, action Action) (err error) {
 func moveDownRefPage(refView *RefView, action Action) (err error) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
-	pageSize := refView.viewDimension.rows - 2
+	pageSize := refView.pageRows()
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex()+1 < renderedRefNum && pageSize > 0 {
@@ -841,7 +1048,7 @@ func moveDownRefPage(refView *RefView, action Action) (err error) {
 }
 
 func moveUpRefHalfPage(refView *RefView, action Action) (err error) {
-	halfPageSize := refView.viewDimension.rows/2 - 2
+	halfPageSize := refView.pageRows() / 2
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex() > 0 && halfPageSize > 0 {
@@ -858,7 +1065,7 @@ func moveUpRefHalfPage(refView *RefView, action Action) (err error) {
 func moveDownRefHalfPage(refView *RefView, action Action) (err error) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
-	halfPageSize := refView.viewDimension.rows/2 - 2
+	halfPageSize := refView.pageRows() / 2
 	viewPos := refView.viewPos
 
 	for viewPos.ActiveRowIndex()+1 < renderedRefNum && halfPageSize > 0 {
@@ -944,6 +1151,83 @@ func selectRef(refView *RefView, action Action) (err error) {
 	return
 }
 
+func checkoutRef(refView *RefView, action Action) (err error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	switch renderedRef.renderedRefType {
+	case RvLocalBranch, RvRemoteBranch, RvTag:
+	default:
+		return
+	}
+
+	ref := renderedRef.ref
+
+	refView.channels.RunOperation(fmt.Sprintf("Checking out %v", ref.Shorthand()), func() (status string, err error) {
+		if err = refView.repoData.Checkout(ref); err != nil {
+			return
+		}
+
+		return fmt.Sprintf("Checked out %v", ref.Shorthand()), nil
+	})
+
+	return
+}
+
+func copyRefName(refView *RefView, action Action) (err error) {
+	return refView.copyRefText("name", func(ref Ref) string {
+		return ref.Name()
+	})
+}
+
+func copyRefShorthand(refView *RefView, action Action) (err error) {
+	return refView.copyRefText("short name", func(ref Ref) string {
+		return ref.Shorthand()
+	})
+}
+
+func copyRefOid(refView *RefView, action Action) (err error) {
+	return refView.copyRefText("commit id", func(ref Ref) string {
+		if ref.Oid() == nil {
+			return ""
+		}
+
+		return ref.Oid().String()
+	})
+}
+
+// copyRefText copies the text returned by refText for the selected ref to the clipboard.
+// Nothing is copied if the selected row is not a ref
+func (refView *RefView) copyRefText(description string, refText func(Ref) string) (err error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	if refView.viewPos.ActiveRowIndex() >= uint(len(renderedRefs)) {
+		return
+	}
+
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	switch renderedRef.renderedRefType {
+	case RvLocalBranch, RvHead, RvRemoteBranch, RvTag:
+	default:
+		return
+	}
+
+	text := refText(renderedRef.ref)
+	if text == "" {
+		refView.channels.ReportStatus("Ref %v has no %v to copy", renderedRef.ref.Shorthand(), description)
+		return
+	}
+
+	if err = CopyToClipboard(text); err != nil {
+		return
+	}
+
+	log.Debugf("Copied ref %v %v to clipboard", description, text)
+	refView.channels.ReportStatus("Copied %v to clipboard", text)
+
+	return
+}
+
 func addRefFilter(refView *RefView, action Action) (err error) {
 	if !(len(action.Args) > 0) {
 		return fmt.Errorf("Expected filter query argument")
@@ -963,8 +1247,116 @@ func addRefFilter(refView *RefView, action Action) (err error) {
 		return
 	}
 
+	refView.applyRefFilter(refFilter)
+
+	return
+}
+
+func addRefNameFilter(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected ref name pattern argument")
+	}
+
+	pattern, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected ref name pattern argument to have type string")
+	}
+
+	refView.removeUpdatingRefNameFilter()
+
+	refFilter, err := refView.createRefNameFilter(pattern)
+	if err != nil {
+		return
+	}
+
+	refView.applyRefFilter(refFilter)
+
+	if refView.viewSearch.Fuzzy() {
+		refView.selectBestFuzzyRefMatch(pattern)
+	}
+
+	return
+}
+
+// updateRefNameFilter replaces the ref name filter being typed with one for the current input.
+// Patterns which are not yet valid globs leave the current filter in place and an empty
+// pattern removes it
+func updateRefNameFilter(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected ref name pattern argument")
+	}
+
+	pattern, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected ref name pattern argument to have type string")
+	}
+
+	if pattern == "" {
+		refView.removeUpdatingRefNameFilter()
+		refView.channels.UpdateDisplay()
+		return
+	}
+
+	refFilter, filterErr := refView.createRefNameFilter(pattern)
+	if filterErr != nil {
+		log.Debugf("Ignoring incomplete ref name pattern: %v", filterErr)
+		return
+	}
+
+	refView.removeUpdatingRefNameFilter()
+	refView.addRefFilterChild(refFilter)
+	refView.refNameFilterUpdating = true
+
+	if refView.viewSearch.Fuzzy() {
+		refView.selectBestFuzzyRefMatch(pattern)
+	}
+
+	refView.channels.UpdateDisplay()
+
+	return
+}
+
+func (refView *RefView) createRefNameFilter(pattern string) (*RefFilter, error) {
+	if refView.viewSearch.Fuzzy() {
+		return CreateFuzzyRefNameFilter(pattern), nil
+	}
+
+	return CreateRefNameFilter(pattern)
+}
+
+// removeUpdatingRefNameFilter removes the ref name filter being typed, if any
+func (refView *RefView) removeUpdatingRefNameFilter() {
+	if refView.refNameFilterUpdating {
+		refView.renderedRefs.RemoveChild()
+		refView.refNameFilterUpdating = false
+	}
+}
+
+// selectBestFuzzyRefMatch selects the displayed ref whose name best matches the pattern
+func (refView *RefView) selectBestFuzzyRefMatch(pattern string) {
+	bestScore, bestIndex := 0, -1
+
+	for renderedRefIndex, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if renderedRef.ref == nil {
+			continue
+		}
+
+		if score, _, matched := FuzzyMatch(pattern, refName(renderedRef)); matched && (bestIndex == -1 || score > bestScore) {
+			bestScore, bestIndex = score, renderedRefIndex
+		}
+	}
+
+	if bestIndex != -1 {
+		refView.viewPos.SetActiveRowIndex(uint(bestIndex))
+		refView.channels.UpdateDisplay()
+	}
+}
+
+// applyRefFilter adds the filter to the chain of filters applied to the displayed refs
+// and moves the selection to the last ref if it is no longer in bounds
+func (refView *RefView) applyRefFilter(refFilter *RefFilter) {
 	beforeRenderedRefNum := len(refView.renderedRefs.RenderedRefs())
-	refView.renderedRefs.AddChild(newFilteredRenderedRefList(refFilter))
+	refView.addRefFilterChild(refFilter)
 	afterRenderedRefNum := len(refView.renderedRefs.RenderedRefs())
 
 	if afterRenderedRefNum < beforeRenderedRefNum {
@@ -973,7 +1365,15 @@ func addRefFilter(refView *RefView, action Action) (err error) {
 		refView.channels.ReportStatus("Filter had no effect")
 	}
 
-	return
+	refView.channels.UpdateDisplay()
+}
+
+func (refView *RefView) addRefFilterChild(refFilter *RefFilter) {
+	refView.renderedRefs.AddChild(newFilteredRenderedRefList(refFilter))
+
+	if renderedRefNum := uint(len(refView.renderedRefs.RenderedRefs())); renderedRefNum > 0 && refView.viewPos.ActiveRowIndex() >= renderedRefNum {
+		refView.viewPos.SetActiveRowIndex(renderedRefNum - 1)
+	}
 }
 
 func removeRefFilter(refView *RefView, action Action) (err error) {
@@ -986,10 +1386,23 @@ func removeRefFilter(refView *RefView, action Action) (err error) {
 	return
 }
 
+func toggleRefSortOrder(refView *RefView, action Action) (err error) {
+	refView.sortByDate = !refView.sortByDate
+	refView.resortRefs()
+
+	if refView.sortByDate {
+		refView.channels.ReportStatus("Sorting refs by commit date")
+	} else {
+		refView.channels.ReportStatus("Sorting refs by name")
+	}
+
+	return
+}
+
 func centerRefView(refView *RefView, action Action) (err error) {
 	viewPos := refView.viewPos
 
-	if viewPos.CenterActiveRow(refView.viewDimension.rows - 2) {
+	if viewPos.CenterActiveRow(refView.pageRows()) {
 		log.Debug("Centering RefView")
 		refView.channels.UpdateDisplay()
 	}
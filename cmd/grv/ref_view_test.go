@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRefNameFilterIsReplacedAsItIsTyped(t *testing.T) {
+	channels := newTestChannels()
+	refView := NewRefView(&MockRepoData{}, channels, NewConfiguration(NewKeyBindingManager(), channels))
+
+	for _, refName := range []string{"feature/login", "feature/logout", "master"} {
+		refView.renderedRefs.Add(&RenderedRef{
+			renderedRefType: RvLocalBranch,
+			value:           "   " + refName,
+		})
+	}
+
+	refView.viewPos.SetActiveRowIndex(2)
+
+	var refNameFilterTests = []struct {
+		input                  string
+		expectedRenderedRefNum int
+	}{
+		{input: "feature", expectedRenderedRefNum: 2},
+		{input: "feature/logi", expectedRenderedRefNum: 1},
+		{input: "feature/[", expectedRenderedRefNum: 1},
+		{input: "", expectedRenderedRefNum: 3},
+		{input: "mas", expectedRenderedRefNum: 1},
+	}
+
+	for _, refNameFilterTest := range refNameFilterTests {
+		if err := refView.HandleAction(Action{ActionType: ActionUpdateRefNameFilter, Args: []interface{}{refNameFilterTest.input}}); err != nil {
+			t.Fatalf("Failed to update ref name filter with input %q: %v", refNameFilterTest.input, err)
+		}
+
+		renderedRefNum := len(refView.renderedRefs.RenderedRefs())
+		if renderedRefNum != refNameFilterTest.expectedRenderedRefNum {
+			t.Errorf("Expected %v refs for input %q but found %v", refNameFilterTest.expectedRenderedRefNum, refNameFilterTest.input, renderedRefNum)
+		}
+
+		if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex >= uint(renderedRefNum) {
+			t.Errorf("Expected active row index %v to be within the %v refs displayed for input %q", activeRowIndex, renderedRefNum, refNameFilterTest.input)
+		}
+	}
+
+	if err := refView.HandleAction(Action{ActionType: ActionAddRefNameFilter, Args: []interface{}{"mas"}}); err != nil {
+		t.Fatalf("Failed to add ref name filter: %v", err)
+	}
+
+	if children := refView.renderedRefs.Children(); children != 1 {
+		t.Errorf("Expected the completed ref name filter to replace the filter being typed but found %v filters", children)
+	}
+
+	if err := refView.HandleAction(Action{ActionType: ActionUpdateRefNameFilter, Args: []interface{}{""}}); err != nil {
+		t.Fatalf("Failed to update ref name filter: %v", err)
+	}
+
+	if children := refView.renderedRefs.Children(); children != 1 {
+		t.Errorf("Expected the completed ref name filter to remain applied but found %v filters", children)
+	}
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	rvColumnNum       = 4
+	rvDateFormat      = "2006-01-02 15:04"
+	rvUpdateBatchSize = 100
+)
+
+type reflogViewHandler func(*ReflogView, Action) error
+
+// ReflogView displays the entries of the HEAD reflog
+type ReflogView struct {
+	channels            *Channels
+	repoData            RepoData
+	reflogEntries       []*ReflogEntry
+	loading             bool
+	viewPos             ViewPos
+	viewDimension       ViewDimension
+	tableFormatter      *TableFormatter
+	handlers            map[ActionType]reflogViewHandler
+	active              bool
+	commitViewListeners []CommitViewListener
+	selectionCh         chan *ReflogEntry
+	cancelCh            chan bool
+	disposed            bool
+	viewSearch          *ViewSearch
+	lock                sync.Mutex
+}
+
+// NewReflogView creates a new instance of the reflog view
+func NewReflogView(repoData RepoData, channels *Channels) *ReflogView {
+	reflogView := &ReflogView{
+		repoData:       repoData,
+		channels:       channels,
+		viewPos:        NewViewPosition(),
+		tableFormatter: NewTableFormatter(rvColumnNum),
+		selectionCh:    make(chan *ReflogEntry, 1),
+		cancelCh:       make(chan bool),
+		handlers: map[ActionType]reflogViewHandler{
+			ActionSelect: selectReflogEntry,
+		},
+	}
+
+	reflogView.viewSearch = NewViewSearch(reflogView, channels)
+
+	return reflogView
+}
+
+// Initialise starts notifying listeners of selected reflog entries
+func (reflogView *ReflogView) Initialise() (err error) {
+	log.Info("Initialising ReflogView")
+	go reflogView.processSelections()
+	return
+}
+
+// Dispose stops notifying listeners of selected reflog entries
+func (reflogView *ReflogView) Dispose() {
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	if !reflogView.disposed {
+		log.Debug("Disposing of ReflogView")
+		reflogView.disposed = true
+		close(reflogView.cancelCh)
+	}
+}
+
+// LoadReflog starts loading the HEAD reflog
+// Entries are displayed as they are received so the UI remains responsive for large reflogs
+func (reflogView *ReflogView) LoadReflog() (err error) {
+	log.Debug("ReflogView loading reflog")
+
+	reflogEntryCh, err := reflogView.repoData.Reflog()
+	if err != nil {
+		return
+	}
+
+	reflogView.lock.Lock()
+	reflogView.reflogEntries = nil
+	reflogView.loading = true
+	reflogView.viewPos = NewViewPosition()
+	reflogView.lock.Unlock()
+
+	go reflogView.receiveReflogEntries(reflogEntryCh)
+
+	return
+}
+
+func (reflogView *ReflogView) receiveReflogEntries(reflogEntryCh <-chan *ReflogEntry) {
+	var reflogEntries []*ReflogEntry
+
+	addReflogEntries := func() {
+		reflogView.lock.Lock()
+		defer reflogView.lock.Unlock()
+
+		reflogView.reflogEntries = append(reflogView.reflogEntries, reflogEntries...)
+		reflogEntries = reflogEntries[:0]
+	}
+
+	for reflogEntry := range reflogEntryCh {
+		reflogEntries = append(reflogEntries, reflogEntry)
+
+		if len(reflogEntries) >= rvUpdateBatchSize {
+			addReflogEntries()
+			reflogView.channels.UpdateDisplay()
+		}
+	}
+
+	addReflogEntries()
+
+	reflogView.lock.Lock()
+	reflogView.loading = false
+	entryNum := len(reflogView.reflogEntries)
+	reflogView.lock.Unlock()
+
+	log.Debugf("ReflogView loaded %v entries", entryNum)
+	reflogView.channels.UpdateDisplay()
+}
+
+// Render generates and writes the reflog view to the provided window
+func (reflogView *ReflogView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering ReflogView")
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	reflogView.viewDimension = win.ViewDimensions()
+
+	lineNum := reflogView.lineNumber()
+
+	if lineNum == 0 {
+		return reflogView.renderEmptyView(win)
+	}
+
+	rows := reflogView.viewDimension.PageRows()
+	viewPos := reflogView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	tableFormatter := reflogView.tableFormatter
+	tableFormatter.Resize(rows)
+	tableFormatter.Clear()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		if err = reflogView.renderReflogEntry(tableFormatter, rowIndex, reflogView.reflogEntries[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, reflogView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpReflogviewTitle, "Reflog for %v", RdlHeadRef); err != nil {
+		return
+	}
+
+	footer := fmt.Sprintf("Entry %v of %v", viewPos.ActiveRowIndex()+1, lineNum)
+	if reflogView.loading {
+		footer += " (loading)"
+	}
+
+	if err = win.SetFooter(CmpReflogviewFooter, "%v", footer); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := reflogView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (reflogView *ReflogView) renderEmptyView(win RenderWindow) (err error) {
+	message := "No reflog entries to display"
+	if reflogView.loading {
+		message = "Loading reflog"
+	}
+
+	if err = win.SetRow(2, 1, CmpNone, "   %v", message); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return
+}
+
+func (reflogView *ReflogView) renderReflogEntry(tableFormatter *TableFormatter, rowIndex uint, reflogEntry *ReflogEntry) (err error) {
+	colIndex := uint(0)
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpReflogviewSelector, "%v", reflogEntry.Selector()); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpReflogviewShortOid, "%v", reflogEntry.newOid.ShortID()); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpReflogviewDate, "%v", reflogEntry.committer.When.Format(rvDateFormat)); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpReflogviewMessage, "%v", reflogEntry.message); err != nil {
+		return
+	}
+
+	return
+}
+
+// RenderHelpBar shows key bindings custom to the reflog view
+func (reflogView *ReflogView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(reflogView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Show Diff"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the reflog view is the active view or not
+func (reflogView *ReflogView) OnActiveChange(active bool) {
+	log.Debugf("ReflogView active: %v", active)
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	reflogView.active = active
+}
+
+// ViewID returns the reflog views ID
+func (reflogView *ReflogView) ViewID() ViewID {
+	return ViewReflog
+}
+
+// HandleEvent does nothing
+func (reflogView *ReflogView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// ViewPos returns the current view position
+func (reflogView *ReflogView) ViewPos() ViewPos {
+	return reflogView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (reflogView *ReflogView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	viewPos := reflogView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Reflog has changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+	reflogView.notifyCommitViewListeners()
+}
+
+// HandleAction checks if the reflog view supports the provided action and executes it if so
+func (reflogView *ReflogView) HandleAction(action Action) (err error) {
+	log.Debugf("ReflogView handling action %v", action)
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	activeRowIndex := reflogView.viewPos.ActiveRowIndex()
+
+	if handler, ok := reflogView.handlers[action.ActionType]; ok {
+		err = handler(reflogView, action)
+	} else if handled, changed := MoveViewPos(reflogView.viewPos, action, reflogView.lineNumber(), reflogView.viewDimension); handled {
+		if changed {
+			if reflogView.viewPos.ActiveRowIndex() != activeRowIndex {
+				reflogView.notifyCommitViewListeners()
+			}
+
+			reflogView.channels.UpdateDisplay()
+		}
+	} else {
+		_, err = reflogView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the reflog view at the specified line index
+func (reflogView *ReflogView) Line(lineIndex uint) (line string) {
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	lineNum := reflogView.lineNumber()
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	reflogEntry := reflogView.reflogEntries[lineIndex]
+	line = fmt.Sprintf("%v %v %v %v", reflogEntry.Selector(), reflogEntry.newOid.ShortID(),
+		reflogEntry.committer.When.Format(rvDateFormat), reflogEntry.message)
+
+	return
+}
+
+// LineNumber returns the number of entries the reflog view currently has
+func (reflogView *ReflogView) LineNumber() (lineNumber uint) {
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	return reflogView.lineNumber()
+}
+
+func (reflogView *ReflogView) lineNumber() uint {
+	return uint(len(reflogView.reflogEntries))
+}
+
+// RegisterCommitViewListener adds a listener to be notified when a reflog entry is selected
+func (reflogView *ReflogView) RegisterCommitViewListener(commitViewListener CommitViewListener) {
+	if commitViewListener == nil {
+		return
+	}
+
+	log.Debugf("Registering CommitViewListener %T", commitViewListener)
+
+	reflogView.lock.Lock()
+	defer reflogView.lock.Unlock()
+
+	reflogView.commitViewListeners = append(reflogView.commitViewListeners, commitViewListener)
+}
+
+// notifyCommitViewListeners queues the active reflog entry to be sent to listeners.
+// Only the most recently selected entry is kept if listeners have not yet been
+// notified of the previous selection
+func (reflogView *ReflogView) notifyCommitViewListeners() {
+	if len(reflogView.commitViewListeners) == 0 || reflogView.lineNumber() == 0 {
+		return
+	}
+
+	reflogEntry := reflogView.reflogEntries[reflogView.viewPos.ActiveRowIndex()]
+
+	select {
+	case <-reflogView.selectionCh:
+	default:
+	}
+
+	reflogView.selectionCh <- reflogEntry
+}
+
+// processSelections notifies listeners of selected reflog entries in the order they were selected
+func (reflogView *ReflogView) processSelections() {
+	for {
+		select {
+		case reflogEntry := <-reflogView.selectionCh:
+			reflogView.notifySelection(reflogEntry)
+		case <-reflogView.cancelCh:
+			return
+		}
+	}
+}
+
+func (reflogView *ReflogView) notifySelection(reflogEntry *ReflogEntry) {
+	log.Debugf("Notifying commit listeners of selected reflog entry %v", reflogEntry.Selector())
+
+	commit, err := reflogView.repoData.Commit(reflogEntry.newOid)
+	if err != nil {
+		reflogView.channels.ReportError(err)
+		return
+	}
+
+	reflogView.lock.Lock()
+	commitViewListeners := append([]CommitViewListener(nil), reflogView.commitViewListeners...)
+	reflogView.lock.Unlock()
+
+	for _, commitViewListener := range commitViewListeners {
+		if err := commitViewListener.OnCommitSelected(commit); err != nil {
+			reflogView.channels.ReportError(err)
+		}
+	}
+}
+
+func (reflogView *ReflogView) createCommitViewListenerView(reflogEntry *ReflogEntry) {
+	createViewArgs := CreateViewArgs{
+		viewID:   ViewDiff,
+		viewArgs: []interface{}{reflogEntry.newOid.String()},
+		registerViewListener: func(observer interface{}) (err error) {
+			if observer == nil {
+				return fmt.Errorf("Invalid CommitViewListener: %v", observer)
+			}
+
+			if commitViewListener, ok := observer.(CommitViewListener); ok {
+				reflogView.RegisterCommitViewListener(commitViewListener)
+			} else {
+				err = fmt.Errorf("Observer is not a CommitViewListener but has type %T", observer)
+			}
+
+			return
+		},
+	}
+
+	reflogView.channels.DoAction(Action{
+		ActionType: ActionSplitView,
+		Args: []interface{}{
+			ActionSplitViewArgs{
+				CreateViewArgs: createViewArgs,
+				orientation:    CoDynamic,
+			},
+		},
+	})
+}
+
+func selectReflogEntry(reflogView *ReflogView, action Action) (err error) {
+	if reflogView.lineNumber() == 0 {
+		return
+	}
+
+	if len(reflogView.commitViewListeners) == 0 {
+		reflogEntry := reflogView.reflogEntries[reflogView.viewPos.ActiveRowIndex()]
+		reflogView.createCommitViewListenerView(reflogEntry)
+		return
+	}
+
+	reflogView.notifyCommitViewListeners()
+
+	return
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+type testReflogCommitViewListener struct {
+	selectedOidCh chan *Oid
+}
+
+func (listener *testReflogCommitViewListener) OnCommitSelected(commit *Commit) error {
+	listener.selectedOidCh <- commit.oid
+	return nil
+}
+
+func newTestReflogEntries(t *testing.T) []*ReflogEntry {
+	committer := &git.Signature{
+		Name:  "Test Author",
+		Email: "test@example.com",
+		When:  time.Date(2018, 6, 1, 10, 30, 0, 0, time.UTC),
+	}
+
+	var reflogEntries []*ReflogEntry
+	for index, id := range []string{
+		"300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5",
+		"8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01",
+		"a63c6349b3fa8e0d3e1b7bc2fc2f9e4d0a0b5c11",
+	} {
+		reflogEntries = append(reflogEntries, &ReflogEntry{
+			index:     uint(index),
+			newOid:    newTestOid(id, t),
+			committer: committer,
+			message:   "commit: Entry " + id[:7],
+		})
+	}
+
+	return reflogEntries
+}
+
+func newTestReflogView(t *testing.T) (*ReflogView, *MockRepoData, []*ReflogEntry) {
+	reflogEntries := newTestReflogEntries(t)
+
+	reflogEntryCh := make(chan *ReflogEntry, len(reflogEntries))
+	for _, reflogEntry := range reflogEntries {
+		reflogEntryCh <- reflogEntry
+	}
+	close(reflogEntryCh)
+
+	repoData := &MockRepoData{}
+	repoData.On("Reflog").Return((<-chan *ReflogEntry)(reflogEntryCh), nil)
+
+	reflogView := NewReflogView(repoData, newTestChannels())
+	reflogView.viewDimension = ViewDimension{rows: 4, cols: 80}
+
+	if err := reflogView.Initialise(); err != nil {
+		t.Fatalf("Failed to initialise ReflogView: %v", err)
+	}
+
+	if err := reflogView.LoadReflog(); err != nil {
+		t.Fatalf("Failed to load reflog: %v", err)
+	}
+
+	for i := 0; i < 100 && reflogView.LineNumber() < uint(len(reflogEntries)); i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if lineNumber := reflogView.LineNumber(); lineNumber != uint(len(reflogEntries)) {
+		t.Fatalf("Expected %v reflog entries but found %v", len(reflogEntries), lineNumber)
+	}
+
+	return reflogView, repoData, reflogEntries
+}
+
+func TestReflogIsReadMostRecentFirst(t *testing.T) {
+	repoDir := newTestRepository(3, t)
+	defer os.RemoveAll(repoDir)
+
+	channels := newTestChannels()
+	repoDataLoader := NewRepoDataLoader(channels)
+	if err := repoDataLoader.Initialise(repoDir, ""); err != nil {
+		t.Fatalf("Unable to initialise RepoDataLoader: %v", err)
+	}
+	defer repoDataLoader.Free()
+
+	head, err := repoDataLoader.Head()
+	if err != nil {
+		t.Fatalf("Unable to load HEAD: %v", err)
+	}
+
+	reflogEntryCh, err := repoDataLoader.Reflog()
+	if err != nil {
+		t.Fatalf("Unable to read reflog: %v", err)
+	}
+
+	var reflogEntries []*ReflogEntry
+	for reflogEntry := range reflogEntryCh {
+		reflogEntries = append(reflogEntries, reflogEntry)
+	}
+
+	if len(reflogEntries) != 3 {
+		t.Fatalf("Expected 3 reflog entries but found %v", len(reflogEntries))
+	}
+
+	for index, reflogEntry := range reflogEntries {
+		if reflogEntry.index != uint(index) {
+			t.Errorf("Expected entry %v to have index %v but found %v", index, index, reflogEntry.index)
+		}
+	}
+
+	if selector := reflogEntries[0].Selector(); selector != "HEAD@{0}" {
+		t.Errorf("Expected selector HEAD@{0} but found %v", selector)
+	}
+
+	if !reflogEntries[0].newOid.Equal(head.Oid()) {
+		t.Errorf("Expected most recent entry to point to HEAD %v but found %v", head.Oid(), reflogEntries[0].newOid)
+	}
+
+	if !reflogEntries[0].oldOid.Equal(reflogEntries[1].newOid) {
+		t.Errorf("Expected most recent entry to follow the previous entry")
+	}
+}
+
+func TestReflogViewLineShowsSelectorOidDateAndMessage(t *testing.T) {
+	reflogView, _, _ := newTestReflogView(t)
+	defer reflogView.Dispose()
+
+	if line, expectedLine := reflogView.Line(1), "HEAD@{1} 8fb4d48 2018-06-01 10:30 commit: Entry 8fb4d48"; line != expectedLine {
+		t.Errorf("Expected line %q but found %q", expectedLine, line)
+	}
+}
+
+func TestReflogViewNavigation(t *testing.T) {
+	reflogView, _, _ := newTestReflogView(t)
+	defer reflogView.Dispose()
+
+	navigationTests := []struct {
+		action                 Action
+		expectedActiveRowIndex uint
+	}{
+		{action: Action{ActionType: ActionNextLine}, expectedActiveRowIndex: 1},
+		{action: Action{ActionType: ActionNextLine, Args: []interface{}{uint(5)}}, expectedActiveRowIndex: 2},
+		{action: Action{ActionType: ActionPrevLine}, expectedActiveRowIndex: 1},
+		{action: Action{ActionType: ActionFirstLine}, expectedActiveRowIndex: 0},
+		{action: Action{ActionType: ActionNextPage}, expectedActiveRowIndex: 2},
+		{action: Action{ActionType: ActionPrevHalfPage}, expectedActiveRowIndex: 1},
+		{action: Action{ActionType: ActionLastLine}, expectedActiveRowIndex: 2},
+		{action: Action{ActionType: ActionPrevPage}, expectedActiveRowIndex: 0},
+	}
+
+	for _, navigationTest := range navigationTests {
+		if err := reflogView.HandleAction(navigationTest.action); err != nil {
+			t.Fatalf("Failed to handle action %v: %v", navigationTest.action.ActionType, err)
+		}
+
+		if activeRowIndex := reflogView.ViewPos().ActiveRowIndex(); activeRowIndex != navigationTest.expectedActiveRowIndex {
+			t.Errorf("Expected active row index to be %v after action %v but found %v",
+				navigationTest.expectedActiveRowIndex, navigationTest.action.ActionType, activeRowIndex)
+		}
+	}
+}
+
+func TestReflogViewNotifiesListenersOfTheLastSelection(t *testing.T) {
+	reflogView, repoData, reflogEntries := newTestReflogView(t)
+	defer reflogView.Dispose()
+
+	for _, reflogEntry := range reflogEntries {
+		repoData.On("Commit", reflogEntry.newOid).Return(&Commit{oid: reflogEntry.newOid}, nil)
+	}
+
+	listener := &testReflogCommitViewListener{selectedOidCh: make(chan *Oid, 10)}
+	reflogView.RegisterCommitViewListener(listener)
+
+	for i := 0; i < 2; i++ {
+		if err := reflogView.HandleAction(Action{ActionType: ActionNextLine}); err != nil {
+			t.Fatalf("Failed to handle action: %v", err)
+		}
+	}
+
+	lastOid := reflogEntries[2].newOid
+
+	for {
+		select {
+		case oid := <-listener.selectedOidCh:
+			if oid.Equal(lastOid) {
+				select {
+				case oid = <-listener.selectedOidCh:
+					t.Errorf("Expected no selections after the last selection but found %v", oid)
+				case <-time.After(time.Millisecond * 50):
+				}
+
+				repoData.AssertNotCalled(t, "Commit", reflogEntries[0].newOid)
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for listener to be notified of %v", lastOid)
+		}
+	}
+}
+
+func TestReflogViewIgnoresUnknownActions(t *testing.T) {
+	reflogView, repoData, _ := newTestReflogView(t)
+	defer reflogView.Dispose()
+
+	if err := reflogView.HandleAction(Action{ActionType: ActionNone}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	repoData.AssertNotCalled(t, "Commit", mock.Anything)
+}
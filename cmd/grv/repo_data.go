@@ -15,15 +15,21 @@ const (
 	// GitRepositoryDirectoryName is the name of the git directory in a git repository
 	GitRepositoryDirectoryName = ".git"
 	updatedRefChannelSize      = 256
+	rdFirstWindowCommitNum     = 100
 )
 
 // OnRefsLoaded is called when all refs have been loaded and processed
 type OnRefsLoaded func([]Ref) error
 
 // CommitSetListener is notified of load and update events for commit sets
+// OnCommitsLoaded receives the state of the commit set once loading has finished
+// or paused, or the error which caused loading to fail
+// OnFirstWindowLoaded is called once enough commits to fill the view have been loaded
+// while loading is still in progress
 type CommitSetListener interface {
-	OnCommitsLoaded(Ref)
+	OnCommitsLoaded(ref Ref, commitSetState CommitSetState, err error)
 	OnCommitsUpdated(ref Ref)
+	OnFirstWindowLoaded(ref Ref)
 }
 
 // StatusListener is notified when git status has changed
@@ -54,29 +60,62 @@ type RefStateListener interface {
 type RepoData interface {
 	EventListener
 	Path() string
+	Workdir() string
+	RebaseInProgress() bool
 	LoadHead() error
 	LoadRefs(OnRefsLoaded)
 	LoadCommits(Ref) error
+	LoadMoreCommits(Ref) error
+	SetFirstWindowCommitNum(commitNum uint)
 	Head() Ref
 	Ref(refName string) (Ref, error)
 	Branches() (localBranches, remoteBranches []Branch, loading bool)
+	AheadBehind(ref Ref) (ahead, behind uint, isTrackingBranch bool)
 	Tags() (tags []*Tag, loading bool)
 	RefsForCommit(*Commit) *CommitRefs
+	RefsAtCommit(oid *Oid) []string
 	CommitSetState(Ref) CommitSetState
 	Commits(ref Ref, startIndex, count uint) (<-chan *Commit, error)
 	CommitByIndex(ref Ref, index uint) (*Commit, error)
 	Commit(oid *Oid) (*Commit, error)
 	CommitByOid(oidStr string) (*Commit, error)
+	ResolveOid(prefix string) (*Oid, error)
+	CommitParentIDs(commit *Commit) []*Oid
+	CommitParentCount(commit *Commit) uint
+	VerifyCommit(oid *Oid) (SignatureStatus, error)
+	Blame(path string, oid *Oid, followRenames bool) (<-chan *BlameLine, error)
+	Reflog() (<-chan *ReflogEntry, error)
+	Tree(oid *Oid, path string) ([]*TreeEntry, error)
+	FileContent(path string, oid *Oid) ([]byte, error)
+	CreateTag(name string, oid *Oid) error
+	CreateBranch(name string, oid *Oid) (Ref, error)
+	Checkout(ref Ref) error
+	Stashes() ([]*StashEntry, error)
+	StashApply(stashEntry *StashEntry) error
+	StashDrop(stashEntry *StashEntry) error
+	BisectStart() error
+	BisectGood(oid *Oid) (*BisectStep, error)
+	BisectBad(oid *Oid) (*BisectStep, error)
+	BisectReset() error
+	AmendCommitMessage(message string) error
 	AddCommitFilter(Ref, *CommitFilter) error
+	SetCommitSortOrder(Ref, CommitSortOrder) error
+	SetFirstParentOnly(ref Ref, firstParentOnly bool) error
+	SetHideMerges(ref Ref, hideMerges bool) error
+	ReloadCommits(ref Ref) error
 	RemoveCommitFilter(Ref) error
-	DiffCommit(commit *Commit) (*Diff, error)
-	DiffFile(statusType StatusType, path string) (*Diff, error)
-	DiffStage(statusType StatusType) (*Diff, error)
+	DiffCommit(commit *Commit, contextLines uint) (*Diff, error)
+	DiffRange(fromOid, toOid *Oid, contextLines uint) (*Diff, error)
+	DiffStat(oid *Oid) (*DiffStat, error)
+	CommitModifiesPath(commit *Commit, path string) (bool, error)
+	DiffFile(statusType StatusType, path string, contextLines uint) (*Diff, error)
+	DiffStage(statusType StatusType, contextLines uint) (*Diff, error)
 	LoadStatus() (err error)
 	Status() *Status
 	RegisterStatusListener(StatusListener)
 	RegisterRefStateListener(RefStateListener)
 	RegisterCommitSetListener(CommitSetListener)
+	UnregisterCommitSetListener(CommitSetListener)
 }
 
 type commitSet interface {
@@ -280,10 +319,13 @@ func (filteredCommitSet *filteredCommitSet) Clone() commitSet {
 }
 
 // CommitSetState describes the current state of a commit set for a ref
+// moreAvailable is true when loading has paused after reaching the commit load limit
 type CommitSetState struct {
-	loading     bool
-	commitNum   uint
-	filterState *CommitSetFilterState
+	loading       bool
+	moreAvailable bool
+	commitNum     uint
+	loadOptions   CommitLoadOptions
+	filterState   *CommitSetFilterState
 }
 
 // CommitSetFilterState describes filter information for a commit set
@@ -292,6 +334,87 @@ type CommitSetFilterState struct {
 	filtersApplied      uint
 }
 
+// commitLoadThrottle pauses the loading of commits for a ref once
+// the load limit has been reached until more commits are requested
+type commitLoadThrottle struct {
+	limit     uint
+	paused    bool
+	cancelled bool
+	resumeCh  chan bool
+	cancelCh  chan bool
+	lock      sync.Mutex
+}
+
+func newCommitLoadThrottle(limit uint) *commitLoadThrottle {
+	return &commitLoadThrottle{
+		limit:    limit,
+		resumeCh: make(chan bool, 1),
+		cancelCh: make(chan bool),
+	}
+}
+
+// limitReached returns true and pauses loading if the number of commits loaded has reached the load limit
+func (throttle *commitLoadThrottle) limitReached(commitNum uint) bool {
+	throttle.lock.Lock()
+	defer throttle.lock.Unlock()
+
+	if throttle.limit == 0 || commitNum < throttle.limit {
+		return false
+	}
+
+	throttle.paused = true
+
+	return true
+}
+
+// wait blocks until loading is resumed. False is returned if grv is exiting or loading has been cancelled
+func (throttle *commitLoadThrottle) wait(exitCh <-chan bool) bool {
+	select {
+	case <-throttle.resumeCh:
+		return true
+	case <-throttle.cancelCh:
+		return false
+	case _, ok := <-exitCh:
+		return ok
+	}
+}
+
+// cancel stops loading any further commits
+func (throttle *commitLoadThrottle) cancel() {
+	throttle.lock.Lock()
+	defer throttle.lock.Unlock()
+
+	if !throttle.cancelled {
+		throttle.cancelled = true
+		close(throttle.cancelCh)
+	}
+}
+
+// resume increases the load limit and resumes loading if it was paused
+func (throttle *commitLoadThrottle) resume(additionalCommits uint) {
+	throttle.lock.Lock()
+	defer throttle.lock.Unlock()
+
+	if !throttle.paused {
+		return
+	}
+
+	throttle.limit += additionalCommits
+	throttle.paused = false
+
+	select {
+	case throttle.resumeCh <- true:
+	default:
+	}
+}
+
+func (throttle *commitLoadThrottle) isPaused() bool {
+	throttle.lock.Lock()
+	defer throttle.lock.Unlock()
+
+	return throttle.paused
+}
+
 type trackingBranchState struct {
 	localBranch  *LocalBranch
 	remoteBranch *RemoteBranch
@@ -310,6 +433,8 @@ type refSet struct {
 	tagsList                      []*Tag
 	remoteToLocalTrackingBranches map[string]map[string]bool
 	loading                       bool
+	reloadRequested               bool
+	queuedOnRefsLoaded            []OnRefsLoaded
 	refStateListeners             []RefStateListener
 	trackingBranchUpdater         trackingBranchUpdater
 	lock                          sync.Mutex
@@ -386,11 +511,20 @@ func (refSet *refSet) ref(refName string) (ref Ref, exists bool) {
 	return
 }
 
-func (refSet *refSet) startRefUpdate() bool {
+// startRefUpdate returns true if no ref update is in progress.
+// Otherwise a reload is requested once the current update completes and
+// the provided callback is queued to be called when the reload completes
+func (refSet *refSet) startRefUpdate(onRefsLoaded OnRefsLoaded) bool {
 	refSet.lock.Lock()
 	defer refSet.lock.Unlock()
 
 	if refSet.loading {
+		refSet.reloadRequested = true
+
+		if onRefsLoaded != nil {
+			refSet.queuedOnRefsLoaded = append(refSet.queuedOnRefsLoaded, onRefsLoaded)
+		}
+
 		return false
 	}
 
@@ -399,11 +533,18 @@ func (refSet *refSet) startRefUpdate() bool {
 	return true
 }
 
-func (refSet *refSet) endRefUpdate() {
+// endRefUpdate returns whether a reload was requested during the update and the callbacks queued for it
+func (refSet *refSet) endRefUpdate() (reloadRequested bool, queuedOnRefsLoaded []OnRefsLoaded) {
 	refSet.lock.Lock()
 	defer refSet.lock.Unlock()
 
+	reloadRequested, queuedOnRefsLoaded = refSet.reloadRequested, refSet.queuedOnRefsLoaded
+
 	refSet.loading = false
+	refSet.reloadRequested = false
+	refSet.queuedOnRefsLoaded = nil
+
+	return
 }
 
 func (refSet *refSet) updateRefs(refs []Ref) (err error) {
@@ -714,13 +855,17 @@ func (commitRefSet *commitRefSet) addBranchForCommit(commit *Commit, newBranch B
 	commitRefs.branches = append(commitRefs.branches, newBranch)
 }
 
-func (commitRefSet *commitRefSet) refsForCommit(commit *Commit) (commitRefsCopy *CommitRefs) {
+func (commitRefSet *commitRefSet) refsForCommit(commit *Commit) *CommitRefs {
+	return commitRefSet.refsForOid(commit.oid)
+}
+
+func (commitRefSet *commitRefSet) refsForOid(oid *Oid) (commitRefsCopy *CommitRefs) {
 	commitRefSet.lock.Lock()
 	defer commitRefSet.lock.Unlock()
 
 	commitRefsCopy = &CommitRefs{}
 
-	commitRefs, ok := commitRefSet.commitRefs[commit.oid]
+	commitRefs, ok := commitRefSet.commitRefs[oid]
 	if ok {
 		commitRefsCopy.tags = append([]*Tag(nil), commitRefs.tags...)
 		commitRefsCopy.branches = append([]Branch(nil), commitRefs.branches...)
@@ -758,6 +903,26 @@ func (refCommitSets *refCommitSets) setCommitSet(ref Ref, commitSet commitSet) {
 	refCommitSets.commits[ref.Name()] = commitSet
 }
 
+// removeCommitSet removes the commit set for the ref and returns the filters
+// that were applied to it in the order they were added
+func (refCommitSets *refCommitSets) removeCommitSet(ref Ref) (commitFilters []*CommitFilter) {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	removedCommitSet, ok := refCommitSets.commits[ref.Name()]
+	if !ok {
+		return
+	}
+
+	delete(refCommitSets.commits, ref.Name())
+
+	for filteredSet, ok := removedCommitSet.(*filteredCommitSet); ok && filteredSet.HasChild(); filteredSet, ok = filteredSet.Child().(*filteredCommitSet) {
+		commitFilters = append([]*CommitFilter{filteredSet.commitFilter}, commitFilters...)
+	}
+
+	return
+}
+
 func (refCommitSets *refCommitSets) addCommitFilter(ref Ref, commitFilter *CommitFilter) (err error) {
 	refCommitSets.lock.Lock()
 	defer refCommitSets.lock.Unlock()
@@ -849,17 +1014,34 @@ func (refCommitSets *refCommitSets) unregisterCommitSetListener(commitSetListene
 	}
 }
 
-func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetLoaded(ref Ref) {
+// notifyCommitSetListenersCommitSetLoaded notifies listeners asynchronously so that
+// listeners are free to call back into RepoData without holding any of its locks
+func (refCommitSets *refCommitSets) notifyCommitSetListenersCommitSetLoaded(ref Ref, commitSetState CommitSetState, err error) {
 	refCommitSets.lock.Lock()
 	defer refCommitSets.lock.Unlock()
 
 	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
 
 	go func() {
-		log.Debugf("Notifying CommitSetListeners commits for ref %v have loaded", ref.Name())
+		log.Debugf("Notifying CommitSetListeners commits for ref %v have loaded. Error: %v", ref.Name(), err)
 
 		for _, listener := range commitSetListeners {
-			listener.OnCommitsLoaded(ref)
+			listener.OnCommitsLoaded(ref, commitSetState, err)
+		}
+	}()
+}
+
+func (refCommitSets *refCommitSets) notifyCommitSetListenersFirstWindowLoaded(ref Ref) {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	commitSetListeners := append([]CommitSetListener(nil), refCommitSets.commitSetListeners...)
+
+	go func() {
+		log.Debugf("Notifying CommitSetListeners first window of commits for ref %v has loaded", ref.Name())
+
+		for _, listener := range commitSetListeners {
+			listener.OnFirstWindowLoaded(ref)
 		}
 	}()
 }
@@ -953,25 +1135,36 @@ func (statusManager *statusManager) unregisterStatusListener(statusListener Stat
 
 // RepositoryData implements RepoData and stores all loaded repository data
 type RepositoryData struct {
-	channels       *Channels
-	repoDataLoader *RepoDataLoader
-	head           Ref
-	refSet         *refSet
-	commitRefSet   *commitRefSet
-	refCommitSets  *refCommitSets
-	statusManager  *statusManager
-	refUpdateCh    chan *UpdatedRef
+	channels            *Channels
+	config              Config
+	repoDataLoader      *RepoDataLoader
+	head                Ref
+	refSet              *refSet
+	commitRefSet        *commitRefSet
+	refCommitSets       *refCommitSets
+	statusManager       *statusManager
+	refUpdateCh         chan *UpdatedRef
+	cancelCh            chan bool
+	commitLoadThrottles map[string]*commitLoadThrottle
+	commitLoadOptions   map[string]CommitLoadOptions
+	firstWindowNum      uint
+	throttleLock        sync.Mutex
 }
 
 // NewRepositoryData creates a new instance
-func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels) *RepositoryData {
+func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels, config Config) *RepositoryData {
 	repoData := &RepositoryData{
-		channels:       channels,
-		repoDataLoader: repoDataLoader,
-		commitRefSet:   newCommitRefSet(),
-		refCommitSets:  newRefCommitSets(channels),
-		statusManager:  newStatusManager(repoDataLoader),
-		refUpdateCh:    make(chan *UpdatedRef, updatedRefChannelSize),
+		channels:            channels,
+		config:              config,
+		repoDataLoader:      repoDataLoader,
+		commitLoadThrottles: make(map[string]*commitLoadThrottle),
+		commitLoadOptions:   make(map[string]CommitLoadOptions),
+		commitRefSet:        newCommitRefSet(),
+		refCommitSets:       newRefCommitSets(channels),
+		statusManager:       newStatusManager(repoDataLoader),
+		refUpdateCh:         make(chan *UpdatedRef, updatedRefChannelSize),
+		cancelCh:            make(chan bool),
+		firstWindowNum:      rdFirstWindowCommitNum,
 	}
 
 	repoData.refSet = newRefSet(repoData)
@@ -980,11 +1173,30 @@ func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels) *Repo
 }
 
 // Free free's any underlying resources
+// Commit loads in progress are cancelled and the repository is only freed
+// once all goroutines using it have finished
 func (repoData *RepositoryData) Free() {
-	close(repoData.refUpdateCh)
+	close(repoData.cancelCh)
+
+	repoData.throttleLock.Lock()
+	for _, throttle := range repoData.commitLoadThrottles {
+		throttle.cancel()
+	}
+	repoData.throttleLock.Unlock()
+
 	repoData.repoDataLoader.Free()
 }
 
+// freed returns true if Free has been called
+func (repoData *RepositoryData) freed() bool {
+	select {
+	case <-repoData.cancelCh:
+		return true
+	default:
+		return false
+	}
+}
+
 // Initialise performs setup to allow loading data from the repository
 func (repoData *RepositoryData) Initialise(repoPath, workTreePath string) (err error) {
 	repoPath, err = repoData.processRepoPath(repoPath)
@@ -1050,6 +1262,16 @@ func (repoData *RepositoryData) Path() string {
 	return repoData.repoDataLoader.Path()
 }
 
+// Workdir returns the file path location of the working tree
+func (repoData *RepositoryData) Workdir() string {
+	return repoData.repoDataLoader.Workdir()
+}
+
+// RebaseInProgress returns true if a rebase is in progress
+func (repoData *RepositoryData) RebaseInProgress() bool {
+	return repoData.repoDataLoader.RebaseInProgress()
+}
+
 // LoadHead attempts to load the HEAD reference
 func (repoData *RepositoryData) LoadHead() (err error) {
 	head, err := repoData.repoDataLoader.Head()
@@ -1063,49 +1285,68 @@ func (repoData *RepositoryData) LoadHead() (err error) {
 }
 
 // LoadRefs loads all branches and tags present in the repository
+// If refs are already being loaded they are reloaded once the current load
+// completes and onRefsLoaded is called when the reload completes
 func (repoData *RepositoryData) LoadRefs(onRefsLoaded OnRefsLoaded) {
 	refSet := repoData.refSet
 
 	log.Debug("Loading refs")
 
-	if !refSet.startRefUpdate() {
-		log.Debugf("Already loading refs")
+	if !refSet.startRefUpdate(onRefsLoaded) {
+		log.Debugf("Already loading refs. Refs will be reloaded when the current load completes")
 		return
 	}
 
 	go func() {
-		defer refSet.endRefUpdate()
+		refs, err := repoData.loadAndUpdateRefs()
+		reloadRequested, queuedOnRefsLoaded := refSet.endRefUpdate()
 
-		if err := repoData.LoadHead(); err != nil {
-			repoData.channels.ReportError(err)
+		if repoData.freed() {
+			log.Debugf("Repository freed while loading refs")
 			return
-		}
-
-		refs, err := repoData.repoDataLoader.LoadRefs()
-		if err != nil {
+		} else if err != nil {
 			repoData.channels.ReportError(err)
-			return
-		}
-
-		repoData.mapRefsToCommits(refs)
+		} else {
+			log.Debug("Refs loaded")
 
-		if err = refSet.updateRefs(refs); err != nil {
-			repoData.channels.ReportError(err)
-			return
+			if onRefsLoaded != nil {
+				if err = onRefsLoaded(refs); err != nil {
+					repoData.channels.ReportError(err)
+				}
+			}
 		}
 
-		refSet.endRefUpdate()
+		if reloadRequested {
+			log.Debugf("Reloading refs requested while refs were loading")
 
-		log.Debug("Refs loaded")
+			repoData.LoadRefs(func(refs []Ref) (err error) {
+				for _, queuedCallback := range queuedOnRefsLoaded {
+					if err := queuedCallback(refs); err != nil {
+						repoData.channels.ReportError(err)
+					}
+				}
 
-		if onRefsLoaded != nil {
-			if err = onRefsLoaded(refs); err != nil {
-				repoData.channels.ReportError(err)
-			}
+				return
+			})
 		}
 	}()
 }
 
+func (repoData *RepositoryData) loadAndUpdateRefs() (refs []Ref, err error) {
+	if err = repoData.LoadHead(); err != nil {
+		return
+	}
+
+	if refs, err = repoData.repoDataLoader.LoadRefs(); err != nil {
+		return
+	}
+
+	repoData.mapRefsToCommits(refs)
+	err = repoData.refSet.updateRefs(refs)
+
+	return
+}
+
 // TODO Become RefStateListener and only update commitRefSet for refs that have changed
 func (repoData *RepositoryData) mapRefsToCommits(refs []Ref) {
 	log.Debug("Mapping refs to commits")
@@ -1132,48 +1373,261 @@ func (repoData *RepositoryData) mapRefsToCommits(refs []Ref) {
 }
 
 // LoadCommits attempts to load all commits for the provided oid
+// CommitSetListeners are notified with the state of the commit set when loading
+// finishes or pauses on reaching the load limit, or with the error if loading fails
 func (repoData *RepositoryData) LoadCommits(ref Ref) (err error) {
 	if _, ok := repoData.refCommitSets.commitSet(ref); ok {
 		log.Debugf("Commits already loading/loaded for ref %v", ref.Name())
 		return
 	}
 
-	commitCh, err := repoData.repoDataLoader.Commits(ref.Oid())
+	throttle := newCommitLoadThrottle(repoData.commitLoadLimit())
+
+	commitCh, errorCh, err := repoData.repoDataLoader.Commits(ref.Oid(), repoData.loadOptions(ref), throttle.cancelCh)
 	if err != nil {
 		return
 	}
 
-	commitSet := newBaseFilteredCommitSet()
-	commitSet.SetLoading(true)
-	repoData.refCommitSets.setCommitSet(ref, commitSet)
+	repoData.loadCommits(ref, throttle, commitCh, errorCh)
+
+	return
+}
+
+// loadCommits adds the commits received on commitCh to a new commit set for the ref
+// Listeners are notified once the first window of commits has been received
+func (repoData *RepositoryData) loadCommits(ref Ref, throttle *commitLoadThrottle, commitCh <-chan *Commit, errorCh <-chan error) {
+	firstWindowCommitNum := repoData.firstWindowCommitNum()
+
+	baseCommitSet := newBaseFilteredCommitSet()
+	baseCommitSet.SetLoading(true)
+	repoData.refCommitSets.setCommitSet(ref, baseCommitSet)
+	repoData.setCommitLoadThrottle(ref, throttle)
 
 	go func() {
 		log.Debugf("Receiving commits from RepoDataLoader for ref %v at %v", ref.Name(), ref.Oid())
+		commitNum := uint(0)
 
 		for commit := range commitCh {
-			commitSet, ok := repoData.refCommitSets.commitSet(ref)
-			if !ok {
-				log.Errorf("Error when loading commits: No CommitSet exists for ref %v", ref.Name())
+			active, err := repoData.updateLoadingCommitSet(ref, throttle, func(loadingCommitSet commitSet) error {
+				return loadingCommitSet.AddCommit(commit)
+			})
+
+			if err != nil {
+				repoData.failLoadingCommits(ref, throttle, err)
+				return
+			} else if !active {
+				log.Debugf("Loading commits for ref %v has been superseded", ref.Name())
 				return
 			}
 
-			if err := commitSet.AddCommit(commit); err != nil {
-				log.Errorf("Error when loading commits for ref %v: %v", ref.Name(), err)
-				return
+			commitNum++
+
+			if commitNum == firstWindowCommitNum {
+				repoData.refCommitSets.notifyCommitSetListenersFirstWindowLoaded(ref)
+			}
+
+			if throttle.limitReached(commitNum) {
+				log.Debugf("Pausing loading commits for ref %v after %v commits", ref.Name(), commitNum)
+				repoData.refCommitSets.notifyCommitSetListenersCommitSetLoaded(ref, repoData.CommitSetState(ref), nil)
+
+				if !throttle.wait(repoData.channels.exitCh) {
+					return
+				}
+
+				log.Debugf("Resuming loading commits for ref %v", ref.Name())
 			}
 		}
 
-		commitSet, ok := repoData.refCommitSets.commitSet(ref)
-		if !ok {
-			log.Errorf("No CommitSet exists for ref %v", ref.Name())
+		select {
+		case err := <-errorCh:
+			repoData.failLoadingCommits(ref, throttle, err)
+			return
+		default:
+		}
+
+		active, err := repoData.updateLoadingCommitSet(ref, throttle, func(loadingCommitSet commitSet) error {
+			loadingCommitSet.SetLoading(false)
+			return nil
+		})
+
+		if err != nil {
+			repoData.failLoadingCommits(ref, throttle, err)
+			return
+		} else if !active {
 			return
 		}
 
-		commitSet.SetLoading(false)
 		log.Debugf("Finished loading commits for ref %v", ref.Name())
 
-		repoData.refCommitSets.notifyCommitSetListenersCommitSetLoaded(ref)
+		repoData.refCommitSets.notifyCommitSetListenersCommitSetLoaded(ref, repoData.CommitSetState(ref), nil)
 	}()
+}
+
+// SetFirstWindowCommitNum sets the number of commits which fill the view
+// CommitSetListeners are notified once this many commits have loaded for a ref
+func (repoData *RepositoryData) SetFirstWindowCommitNum(commitNum uint) {
+	if commitNum == 0 {
+		return
+	}
+
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	repoData.firstWindowNum = commitNum
+}
+
+func (repoData *RepositoryData) firstWindowCommitNum() uint {
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	return repoData.firstWindowNum
+}
+
+// failLoadingCommits marks the commit set of the ref as no longer loading and
+// notifies listeners of the error, provided the load is still the current load for the ref
+func (repoData *RepositoryData) failLoadingCommits(ref Ref, throttle *commitLoadThrottle, err error) {
+	log.Errorf("Error when loading commits for ref %v: %v", ref.Name(), err)
+
+	active, _ := repoData.updateLoadingCommitSet(ref, throttle, func(loadingCommitSet commitSet) error {
+		loadingCommitSet.SetLoading(false)
+		return nil
+	})
+
+	if active {
+		repoData.refCommitSets.notifyCommitSetListenersCommitSetLoaded(ref, repoData.CommitSetState(ref), err)
+	}
+}
+
+// LoadMoreCommits resumes loading commits for the provided ref if
+// loading paused after reaching the commit load limit
+func (repoData *RepositoryData) LoadMoreCommits(ref Ref) (err error) {
+	throttle, exists := repoData.commitLoadThrottle(ref)
+	if !exists {
+		return fmt.Errorf("No commits loading for ref %v", ref.Name())
+	}
+
+	if throttle.isPaused() {
+		log.Debugf("Loading more commits for ref %v", ref.Name())
+		throttle.resume(repoData.commitLoadLimit())
+	}
+
+	return
+}
+
+func (repoData *RepositoryData) commitLoadLimit() uint {
+	return uint(repoData.config.GetInt(CfCommitLoadLimit))
+}
+
+func (repoData *RepositoryData) commitLoadThrottle(ref Ref) (throttle *commitLoadThrottle, exists bool) {
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	throttle, exists = repoData.commitLoadThrottles[ref.Name()]
+	return
+}
+
+func (repoData *RepositoryData) setCommitLoadThrottle(ref Ref, throttle *commitLoadThrottle) {
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	repoData.commitLoadThrottles[ref.Name()] = throttle
+}
+
+// updateLoadingCommitSet calls update with the commit set of the ref if the load the throttle
+// belongs to is still the current load for the ref. Otherwise false is returned
+func (repoData *RepositoryData) updateLoadingCommitSet(ref Ref, throttle *commitLoadThrottle, update func(commitSet) error) (active bool, err error) {
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	if repoData.commitLoadThrottles[ref.Name()] != throttle {
+		return
+	}
+
+	commitSet, ok := repoData.refCommitSets.commitSet(ref)
+	if !ok {
+		err = fmt.Errorf("No CommitSet exists for ref %v", ref.Name())
+		return
+	}
+
+	active = true
+	err = update(commitSet)
+
+	return
+}
+
+func (repoData *RepositoryData) loadOptions(ref Ref) CommitLoadOptions {
+	repoData.throttleLock.Lock()
+	defer repoData.throttleLock.Unlock()
+
+	return repoData.commitLoadOptions[ref.Name()]
+}
+
+// SetCommitSortOrder reloads the commits for the provided ref in the provided order
+func (repoData *RepositoryData) SetCommitSortOrder(ref Ref, commitSortOrder CommitSortOrder) error {
+	return repoData.updateLoadOptions(ref, func(commitLoadOptions *CommitLoadOptions) {
+		commitLoadOptions.sortOrder = commitSortOrder
+	})
+}
+
+// SetFirstParentOnly reloads the commits for the provided ref following either
+// only the first parent of merge commits or all parents
+func (repoData *RepositoryData) SetFirstParentOnly(ref Ref, firstParentOnly bool) error {
+	return repoData.updateLoadOptions(ref, func(commitLoadOptions *CommitLoadOptions) {
+		commitLoadOptions.firstParentOnly = firstParentOnly
+	})
+}
+
+// SetHideMerges reloads the commits for the provided ref either excluding or including merge commits
+func (repoData *RepositoryData) SetHideMerges(ref Ref, hideMerges bool) error {
+	return repoData.updateLoadOptions(ref, func(commitLoadOptions *CommitLoadOptions) {
+		commitLoadOptions.hideMerges = hideMerges
+	})
+}
+
+// updateLoadOptions applies the update to the load options of the ref and reloads its commits if they have changed
+// Any filters applied to the commits of the ref are reapplied
+func (repoData *RepositoryData) updateLoadOptions(ref Ref, update func(*CommitLoadOptions)) (err error) {
+	repoData.throttleLock.Lock()
+
+	commitLoadOptions := repoData.commitLoadOptions[ref.Name()]
+	update(&commitLoadOptions)
+
+	if repoData.commitLoadOptions[ref.Name()] == commitLoadOptions {
+		repoData.throttleLock.Unlock()
+		return
+	}
+
+	repoData.commitLoadOptions[ref.Name()] = commitLoadOptions
+	repoData.throttleLock.Unlock()
+
+	log.Debugf("Reloading commits for ref %v with options %+v", ref.Name(), commitLoadOptions)
+
+	return repoData.ReloadCommits(ref)
+}
+
+// ReloadCommits discards any loaded commits for the provided ref and loads them again
+// Any filters applied to the commits of the ref are reapplied
+func (repoData *RepositoryData) ReloadCommits(ref Ref) (err error) {
+	repoData.throttleLock.Lock()
+
+	if throttle, exists := repoData.commitLoadThrottles[ref.Name()]; exists {
+		throttle.cancel()
+		delete(repoData.commitLoadThrottles, ref.Name())
+	}
+
+	commitFilters := repoData.refCommitSets.removeCommitSet(ref)
+
+	repoData.throttleLock.Unlock()
+
+	if err = repoData.LoadCommits(ref); err != nil {
+		return
+	}
+
+	for _, commitFilter := range commitFilters {
+		if err = repoData.refCommitSets.addCommitFilter(ref, commitFilter); err != nil {
+			return
+		}
+	}
 
 	return
 }
@@ -1198,6 +1652,22 @@ func (repoData *RepositoryData) Branches() (localBranches []Branch, remoteBranch
 	return repoData.refSet.branches()
 }
 
+// AheadBehind returns the number of commits the provided ref is ahead and behind its upstream branch
+// If the ref is not a local branch tracking an upstream branch then isTrackingBranch is false
+func (repoData *RepositoryData) AheadBehind(ref Ref) (ahead, behind uint, isTrackingBranch bool) {
+	currentRef, exists := repoData.refSet.ref(ref.Name())
+	if !exists {
+		return
+	}
+
+	localBranch, isLocalBranch := currentRef.(*LocalBranch)
+	if !isLocalBranch || !localBranch.IsTrackingBranch() {
+		return
+	}
+
+	return localBranch.ahead, localBranch.behind, true
+}
+
 // Tags returns all loaded tags
 func (repoData *RepositoryData) Tags() (tags []*Tag, loading bool) {
 	return repoData.refSet.tags()
@@ -1208,10 +1678,61 @@ func (repoData *RepositoryData) RefsForCommit(commit *Commit) *CommitRefs {
 	return repoData.commitRefSet.refsForCommit(commit)
 }
 
+// RefsAtCommit returns decorations for the refs pointing to the provided commit
+// in the format used by git log --decorate
+func (repoData *RepositoryData) RefsAtCommit(oid *Oid) []string {
+	return CommitDecorations(repoData.Head(), oid, repoData.commitRefSet.refsForOid(oid))
+}
+
+// CommitDecorations generates decorations for the provided refs pointing to the commit with the provided oid.
+// HEAD is listed first followed by local branches, remote branches and tags
+func CommitDecorations(head Ref, oid *Oid, commitRefs *CommitRefs) (decorations []string) {
+	headIsBranch := false
+
+	if head != nil && head.Oid().Equal(oid) {
+		if _, isDetached := head.(*HEAD); isDetached {
+			decorations = append(decorations, RdlHeadRef)
+		} else {
+			headIsBranch = true
+		}
+	}
+
+	var localBranches, remoteBranches []string
+
+	for _, branch := range commitRefs.branches {
+		switch {
+		case branch.IsRemote():
+			remoteBranches = append(remoteBranches, branch.Shorthand())
+		case headIsBranch && branch.Name() == head.Name():
+			decorations = append(decorations, fmt.Sprintf("%v -> %v", RdlHeadRef, branch.Shorthand()))
+		default:
+			localBranches = append(localBranches, branch.Shorthand())
+		}
+	}
+
+	decorations = append(decorations, localBranches...)
+	decorations = append(decorations, remoteBranches...)
+
+	for _, tag := range commitRefs.tags {
+		decorations = append(decorations, "tag: "+tag.Shorthand())
+	}
+
+	return
+}
+
 // CommitSetState returns the current commit set state for the provided oid
 func (repoData *RepositoryData) CommitSetState(ref Ref) CommitSetState {
 	if commitSet, ok := repoData.refCommitSets.commitSet(ref); ok {
-		return commitSet.CommitSetState()
+		commitSetState := commitSet.CommitSetState()
+
+		if throttle, exists := repoData.commitLoadThrottle(ref); exists && throttle.isPaused() {
+			commitSetState.loading = false
+			commitSetState.moreAvailable = true
+		}
+
+		commitSetState.loadOptions = repoData.loadOptions(ref)
+
+		return commitSetState
 	}
 
 	return CommitSetState{
@@ -1276,6 +1797,167 @@ func (repoData *RepositoryData) CommitByOid(oidStr string) (*Commit, error) {
 	return repoData.repoDataLoader.CommitByOid(oidStr)
 }
 
+// ResolveOid returns the oid of the commit the provided full or abbreviated oid string refers to
+func (repoData *RepositoryData) ResolveOid(prefix string) (*Oid, error) {
+	return repoData.repoDataLoader.ResolveOid(prefix)
+}
+
+// CommitParentIDs returns the ids of the parents of the provided commit
+func (repoData *RepositoryData) CommitParentIDs(commit *Commit) []*Oid {
+	return repoData.repoDataLoader.CommitParentIDs(commit)
+}
+
+// CommitParentCount returns the number of parents of the provided commit
+func (repoData *RepositoryData) CommitParentCount(commit *Commit) uint {
+	return repoData.repoDataLoader.CommitParentCount(commit)
+}
+
+// DiffStat returns the number of lines added and removed by the commit with the provided oid
+func (repoData *RepositoryData) DiffStat(oid *Oid) (*DiffStat, error) {
+	return repoData.repoDataLoader.DiffStat(oid)
+}
+
+// CommitModifiesPath returns true if the commit modified the file or directory at the provided path
+func (repoData *RepositoryData) CommitModifiesPath(commit *Commit, path string) (bool, error) {
+	return repoData.repoDataLoader.CommitModifiesPath(commit, path)
+}
+
+// VerifyCommit returns the status of the signature of the commit with the provided oid
+func (repoData *RepositoryData) VerifyCommit(oid *Oid) (SignatureStatus, error) {
+	return repoData.repoDataLoader.VerifyCommit(oid)
+}
+
+// Blame returns blame information for the file at the provided path as of the commit with the provided oid
+// If followRenames is set lines are attributed to the commit they originated in across renames
+func (repoData *RepositoryData) Blame(path string, oid *Oid, followRenames bool) (<-chan *BlameLine, error) {
+	return repoData.repoDataLoader.Blame(path, oid, followRenames)
+}
+
+// CreateTag creates a lightweight tag pointing to the provided commit and reloads refs
+func (repoData *RepositoryData) CreateTag(name string, oid *Oid) (err error) {
+	if err = repoData.repoDataLoader.CreateTag(name, oid); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+
+	return
+}
+
+// CreateBranch creates a local branch pointing to the provided commit and reloads refs
+func (repoData *RepositoryData) CreateBranch(name string, oid *Oid) (branch Ref, err error) {
+	if branch, err = repoData.repoDataLoader.CreateBranch(name, oid); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+
+	return
+}
+
+// Checkout checks out the provided ref and reloads refs and status to reflect the new HEAD
+func (repoData *RepositoryData) Checkout(ref Ref) (err error) {
+	if err = repoData.repoDataLoader.Checkout(ref); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// Stashes returns the stash entries of the repository
+func (repoData *RepositoryData) Stashes() ([]*StashEntry, error) {
+	return repoData.repoDataLoader.Stashes()
+}
+
+// StashApply applies the provided stash entry and reloads the status
+func (repoData *RepositoryData) StashApply(stashEntry *StashEntry) (err error) {
+	if err = repoData.repoDataLoader.StashApply(stashEntry); err != nil {
+		return
+	}
+
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// StashDrop removes the provided stash entry
+func (repoData *RepositoryData) StashDrop(stashEntry *StashEntry) error {
+	return repoData.repoDataLoader.StashDrop(stashEntry)
+}
+
+// BisectStart starts a bisect session
+func (repoData *RepositoryData) BisectStart() error {
+	return repoData.repoDataLoader.BisectStart()
+}
+
+// BisectGood marks the provided commit as good and reloads refs and status
+// as bisect checks out the next commit to test
+func (repoData *RepositoryData) BisectGood(oid *Oid) (bisectStep *BisectStep, err error) {
+	if bisectStep, err = repoData.repoDataLoader.BisectGood(oid); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// BisectBad marks the provided commit as bad and reloads refs and status
+// as bisect checks out the next commit to test
+func (repoData *RepositoryData) BisectBad(oid *Oid) (bisectStep *BisectStep, err error) {
+	if bisectStep, err = repoData.repoDataLoader.BisectBad(oid); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// BisectReset ends the bisect session and reloads refs and status
+func (repoData *RepositoryData) BisectReset() (err error) {
+	if err = repoData.repoDataLoader.BisectReset(); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// AmendCommitMessage replaces the message of the HEAD commit and reloads refs and status
+func (repoData *RepositoryData) AmendCommitMessage(message string) (err error) {
+	if err = repoData.repoDataLoader.AmendCommitMessage(message); err != nil {
+		return
+	}
+
+	repoData.LoadRefs(nil)
+	err = repoData.LoadStatus()
+
+	return
+}
+
+// Reflog returns the entries of the HEAD reflog
+func (repoData *RepositoryData) Reflog() (<-chan *ReflogEntry, error) {
+	return repoData.repoDataLoader.Reflog()
+}
+
+// Tree returns the entries of the directory at the provided path in the tree of the commit with the provided oid
+func (repoData *RepositoryData) Tree(oid *Oid, path string) ([]*TreeEntry, error) {
+	return repoData.repoDataLoader.Tree(oid, path)
+}
+
+// FileContent returns the content of the file at the provided path as of the commit with the provided oid
+func (repoData *RepositoryData) FileContent(path string, oid *Oid) ([]byte, error) {
+	return repoData.repoDataLoader.FileContent(path, oid)
+}
+
 // AddCommitFilter adds the filter to the specified ref
 func (repoData *RepositoryData) AddCommitFilter(ref Ref, commitFilter *CommitFilter) error {
 	return repoData.refCommitSets.addCommitFilter(ref, commitFilter)
@@ -1288,20 +1970,25 @@ func (repoData *RepositoryData) RemoveCommitFilter(ref Ref) error {
 
 // DiffCommit loads a diff between the commit with the specified oid and its parent
 // If the commit has more than one parent no diff is returned
-func (repoData *RepositoryData) DiffCommit(commit *Commit) (*Diff, error) {
-	return repoData.repoDataLoader.DiffCommit(commit)
+func (repoData *RepositoryData) DiffCommit(commit *Commit, contextLines uint) (*Diff, error) {
+	return repoData.repoDataLoader.DiffCommit(commit, contextLines)
+}
+
+// DiffRange loads a diff between the commits with the provided oids
+func (repoData *RepositoryData) DiffRange(fromOid, toOid *Oid, contextLines uint) (*Diff, error) {
+	return repoData.repoDataLoader.DiffRange(fromOid, toOid, contextLines)
 }
 
 // DiffFile Generates a diff for the provided file
 // If statusType is StStaged then the diff is between HEAD and the index
 // If statusType is StUnstaged then the diff is between index and the working directory
-func (repoData *RepositoryData) DiffFile(statusType StatusType, path string) (*Diff, error) {
-	return repoData.repoDataLoader.DiffFile(statusType, path)
+func (repoData *RepositoryData) DiffFile(statusType StatusType, path string, contextLines uint) (*Diff, error) {
+	return repoData.repoDataLoader.DiffFile(statusType, path, contextLines)
 }
 
 // DiffStage returns a diff for all files in the provided stage
-func (repoData *RepositoryData) DiffStage(statusType StatusType) (*Diff, error) {
-	return repoData.repoDataLoader.DiffStage(statusType)
+func (repoData *RepositoryData) DiffStage(statusType StatusType, contextLines uint) (*Diff, error) {
+	return repoData.repoDataLoader.DiffStage(statusType, contextLines)
 }
 
 // LoadStatus loads the current git status
@@ -1329,6 +2016,11 @@ func (repoData *RepositoryData) RegisterCommitSetListener(commitSetListener Comm
 	repoData.refCommitSets.registerCommitSetListener(commitSetListener)
 }
 
+// UnregisterCommitSetListener stops the listener from being notified of commitSet events
+func (repoData *RepositoryData) UnregisterCommitSetListener(commitSetListener CommitSetListener) {
+	repoData.refCommitSets.unregisterCommitSetListener(commitSetListener)
+}
+
 // OnHeadChanged does nothing
 func (repoData *RepositoryData) OnHeadChanged(oldHead, newHead Ref) {
 
@@ -1348,6 +2040,8 @@ func (repoData *RepositoryData) addUpdatedRefsToProcessingQueue(updatedRefs []*U
 	for _, updatedRef := range updatedRefs {
 		select {
 		case repoData.refUpdateCh <- updatedRef:
+		case <-repoData.cancelCh:
+			return
 		default:
 			log.Errorf("Unable process UpdatedRef %v", updatedRef)
 		}
@@ -1357,7 +2051,16 @@ func (repoData *RepositoryData) addUpdatedRefsToProcessingQueue(updatedRefs []*U
 func (repoData *RepositoryData) processUpdatedRefs() {
 	log.Info("Starting UpdatedRef processor")
 
-	for updatedRef := range repoData.refUpdateCh {
+	for {
+		var updatedRef *UpdatedRef
+
+		select {
+		case updatedRef = <-repoData.refUpdateCh:
+		case <-repoData.cancelCh:
+			log.Info("Stopping UpdatedRef processor")
+			return
+		}
+
 		oldRef := updatedRef.OldRef
 		newRef := updatedRef.NewRef
 
@@ -1369,7 +2072,7 @@ func (repoData *RepositoryData) processUpdatedRefs() {
 			continue
 		}
 
-		commitCh, err := repoData.repoDataLoader.Commits(newRef.Oid())
+		commitCh, errorCh, err := repoData.repoDataLoader.Commits(newRef.Oid(), repoData.loadOptions(oldRef), nil)
 		if err != nil {
 			log.Errorf("Unable to load commits for range %v: %v", newRef.Name(), err)
 			continue
@@ -1385,6 +2088,13 @@ func (repoData *RepositoryData) processUpdatedRefs() {
 			}
 		}
 
+		select {
+		case err := <-errorCh:
+			log.Errorf("Unable to load commits for ref %v: %v", newRef.Name(), err)
+			continue
+		default:
+		}
+
 		log.Debugf("Updating ref %v with %v commits", newRef.Name(), len(commits))
 		commitSet.Update(commits)
 		repoData.refCommitSets.setCommitSet(newRef, commitSet)
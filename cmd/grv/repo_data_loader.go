@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"os/exec"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	slice "github.com/bradfitz/slice"
@@ -18,8 +25,57 @@ const (
 	rdlCommitBufferSize = 100
 	rdlDiffStatsCols    = 80
 	rdlShortOidLen      = 7
+	rdlBlameBufferSize  = 100
+	rdlBlameMaxLineSize = 16 * 1024 * 1024
+	rdlReflogBufferSize = 100
+	rdlTagRefPrefix     = "refs/tags/"
+	rdlOidHexLen        = 40
+	rdlMinOidPrefixLen  = 4
+	rdlMaxOidCandidates = 5
+	rdlStashRef         = "stash"
+	rdlStashBranchSep   = ": "
+	rdlBisectRefPrefix  = "refs/bisect/"
+	rdlBisectBadRef     = rdlBisectRefPrefix + "bad"
+	rdlBisectGoodPrefix = rdlBisectRefPrefix + "good-"
 )
 
+var errRdlRepositoryFreed = errors.New("Repository has been closed")
+
+var rdlStashMessagePrefixes = []string{"WIP on ", "On "}
+
+var rdlBisectingRegex = regexp.MustCompile(`^Bisecting: (\d+) revisions? left to test after this \(roughly (\d+) steps?\)\s*\n\[([0-9a-f]+)\]`)
+var rdlFirstBadCommitRegex = regexp.MustCompile(`^([0-9a-f]+) is the first bad commit`)
+
+// SignatureStatus is the result of verifying the signature of a commit
+type SignatureStatus int
+
+// The set of signature statuses. Each status other than SsUnknown corresponds
+// to a value of git's %G? format placeholder
+const (
+	SsUnknown SignatureStatus = iota
+	SsNone
+	SsGood
+	SsGoodUnknownValidity
+	SsBad
+	SsExpiredSignature
+	SsExpiredKey
+	SsRevokedKey
+	SsMissingKey
+)
+
+// gitSignatureStatuses maps the output of git's %G? format placeholder to a signature status
+// Any other value means the commit is unsigned
+var gitSignatureStatuses = map[string]SignatureStatus{
+	"G": SsGood,
+	"U": SsGoodUnknownValidity,
+	"B": SsBad,
+	"X": SsExpiredSignature,
+	"Y": SsExpiredKey,
+	"R": SsRevokedKey,
+	"E": SsMissingKey,
+	"N": SsNone,
+}
+
 type instanceCache struct {
 	oids       map[string]*Oid
 	commits    map[string]*Commit
@@ -29,9 +85,13 @@ type instanceCache struct {
 
 // RepoDataLoader handles loading data from the repository
 type RepoDataLoader struct {
-	repo     *git.Repository
-	cache    *instanceCache
-	channels *Channels
+	repo      *git.Repository
+	cache     *instanceCache
+	channels  *Channels
+	cancelCh  chan bool
+	waitGroup sync.WaitGroup
+	freed     bool
+	freeLock  sync.Mutex
 }
 
 // Oid is reference to a git object
@@ -53,6 +113,18 @@ func (oid Oid) String() string {
 	return oid.oid.String()
 }
 
+// AbbreviatedID returns the oid hash shortened to the provided length
+// The length is clamped to the length of the full hash
+func (oid Oid) AbbreviatedID(length int) string {
+	id := oid.String()
+
+	if length < 0 {
+		length = 0
+	}
+
+	return id[0:MinInt(length, len(id))]
+}
+
 // ShortID returns a shortened oid hash
 func (oid Oid) ShortID() (shortID string) {
 	id := oid.String()
@@ -306,6 +378,54 @@ func (head *HEAD) Equal(other Ref) bool {
 	return head.Oid().Equal(otherHead.Oid())
 }
 
+// DetachedHeadDescription returns a description of the commit HEAD is detached at.
+// An empty string is returned if HEAD is not detached
+func DetachedHeadDescription(head Ref) string {
+	if _, isDetached := head.(*HEAD); !isDetached {
+		return ""
+	} else if head.Oid() == nil {
+		return "detached"
+	}
+
+	return fmt.Sprintf("detached at %v", head.Oid().ShortID())
+}
+
+// OidRef refers directly to a commit which doesn't have a ref name.
+// The oid is used as the name so commits of different oids are kept separate
+type OidRef struct {
+	oid *Oid
+}
+
+// NewOidRef creates a ref for the provided oid
+func NewOidRef(oid *Oid) *OidRef {
+	return &OidRef{oid: oid}
+}
+
+// Oid the ref points to
+func (oidRef *OidRef) Oid() *Oid {
+	return oidRef.oid
+}
+
+// Name returns the full oid
+func (oidRef *OidRef) Name() string {
+	return oidRef.oid.String()
+}
+
+// Shorthand returns the shortened oid
+func (oidRef *OidRef) Shorthand() string {
+	return oidRef.oid.ShortID()
+}
+
+// Equal returns true if the other ref is an OidRef for the same oid
+func (oidRef *OidRef) Equal(other Ref) bool {
+	otherOidRef, ok := other.(*OidRef)
+	if !ok {
+		return false
+	}
+
+	return oidRef.oid.Equal(otherOidRef.oid)
+}
+
 // Commit contains data for a commit
 type Commit struct {
 	oid    *Oid
@@ -318,6 +438,105 @@ type Diff struct {
 	stats    bytes.Buffer
 }
 
+// BlameLine is a line of a file along with the commit which last modified it
+// origPath is the path of the file in the commit the line originated in
+type BlameLine struct {
+	oid        *Oid
+	author     *git.Signature
+	lineNumber uint
+	line       string
+	origPath   string
+}
+
+// ReflogEntry is an entry in the HEAD reflog
+type ReflogEntry struct {
+	index     uint
+	oldOid    *Oid
+	newOid    *Oid
+	committer *git.Signature
+	message   string
+}
+
+// Selector returns the reflog selector for this entry, e.g. HEAD@{0}
+func (reflogEntry *ReflogEntry) Selector() string {
+	return fmt.Sprintf("%v@{%v}", RdlHeadRef, reflogEntry.index)
+}
+
+// StashEntry is an entry in the stash list
+type StashEntry struct {
+	index   uint
+	oid     *Oid
+	branch  string
+	message string
+}
+
+// Selector returns the stash selector for this entry, e.g. stash@{0}
+func (stashEntry *StashEntry) Selector() string {
+	return fmt.Sprintf("%v@{%v}", rdlStashRef, stashEntry.index)
+}
+
+// BisectStep describes the state of a bisect after a commit has been marked good or bad
+type BisectStep struct {
+	output        string
+	nextID        string
+	firstBadID    string
+	revisionsLeft uint
+	stepsLeft     uint
+	badID         string
+	goodIDs       []string
+}
+
+// Finished returns true if bisect has identified the first bad commit
+func (bisectStep *BisectStep) Finished() bool {
+	return bisectStep.firstBadID != ""
+}
+
+// DiffStat contains the number of lines added and removed by a commit
+type DiffStat struct {
+	added   uint
+	removed uint
+}
+
+// TreeEntry is an entry of a directory in the tree of a commit
+type TreeEntry struct {
+	name  string
+	oid   *Oid
+	isDir bool
+}
+
+// CommitSortOrder describes the order commits are loaded in
+type CommitSortOrder int
+
+// The supported commit orderings
+const (
+	CsoDate CommitSortOrder = iota
+	CsoTopological
+)
+
+var commitSortOrderSortTypes = map[CommitSortOrder]git.SortType{
+	CsoDate:        git.SortTime,
+	CsoTopological: git.SortTopological | git.SortTime,
+}
+
+var commitSortOrderDisplayNames = map[CommitSortOrder]string{
+	CsoDate:        "date",
+	CsoTopological: "topological",
+}
+
+// CommitSortOrderDisplayName returns the display name of the CommitSortOrder
+func CommitSortOrderDisplayName(commitSortOrder CommitSortOrder) string {
+	return commitSortOrderDisplayNames[commitSortOrder]
+}
+
+// CommitLoadOptions determine how the commit history of a ref is traversed
+// If firstParentOnly is set only the first parent of merge commits is followed
+// If hideMerges is set commits with more than one parent are excluded
+type CommitLoadOptions struct {
+	sortOrder       CommitSortOrder
+	firstParentOnly bool
+	hideMerges      bool
+}
+
 // StatusEntryType describes the type of change a status entry has undergone
 type StatusEntryType int
 
@@ -555,23 +774,78 @@ func (cache *instanceCache) getCachedOid(oidStr string) (oid *Oid, exists bool)
 	return
 }
 
+func (cache *instanceCache) getCachedCommitOidsWithPrefix(prefix string) (oids []*Oid) {
+	cache.commitLock.Lock()
+	defer cache.commitLock.Unlock()
+
+	for oidStr, commit := range cache.commits {
+		if strings.HasPrefix(oidStr, prefix) {
+			oids = append(oids, commit.oid)
+		}
+	}
+
+	return
+}
+
 // NewRepoDataLoader creates a new instance
 func NewRepoDataLoader(channels *Channels) *RepoDataLoader {
 	return &RepoDataLoader{
 		cache:    newInstanceCache(),
 		channels: channels,
+		cancelCh: make(chan bool),
 	}
 }
 
-// Free releases any resources
+// Free cancels any loads in progress, waits for all users of the repository to finish and then releases any resources
 func (repoDataLoader *RepoDataLoader) Free() {
 	log.Info("Freeing RepoDataLoader")
 
+	repoDataLoader.freeLock.Lock()
+	if repoDataLoader.freed {
+		repoDataLoader.freeLock.Unlock()
+		return
+	}
+
+	repoDataLoader.freed = true
+	close(repoDataLoader.cancelCh)
+	repoDataLoader.freeLock.Unlock()
+
+	repoDataLoader.waitGroup.Wait()
+
 	if repoDataLoader.repo != nil {
 		repoDataLoader.repo.Free()
 	}
 }
 
+// acquire prevents the repository from being freed until release is called.
+// False is returned if the repository has already been freed
+func (repoDataLoader *RepoDataLoader) acquire() bool {
+	repoDataLoader.freeLock.Lock()
+	defer repoDataLoader.freeLock.Unlock()
+
+	if repoDataLoader.freed {
+		return false
+	}
+
+	repoDataLoader.waitGroup.Add(1)
+
+	return true
+}
+
+func (repoDataLoader *RepoDataLoader) release() {
+	repoDataLoader.waitGroup.Done()
+}
+
+// stopping returns true if grv is exiting or the repository is being freed
+func (repoDataLoader *RepoDataLoader) stopping() bool {
+	select {
+	case <-repoDataLoader.cancelCh:
+		return true
+	default:
+		return repoDataLoader.channels.Exit()
+	}
+}
+
 // Initialise attempts to access the repository
 func (repoDataLoader *RepoDataLoader) Initialise(repoPath, workTreePath string) error {
 	log.Infof("Opening repository at %v", repoPath)
@@ -596,11 +870,48 @@ func (repoDataLoader *RepoDataLoader) Initialise(repoPath, workTreePath string)
 
 // Path returns the file path location of the repository
 func (repoDataLoader *RepoDataLoader) Path() string {
+	if !repoDataLoader.acquire() {
+		return ""
+	}
+	defer repoDataLoader.release()
+
 	return repoDataLoader.repo.Path()
 }
 
+// Workdir returns the file path location of the working tree of the repository
+// An empty string is returned for bare repositories
+func (repoDataLoader *RepoDataLoader) Workdir() string {
+	if !repoDataLoader.acquire() {
+		return ""
+	}
+	defer repoDataLoader.release()
+
+	return repoDataLoader.repo.Workdir()
+}
+
+// RebaseInProgress returns true if a rebase has been started and not yet completed or aborted
+func (repoDataLoader *RepoDataLoader) RebaseInProgress() bool {
+	if !repoDataLoader.acquire() {
+		return false
+	}
+	defer repoDataLoader.release()
+
+	switch repoDataLoader.repo.State() {
+	case git.RepositoryStateRebase, git.RepositoryStateRebaseInteractive, git.RepositoryStateRebaseMerge:
+		return true
+	}
+
+	return false
+}
+
 // Head loads the current HEAD ref
 func (repoDataLoader *RepoDataLoader) Head() (ref Ref, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
 	log.Debug("Loading HEAD")
 	rawRef, err := repoDataLoader.repo.Head()
 	if err != nil {
@@ -630,6 +941,12 @@ func (repoDataLoader *RepoDataLoader) Head() (ref Ref, err error) {
 
 // LoadRefs loads all branches and tags present in the repository
 func (repoDataLoader *RepoDataLoader) LoadRefs() (refs []Ref, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
 	branches, err := repoDataLoader.loadBranches()
 	if err != nil {
 		return
@@ -743,68 +1060,120 @@ func (repoDataLoader *RepoDataLoader) loadTags() (tags []*Tag, err error) {
 	return
 }
 
-// Commits loads all commits for the provided ref and returns a channel from which the loaded commits can be read
-func (repoDataLoader *RepoDataLoader) Commits(oid *Oid) (<-chan *Commit, error) {
+// Commits loads all commits for the provided ref using the provided options and returns a channel from which the loaded commits can be read
+// Loading stops when cancelCh is closed
+func (repoDataLoader *RepoDataLoader) Commits(oid *Oid, commitLoadOptions CommitLoadOptions, cancelCh <-chan bool) (<-chan *Commit, <-chan error, error) {
+	if !repoDataLoader.acquire() {
+		return nil, nil, errRdlRepositoryFreed
+	}
+	defer repoDataLoader.release()
+
 	revWalk, err := repoDataLoader.repo.Walk()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	revWalk.Sorting(commitSortOrderSortTypes[commitLoadOptions.sortOrder])
+
+	if commitLoadOptions.firstParentOnly {
+		revWalk.SimplifyFirstParent()
 	}
 
 	if err := revWalk.Push(oid.oid); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	log.Debugf("Loading commits for oid %v", oid)
+	log.Debugf("Loading commits for oid %v with options %+v", oid, commitLoadOptions)
+
+	commitCh, errorCh := repoDataLoader.loadCommits(revWalk, commitLoadOptions.hideMerges, cancelCh)
 
-	return repoDataLoader.loadCommits(revWalk), nil
+	return commitCh, errorCh, nil
 }
 
 // CommitRange accepts a range of the form rev..rev and returns a stream of commits in this range
-func (repoDataLoader *RepoDataLoader) CommitRange(commitRange string) (<-chan *Commit, error) {
+func (repoDataLoader *RepoDataLoader) CommitRange(commitRange string) (<-chan *Commit, <-chan error, error) {
+	if !repoDataLoader.acquire() {
+		return nil, nil, errRdlRepositoryFreed
+	}
+	defer repoDataLoader.release()
+
 	revWalk, err := repoDataLoader.repo.Walk()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := revWalk.PushRange(commitRange); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	log.Debugf("Loading commits for range %v", commitRange)
 
-	return repoDataLoader.loadCommits(revWalk), nil
+	commitCh, errorCh := repoDataLoader.loadCommits(revWalk, false, nil)
+
+	return commitCh, errorCh, nil
 }
 
-func (repoDataLoader *RepoDataLoader) loadCommits(revWalk *git.RevWalk) <-chan *Commit {
+// loadCommits streams the commits of the rev walk, skipping merge commits if hideMerges is set.
+// If iterating fails the error is sent on the error channel before the commit channel is closed
+func (repoDataLoader *RepoDataLoader) loadCommits(revWalk *git.RevWalk, hideMerges bool, cancelCh <-chan bool) (<-chan *Commit, <-chan error) {
 	commitCh := make(chan *Commit, rdlCommitBufferSize)
+	errorCh := make(chan error, 1)
+
+	if !repoDataLoader.acquire() {
+		revWalk.Free()
+		close(commitCh)
+		errorCh <- errRdlRepositoryFreed
+		return commitCh, errorCh
+	}
 
 	go func() {
+		defer repoDataLoader.release()
 		defer close(commitCh)
 		defer revWalk.Free()
 
 		commitNum := 0
 
 		if err := revWalk.Iterate(func(commit *git.Commit) bool {
-			if repoDataLoader.channels.Exit() {
+			if repoDataLoader.stopping() {
+				return false
+			}
+
+			if hideMerges && commit.ParentCount() > 1 {
+				return true
+			}
+
+			select {
+			case commitCh <- repoDataLoader.cache.getCommit(commit):
+			case <-cancelCh:
+				log.Debugf("Loading commits cancelled")
+				return false
+			case <-repoDataLoader.cancelCh:
+				log.Debugf("Loading commits cancelled as repository is being freed")
 				return false
 			}
 
 			commitNum++
-			commitCh <- repoDataLoader.cache.getCommit(commit)
 
 			return true
 		}); err != nil {
 			log.Errorf("Error when iterating over commits: %v", err)
+			errorCh <- fmt.Errorf("Error when iterating over commits: %v", err)
 		}
 
 		log.Debugf("Loaded %v commits", commitNum)
 	}()
 
-	return commitCh
+	return commitCh, errorCh
 }
 
 // Commit loads a commit for the provided oid (if it points to a commit)
 func (repoDataLoader *RepoDataLoader) Commit(oid *Oid) (commit *Commit, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
 	if cachedCommit, isCached := repoDataLoader.cache.getCachedCommit(oid); isCached {
 		return cachedCommit, nil
 	}
@@ -867,150 +1236,1194 @@ func (repoDataLoader *RepoDataLoader) CommitByOid(oidStr string) (*Commit, error
 	return repoDataLoader.Commit(oid)
 }
 
-// MergeBase finds the best common ancestor between two commits
-func (repoDataLoader *RepoDataLoader) MergeBase(oid1, oid2 *Oid) (commonAncestor *Oid, err error) {
-	rawOid, err := repoDataLoader.repo.MergeBase(oid1.oid, oid2.oid)
-	if err != nil {
-		err = fmt.Errorf("Unable to find common ancestor for oids %v and %v: %v", oid1, oid2, err)
+// ResolveOid finds the commit the provided full or abbreviated oid string refers to
+// Loaded commits are checked first so that ambiguous prefixes can list the candidates
+func (repoDataLoader *RepoDataLoader) ResolveOid(prefix string) (oid *Oid, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
 	}
+	defer repoDataLoader.release()
 
-	commonAncestor = repoDataLoader.cache.getOid(rawOid)
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
 
-	return
-}
+	if len(prefix) < rdlMinOidPrefixLen || len(prefix) > rdlOidHexLen || strings.Trim(prefix, "0123456789abcdef") != "" {
+		err = fmt.Errorf("Invalid commit id %v: Expected between %v and %v hex characters", prefix, rdlMinOidPrefixLen, rdlOidHexLen)
+		return
+	}
 
-// AheadBehind returns the number of unique commits between two branches
-func (repoDataLoader *RepoDataLoader) AheadBehind(local, upstream *Oid) (ahead, behind int, err error) {
-	return repoDataLoader.repo.AheadBehind(local.oid, upstream.oid)
-}
+	candidates := repoDataLoader.cache.getCachedCommitOidsWithPrefix(prefix)
 
-// DiffCommit loads a diff between the commit with the specified oid and its parent
-// If the commit has more than one parent no diff is returned
-func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit) (diff *Diff, err error) {
-	diff = &Diff{}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	} else if len(candidates) > 1 {
+		var candidateIDs []string
+		for _, candidate := range candidates {
+			candidateIDs = append(candidateIDs, candidate.String()[:len(prefix)+1])
+		}
 
-	if commit.commit.ParentCount() > 1 {
+		sort.Strings(candidateIDs)
+
+		if len(candidateIDs) > rdlMaxOidCandidates {
+			candidateIDs = append(candidateIDs[:rdlMaxOidCandidates], "...")
+		}
+
+		err = fmt.Errorf("Commit id %v is ambiguous. Candidates: %v", prefix, strings.Join(candidateIDs, ", "))
 		return
 	}
 
-	var commitTree, parentTree *git.Tree
-	if commitTree, err = commit.commit.Tree(); err != nil {
+	object, err := repoDataLoader.repo.RevparseSingle(prefix)
+	if err != nil {
+		err = fmt.Errorf("No commit found for id %v", prefix)
 		return
 	}
-	defer commitTree.Free()
 
-	if commit.commit.ParentCount() > 0 {
-		if parentTree, err = commit.commit.Parent(0).Tree(); err != nil {
-			return
-		}
-		defer parentTree.Free()
+	commit, err := repoDataLoader.Commit(repoDataLoader.cache.getOid(object.Id()))
+	if err != nil || commit == nil {
+		err = fmt.Errorf("Object %v is not a commit", prefix)
+		return
 	}
 
-	options, err := git.DefaultDiffOptions()
-	if err != nil {
+	oid = commit.oid
+
+	return
+}
+
+// CreateTag creates a lightweight tag with the provided name pointing to the provided commit
+func (repoDataLoader *RepoDataLoader) CreateTag(name string, oid *Oid) (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
 		return
 	}
+	defer repoDataLoader.release()
 
-	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, &options)
+	if err = ValidateRefName(name); err != nil {
+		return
+	}
+
+	ref, err := repoDataLoader.repo.References.Create(rdlTagRefPrefix+name, oid.oid, false, "")
 	if err != nil {
+		err = fmt.Errorf("Unable to create tag %v: %v", name, err)
 		return
 	}
-	defer commitDiff.Free()
 
-	return repoDataLoader.generateDiff(commitDiff)
-}
+	ref.Free()
+	log.Infof("Created tag %v at commit %v", name, oid)
 
-// DiffStage returns a diff for all files in the provided stage
-func (repoDataLoader *RepoDataLoader) DiffStage(statusType StatusType) (diff *Diff, err error) {
-	diff = &Diff{}
+	return
+}
 
-	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
-	if err != nil || rawDiff == nil {
+// CreateBranch creates a local branch with the provided name pointing to the provided commit
+func (repoDataLoader *RepoDataLoader) CreateBranch(name string, oid *Oid) (branch *LocalBranch, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
 		return
 	}
-	defer rawDiff.Free()
-
-	return repoDataLoader.generateDiff(rawDiff)
-}
+	defer repoDataLoader.release()
 
-// DiffFile Generates a diff for the provided file
-// If statusType is StStaged then the diff is between HEAD and the index
-// If statusType is StUnstaged then the diff is between index and the working directory
-func (repoDataLoader *RepoDataLoader) DiffFile(statusType StatusType, path string) (diff *Diff, err error) {
-	diff = &Diff{}
+	if err = ValidateRefName(name); err != nil {
+		return
+	}
 
-	rawDiff, err := repoDataLoader.generateRawDiff(statusType)
-	if err != nil || rawDiff == nil {
+	commit, err := repoDataLoader.repo.LookupCommit(oid.oid)
+	if err != nil {
+		err = fmt.Errorf("Unable to create branch %v: %v", name, err)
 		return
 	}
-	defer rawDiff.Free()
+	defer commit.Free()
 
-	numDeltas, err := rawDiff.NumDeltas()
+	rawBranch, err := repoDataLoader.repo.CreateBranch(name, commit, false)
 	if err != nil {
+		err = fmt.Errorf("Unable to create branch %v: %v", name, err)
 		return
 	}
+	defer rawBranch.Free()
 
-	var diffDelta git.DiffDelta
-	var patch *git.Patch
-	var patchString string
+	if branch, err = newLocalBranch(oid, rawBranch); err != nil {
+		return
+	}
 
-	for i := 0; i < numDeltas; i++ {
-		if diffDelta, err = rawDiff.GetDelta(i); err != nil {
-			return
-		}
+	log.Infof("Created branch %v at commit %v", name, oid)
 
-		if diffDelta.NewFile.Path == path {
-			if patch, err = rawDiff.Patch(i); err != nil {
-				return
-			}
+	return
+}
 
-			if patchString, err = patch.String(); err != nil {
-				return
-			}
+// Checkout checks out the provided ref using git so that local changes
+// which would be overwritten prevent the checkout in the same way they do on the command line
+func (repoDataLoader *RepoDataLoader) Checkout(ref Ref) (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
 
-			diff.diffText.WriteString(patchString)
+	target := ref.Name()
+	if _, isLocalBranch := ref.(*LocalBranch); isLocalBranch {
+		target = ref.Shorthand()
+	}
 
-			if err := patch.Free(); err != nil {
-				log.Errorf("Error when freeing patch: %v", err)
-			}
+	log.Infof("Checking out %v", target)
 
-			break
-		}
+	if err = repoDataLoader.runGitCommand("checkout", target); err != nil {
+		err = fmt.Errorf("Unable to checkout %v: %v", ref.Shorthand(), err)
 	}
 
 	return
 }
 
-func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType) (rawDiff *git.Diff, err error) {
-	var index *git.Index
-	var options git.DiffOptions
-
-	switch statusType {
-	case StStaged:
-		var head Ref
-		var commit *Commit
-		var tree *git.Tree
+// Stashes returns the stash entries of the repository, most recent first
+func (repoDataLoader *RepoDataLoader) Stashes() (stashEntries []*StashEntry, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
 
-		if head, err = repoDataLoader.Head(); err != nil {
-			return
-		}
+	err = repoDataLoader.repo.Stashes.Foreach(func(index int, message string, id *git.Oid) error {
+		branch, message := parseStashMessage(message)
 
-		if commit, err = repoDataLoader.Commit(head.Oid()); err != nil {
-			return
-		}
+		stashEntries = append(stashEntries, &StashEntry{
+			index:   uint(index),
+			oid:     repoDataLoader.cache.getOid(id),
+			branch:  branch,
+			message: message,
+		})
 
-		if tree, err = commit.commit.Tree(); err != nil {
-			return
-		}
+		return nil
+	})
 
-		if index, err = repoDataLoader.repo.Index(); err != nil {
-			return
-		}
+	if err != nil {
+		err = fmt.Errorf("Unable to load stashes: %v", err)
+	}
+
+	return
+}
+
+// parseStashMessage splits a stash message of the form "WIP on branch: message" or "On branch: message"
+// into the branch the stash was created on and the remaining message
+func parseStashMessage(stashMessage string) (branch, message string) {
+	separatorIndex := strings.Index(stashMessage, rdlStashBranchSep)
+	if separatorIndex == -1 {
+		return "", stashMessage
+	}
+
+	for _, prefix := range rdlStashMessagePrefixes {
+		if strings.HasPrefix(stashMessage, prefix) && len(prefix) <= separatorIndex {
+			return stashMessage[len(prefix):separatorIndex], stashMessage[separatorIndex+len(rdlStashBranchSep):]
+		}
+	}
+
+	return "", stashMessage
+}
+
+// StashApply applies the provided stash entry to the working tree using git
+func (repoDataLoader *RepoDataLoader) StashApply(stashEntry *StashEntry) (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Infof("Applying stash %v", stashEntry.Selector())
+
+	if err = repoDataLoader.runGitCommand("stash", "apply", stashEntry.Selector()); err != nil {
+		err = fmt.Errorf("Unable to apply %v: %v", stashEntry.Selector(), err)
+	}
+
+	return
+}
+
+// StashDrop removes the provided stash entry using git
+func (repoDataLoader *RepoDataLoader) StashDrop(stashEntry *StashEntry) (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Infof("Dropping stash %v", stashEntry.Selector())
+
+	if err = repoDataLoader.runGitCommand("stash", "drop", stashEntry.Selector()); err != nil {
+		err = fmt.Errorf("Unable to drop %v: %v", stashEntry.Selector(), err)
+	}
+
+	return
+}
+
+// BisectStart starts a bisect session using git
+func (repoDataLoader *RepoDataLoader) BisectStart() (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Info("Starting bisect")
+
+	if err = repoDataLoader.runGitCommand("bisect", "start"); err != nil {
+		err = fmt.Errorf("Unable to start bisect: %v", err)
+	}
+
+	return
+}
+
+// BisectGood marks the provided commit as good
+func (repoDataLoader *RepoDataLoader) BisectGood(oid *Oid) (*BisectStep, error) {
+	return repoDataLoader.bisectMark("good", oid)
+}
+
+// BisectBad marks the provided commit as bad
+func (repoDataLoader *RepoDataLoader) BisectBad(oid *Oid) (*BisectStep, error) {
+	return repoDataLoader.bisectMark("bad", oid)
+}
+
+func (repoDataLoader *RepoDataLoader) bisectMark(term string, oid *Oid) (bisectStep *BisectStep, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Infof("Marking commit %v as %v", oid, term)
+
+	output, err := repoDataLoader.runGitCommandWithOutput("bisect", term, oid.String())
+	if err != nil {
+		err = fmt.Errorf("Unable to mark commit %v as %v: %v", oid.ShortID(), term, err)
+		return
+	}
+
+	bisectStep = parseBisectOutput(output)
+
+	refOutput, err := repoDataLoader.runGitCommandWithOutput("for-each-ref", "--format=%(refname) %(objectname)", rdlBisectRefPrefix)
+	if err != nil {
+		err = fmt.Errorf("Unable to load bisect refs: %v", err)
+		return
+	}
+
+	for _, line := range strings.Split(refOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		if fields[0] == rdlBisectBadRef {
+			bisectStep.badID = fields[1]
+		} else if strings.HasPrefix(fields[0], rdlBisectGoodPrefix) {
+			bisectStep.goodIDs = append(bisectStep.goodIDs, fields[1])
+		}
+	}
+
+	return
+}
+
+// parseBisectOutput extracts the next commit to test or the first bad commit from the output of git bisect
+func parseBisectOutput(output string) (bisectStep *BisectStep) {
+	output = strings.TrimSpace(output)
+	bisectStep = &BisectStep{
+		output: strings.SplitN(output, "\n", 2)[0],
+	}
+
+	if matches := rdlBisectingRegex.FindStringSubmatch(output); matches != nil {
+		revisionsLeft, _ := strconv.ParseUint(matches[1], 10, 32)
+		stepsLeft, _ := strconv.ParseUint(matches[2], 10, 32)
+
+		bisectStep.revisionsLeft = uint(revisionsLeft)
+		bisectStep.stepsLeft = uint(stepsLeft)
+		bisectStep.nextID = matches[3]
+	} else if matches := rdlFirstBadCommitRegex.FindStringSubmatch(output); matches != nil {
+		bisectStep.firstBadID = matches[1]
+	}
+
+	return
+}
+
+// BisectReset ends the bisect session and returns to the commit checked out before it started
+func (repoDataLoader *RepoDataLoader) BisectReset() (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Info("Resetting bisect")
+
+	if err = repoDataLoader.runGitCommand("bisect", "reset"); err != nil {
+		err = fmt.Errorf("Unable to reset bisect: %v", err)
+	}
+
+	return
+}
+
+// AmendCommitMessage replaces the message of the HEAD commit with the provided message.
+// Only the message is changed, any staged changes are not included in the amended commit.
+// Lines starting with # are part of the message and are preserved, only surrounding whitespace is cleaned up
+func (repoDataLoader *RepoDataLoader) AmendCommitMessage(message string) (err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	log.Info("Amending HEAD commit message")
+
+	if err = repoDataLoader.runGitCommand("commit", "--amend", "--only", "--cleanup=whitespace", "-m", message); err != nil {
+		err = fmt.Errorf("Unable to amend commit: %v", err)
+	}
+
+	return
+}
+
+// VerifyCommit checks the signature of the provided commit using git
+func (repoDataLoader *RepoDataLoader) VerifyCommit(oid *Oid) (status SignatureStatus, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	cmd := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "log", "-1", "--format=%G?", oid.String())
+
+	output, err := cmd.Output()
+	if err != nil {
+		err = fmt.Errorf("Unable to verify signature of commit %v: %v", oid.ShortID(), err)
+		return
+	}
+
+	status, ok := gitSignatureStatuses[strings.TrimSpace(string(output))]
+	if !ok {
+		status = SsNone
+	}
+
+	log.Debugf("Signature status of commit %v: %v", oid, status)
+
+	return
+}
+
+// runGitCommand runs git with the provided arguments against the working tree of the repository
+// If the command fails the output of git is returned as the error
+func (repoDataLoader *RepoDataLoader) runGitCommand(args ...string) (err error) {
+	_, err = repoDataLoader.runGitCommandWithOutput(args...)
+	return
+}
+
+// runGitCommandWithOutput behaves like runGitCommand but also returns the output of git
+func (repoDataLoader *RepoDataLoader) runGitCommandWithOutput(args ...string) (output string, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	repo := repoDataLoader.repo
+
+	if repo.IsBare() {
+		err = fmt.Errorf("Repository has no working tree")
+		return
+	}
+
+	cmd := exec.Command("git", append([]string{"--git-dir", repo.Path(), "--work-tree", repo.Workdir()}, args...)...)
+	cmd.Dir = repo.Workdir()
+
+	rawOutput, cmdErr := cmd.CombinedOutput()
+	output = string(rawOutput)
+
+	if cmdErr != nil {
+		err = errors.New(strings.TrimSpace(output))
+	}
+
+	return
+}
+
+// MergeBase finds the best common ancestor between two commits
+func (repoDataLoader *RepoDataLoader) MergeBase(oid1, oid2 *Oid) (commonAncestor *Oid, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	rawOid, err := repoDataLoader.repo.MergeBase(oid1.oid, oid2.oid)
+	if err != nil {
+		err = fmt.Errorf("Unable to find common ancestor for oids %v and %v: %v", oid1, oid2, err)
+	}
+
+	commonAncestor = repoDataLoader.cache.getOid(rawOid)
+
+	return
+}
+
+// AheadBehind returns the number of unique commits between two branches
+func (repoDataLoader *RepoDataLoader) AheadBehind(local, upstream *Oid) (ahead, behind int, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	return repoDataLoader.repo.AheadBehind(local.oid, upstream.oid)
+}
+
+// CommitParentIDs returns the ids of the parents of the provided commit
+func (repoDataLoader *RepoDataLoader) CommitParentIDs(commit *Commit) (parentIDs []*Oid) {
+	parentCount := commit.commit.ParentCount()
+
+	for parentIndex := uint(0); parentIndex < parentCount; parentIndex++ {
+		parentIDs = append(parentIDs, repoDataLoader.cache.getOid(commit.commit.ParentId(parentIndex)))
+	}
+
+	return
+}
+
+// CommitParentCount returns the number of parents the provided commit has
+func (repoDataLoader *RepoDataLoader) CommitParentCount(commit *Commit) uint {
+	return commit.commit.ParentCount()
+}
+
+// Blame generates blame information for the file at the provided path as of the commit with the provided oid
+// Blaming large files can take some time so lines are returned on a channel once the blame has been generated
+// If followRenames is set lines moved or copied from other files are attributed to the commit they originated in.
+// libgit2 does not implement move and copy tracking so in this case the blame is generated by git
+func (repoDataLoader *RepoDataLoader) Blame(path string, oid *Oid, followRenames bool) (<-chan *BlameLine, error) {
+	if !repoDataLoader.acquire() {
+		return nil, errRdlRepositoryFreed
+	}
+	defer repoDataLoader.release()
+
+	commit, err := repoDataLoader.Commit(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	treeEntry, err := tree.EntryByPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find file %v in commit %v: %v", path, oid, err)
+	}
+
+	if followRenames {
+		return repoDataLoader.gitBlame(path, oid)
+	}
+
+	blob, err := repoDataLoader.repo.LookupBlob(treeEntry.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := git.DefaultBlameOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	options.NewestCommit = oid.oid
+
+	if !repoDataLoader.acquire() {
+		blob.Free()
+		return nil, errRdlRepositoryFreed
+	}
+
+	blameLineCh := make(chan *BlameLine, rdlBlameBufferSize)
+
+	go func() {
+		defer repoDataLoader.release()
+		defer close(blameLineCh)
+		defer blob.Free()
+
+		log.Debugf("Generating blame for file %v at commit %v", path, oid)
+
+		blame, err := repoDataLoader.repo.BlameFile(path, &options)
+		if err != nil {
+			repoDataLoader.channels.ReportError(fmt.Errorf("Unable to blame file %v: %v", path, err))
+			return
+		}
+		defer blame.Free()
+
+		scanner := bufio.NewScanner(bytes.NewReader(blob.Contents()))
+		scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), rdlBlameMaxLineSize)
+		lineNumber := uint(0)
+
+		for scanner.Scan() {
+			if repoDataLoader.stopping() {
+				return
+			}
+
+			lineNumber++
+
+			hunk, err := blame.HunkByLine(int(lineNumber))
+			if err != nil {
+				repoDataLoader.channels.ReportError(fmt.Errorf("Unable to determine blame for line %v of file %v: %v", lineNumber, path, err))
+				return
+			}
+
+			select {
+			case blameLineCh <- &BlameLine{
+				oid:        repoDataLoader.cache.getOid(hunk.FinalCommitId),
+				author:     hunk.FinalSignature,
+				lineNumber: lineNumber,
+				line:       scanner.Text(),
+				origPath:   hunk.OrigPath,
+			}:
+			case <-repoDataLoader.cancelCh:
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			repoDataLoader.channels.ReportError(fmt.Errorf("Unable to read line %v of file %v: %v", lineNumber+1, path, err))
+			return
+		}
+
+		log.Debugf("Generated blame for %v lines of file %v", lineNumber, path)
+	}()
+
+	return blameLineCh, nil
+}
+
+// gitBlame generates blame information using git blame with move and copy detection enabled.
+// The line porcelain output of git is parsed and each line is returned on the channel as it is read
+func (repoDataLoader *RepoDataLoader) gitBlame(path string, oid *Oid) (<-chan *BlameLine, error) {
+	cmd := exec.Command("git", "--git-dir", repoDataLoader.repo.Path(), "blame", "--line-porcelain", "-M", "-C", oid.String(), "--", path)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if !repoDataLoader.acquire() {
+		return nil, errRdlRepositoryFreed
+	}
+
+	if err = cmd.Start(); err != nil {
+		repoDataLoader.release()
+		return nil, fmt.Errorf("Unable to run git blame: %v", err)
+	}
+
+	blameLineCh := make(chan *BlameLine, rdlBlameBufferSize)
+
+	go func() {
+		defer repoDataLoader.release()
+		defer close(blameLineCh)
+
+		log.Debugf("Generating blame for file %v at commit %v using git", path, oid)
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), rdlBlameMaxLineSize)
+		parser := newBlamePorcelainParser(repoDataLoader.cache)
+
+		for scanner.Scan() {
+			if repoDataLoader.stopping() {
+				if err := cmd.Process.Kill(); err != nil {
+					log.Errorf("Unable to kill git blame: %v", err)
+				}
+
+				break
+			}
+
+			blameLine := parser.parseLine(scanner.Text())
+			if blameLine == nil {
+				continue
+			}
+
+			select {
+			case blameLineCh <- blameLine:
+			case <-repoDataLoader.cancelCh:
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			repoDataLoader.channels.ReportError(fmt.Errorf("Unable to read line %v of file %v: %v", parser.lineNumber+1, path, err))
+
+			if err := cmd.Process.Kill(); err != nil {
+				log.Errorf("Unable to kill git blame: %v", err)
+			}
+
+			if err := cmd.Wait(); err != nil {
+				log.Debugf("git blame exited: %v", err)
+			}
+
+			return
+		}
+
+		if err := cmd.Wait(); err != nil && !repoDataLoader.stopping() {
+			repoDataLoader.channels.ReportError(fmt.Errorf("Unable to blame file %v: %v", path, strings.TrimSpace(stderr.String())))
+			return
+		}
+
+		log.Debugf("Generated blame for %v lines of file %v", parser.lineNumber, path)
+	}()
+
+	return blameLineCh, nil
+}
+
+// blamePorcelainParser builds blame lines from the line porcelain output of git blame
+type blamePorcelainParser struct {
+	cache          *instanceCache
+	blameLine      *BlameLine
+	headerExpected bool
+	lineNumber     uint
+}
+
+func newBlamePorcelainParser(cache *instanceCache) *blamePorcelainParser {
+	return &blamePorcelainParser{
+		cache:          cache,
+		blameLine:      &BlameLine{author: &git.Signature{}},
+		headerExpected: true,
+	}
+}
+
+// parseLine processes a line of porcelain output
+// The blame line is returned once its content line has been read, otherwise nil is returned
+func (parser *blamePorcelainParser) parseLine(text string) (blameLine *BlameLine) {
+	switch {
+	case parser.headerExpected:
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			return
+		}
+
+		rawOid, err := git.NewOid(fields[0])
+		if err != nil {
+			log.Errorf("Invalid blame header %q: %v", text, err)
+			return
+		}
+
+		parser.blameLine.oid = parser.cache.getOid(rawOid)
+		parser.headerExpected = false
+	case strings.HasPrefix(text, "\t"):
+		parser.lineNumber++
+		blameLine = parser.blameLine
+		blameLine.lineNumber = parser.lineNumber
+		blameLine.line = text[1:]
+
+		parser.blameLine = &BlameLine{author: &git.Signature{}}
+		parser.headerExpected = true
+	default:
+		applyBlamePorcelainField(parser.blameLine, text)
+	}
+
+	return
+}
+
+// applyBlamePorcelainField sets the author or original path of the blame line from a field of git blame porcelain output
+func applyBlamePorcelainField(blameLine *BlameLine, text string) {
+	fieldParts := strings.SplitN(text, " ", 2)
+	if len(fieldParts) != 2 {
+		return
+	}
+
+	key, value := fieldParts[0], fieldParts[1]
+
+	switch key {
+	case "author":
+		blameLine.author.Name = value
+	case "author-mail":
+		blameLine.author.Email = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+	case "author-time":
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			blameLine.author.When = time.Unix(seconds, 0).In(blameLine.author.When.Location())
+		}
+	case "author-tz":
+		if offset, ok := parseTimezoneOffset(value); ok {
+			blameLine.author.When = blameLine.author.When.In(time.FixedZone(value, offset))
+		}
+	case "filename":
+		if unquotedPath, err := strconv.Unquote(value); err == nil {
+			value = unquotedPath
+		}
+
+		blameLine.origPath = value
+	}
+}
+
+// parseTimezoneOffset converts a timezone offset of the form +hhmm into seconds east of UTC
+func parseTimezoneOffset(timezone string) (offset int, ok bool) {
+	if len(timezone) != 5 || (timezone[0] != '+' && timezone[0] != '-') {
+		return
+	}
+
+	hours, err := strconv.Atoi(timezone[1:3])
+	if err != nil {
+		return
+	}
+
+	minutes, err := strconv.Atoi(timezone[3:])
+	if err != nil {
+		return
+	}
+
+	offset = hours*3600 + minutes*60
+	if timezone[0] == '-' {
+		offset = -offset
+	}
+
+	return offset, true
+}
+
+// Reflog returns the entries of the HEAD reflog, most recent first
+// Entries are streamed so that large reflogs can be displayed as they are read
+func (repoDataLoader *RepoDataLoader) Reflog() (<-chan *ReflogEntry, error) {
+	if !repoDataLoader.acquire() {
+		return nil, errRdlRepositoryFreed
+	}
+	defer repoDataLoader.release()
+
+	reflog, err := repoDataLoader.repo.ReadReflog(RdlHeadRef)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read reflog for %v: %v", RdlHeadRef, err)
+	}
+
+	if !repoDataLoader.acquire() {
+		reflog.Free()
+		return nil, errRdlRepositoryFreed
+	}
+
+	reflogEntryCh := make(chan *ReflogEntry, rdlReflogBufferSize)
+
+	go func() {
+		defer repoDataLoader.release()
+		defer close(reflogEntryCh)
+		defer reflog.Free()
+
+		entryCount := reflog.EntryCount()
+		log.Debugf("Loading %v reflog entries for %v", entryCount, RdlHeadRef)
+
+		for index := uint(0); index < entryCount; index++ {
+			if repoDataLoader.stopping() {
+				return
+			}
+
+			rawEntry := reflog.EntryByIndex(index)
+
+			select {
+			case reflogEntryCh <- &ReflogEntry{
+				index:     index,
+				oldOid:    repoDataLoader.cache.getOid(rawEntry.Old),
+				newOid:    repoDataLoader.cache.getOid(rawEntry.New),
+				committer: rawEntry.Committer,
+				message:   rawEntry.Message,
+			}:
+			case <-repoDataLoader.cancelCh:
+				return
+			}
+		}
+	}()
+
+	return reflogEntryCh, nil
+}
+
+// Tree returns the entries of the directory at the provided path in the tree of the commit with the provided oid
+// An empty path returns the entries of the root directory. Directories are ordered before files
+func (repoDataLoader *RepoDataLoader) Tree(oid *Oid, path string) (treeEntries []*TreeEntry, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	commit, err := repoDataLoader.Commit(oid)
+	if err != nil {
+		return
+	}
+
+	tree, err := commit.commit.Tree()
+	if err != nil {
+		return
+	}
+	defer tree.Free()
+
+	if path != "" {
+		var treeEntry *git.TreeEntry
+		if treeEntry, err = tree.EntryByPath(path); err != nil {
+			err = fmt.Errorf("Unable to find directory %v in commit %v: %v", path, oid, err)
+			return
+		} else if treeEntry.Type != git.ObjectTree {
+			err = fmt.Errorf("%v is not a directory in commit %v", path, oid)
+			return
+		}
+
+		var subTree *git.Tree
+		if subTree, err = repoDataLoader.repo.LookupTree(treeEntry.Id); err != nil {
+			return
+		}
+		defer subTree.Free()
+
+		tree = subTree
+	}
+
+	entryCount := tree.EntryCount()
+
+	for index := uint64(0); index < entryCount; index++ {
+		rawEntry := tree.EntryByIndex(index)
+
+		treeEntries = append(treeEntries, &TreeEntry{
+			name:  rawEntry.Name,
+			oid:   repoDataLoader.cache.getOid(rawEntry.Id),
+			isDir: rawEntry.Type == git.ObjectTree,
+		})
+	}
+
+	sort.SliceStable(treeEntries, func(i, j int) bool {
+		if treeEntries[i].isDir != treeEntries[j].isDir {
+			return treeEntries[i].isDir
+		}
+
+		return treeEntries[i].name < treeEntries[j].name
+	})
+
+	log.Debugf("Loaded %v tree entries for path \"%v\" in commit %v", len(treeEntries), path, oid)
+
+	return
+}
+
+// FileContent loads the content of the file at the provided path as of the commit with the provided oid
+func (repoDataLoader *RepoDataLoader) FileContent(path string, oid *Oid) (content []byte, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	commit, err := repoDataLoader.Commit(oid)
+	if err != nil {
+		return
+	}
+
+	tree, err := commit.commit.Tree()
+	if err != nil {
+		return
+	}
+	defer tree.Free()
+
+	treeEntry, err := tree.EntryByPath(path)
+	if err != nil {
+		err = fmt.Errorf("Unable to find file %v in commit %v: %v", path, oid, err)
+		return
+	} else if treeEntry.Type != git.ObjectBlob {
+		err = fmt.Errorf("%v is not a file in commit %v", path, oid)
+		return
+	}
+
+	blob, err := repoDataLoader.repo.LookupBlob(treeEntry.Id)
+	if err != nil {
+		return
+	}
+	defer blob.Free()
+
+	content = blob.Contents()
+	log.Debugf("Loaded %v bytes for file %v in commit %v", len(content), path, oid)
+
+	return
+}
+
+// DiffCommit loads a diff between the commit with the specified oid and its parent
+// with the provided number of context lines around each change.
+// If the commit has more than one parent no diff is returned
+func (repoDataLoader *RepoDataLoader) DiffCommit(commit *Commit, contextLines uint) (diff *Diff, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	diff = &Diff{}
+
+	if commit.commit.ParentCount() > 1 {
+		return
+	}
+
+	var commitTree, parentTree *git.Tree
+	if commitTree, err = commit.commit.Tree(); err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	if commit.commit.ParentCount() > 0 {
+		if parentTree, err = commit.commit.Parent(0).Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	options.ContextLines = uint32(contextLines)
+
+	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, &options)
+	if err != nil {
+		return
+	}
+	defer commitDiff.Free()
+
+	return repoDataLoader.generateDiff(commitDiff)
+}
+
+// DiffRange loads a diff between the trees of the commits with the provided oids
+func (repoDataLoader *RepoDataLoader) DiffRange(fromOid, toOid *Oid, contextLines uint) (diff *Diff, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	fromCommit, err := repoDataLoader.Commit(fromOid)
+	if err != nil {
+		return
+	}
+
+	toCommit, err := repoDataLoader.Commit(toOid)
+	if err != nil {
+		return
+	}
+
+	var fromTree, toTree *git.Tree
+	if fromTree, err = fromCommit.commit.Tree(); err != nil {
+		return
+	}
+	defer fromTree.Free()
+
+	if toTree, err = toCommit.commit.Tree(); err != nil {
+		return
+	}
+	defer toTree.Free()
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	options.ContextLines = uint32(contextLines)
+
+	rangeDiff, err := repoDataLoader.repo.DiffTreeToTree(fromTree, toTree, &options)
+	if err != nil {
+		return
+	}
+	defer rangeDiff.Free()
+
+	return repoDataLoader.generateDiff(rangeDiff)
+}
+
+// DiffStat returns the number of lines added and removed by the commit with the provided oid.
+// Merge commits are compared against their first parent
+func (repoDataLoader *RepoDataLoader) DiffStat(oid *Oid) (diffStat *DiffStat, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	commit, err := repoDataLoader.Commit(oid)
+	if err != nil {
+		return
+	}
+
+	var commitTree, parentTree *git.Tree
+	if commitTree, err = commit.commit.Tree(); err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	if commit.commit.ParentCount() > 0 {
+		parent := commit.commit.Parent(0)
+		defer parent.Free()
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, &options)
+	if err != nil {
+		return
+	}
+	defer commitDiff.Free()
+
+	stats, err := commitDiff.Stats()
+	if err != nil {
+		return
+	}
+	defer stats.Free()
+
+	diffStat = &DiffStat{
+		added:   uint(stats.Insertions()),
+		removed: uint(stats.Deletions()),
+	}
+
+	return
+}
+
+// CommitModifiesPath returns true if the entry at the provided path differs between
+// the commit and its first parent. Root commits modify every path they contain
+func (repoDataLoader *RepoDataLoader) CommitModifiesPath(commit *Commit, path string) (modified bool, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	commitTree, err := commit.commit.Tree()
+	if err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	var parentTree *git.Tree
+	if commit.commit.ParentCount() > 0 {
+		parent := commit.commit.Parent(0)
+		defer parent.Free()
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	commitEntryID := treeEntryID(commitTree, path)
+	parentEntryID := treeEntryID(parentTree, path)
+
+	switch {
+	case commitEntryID == nil || parentEntryID == nil:
+		modified = commitEntryID != parentEntryID
+	default:
+		modified = !commitEntryID.Equal(parentEntryID)
+	}
+
+	return
+}
+
+// treeEntryID returns the id of the entry at the provided path or nil if no such entry exists
+func treeEntryID(tree *git.Tree, path string) *git.Oid {
+	if tree == nil {
+		return nil
+	}
+
+	treeEntry, err := tree.EntryByPath(path)
+	if err != nil {
+		return nil
+	}
+
+	return treeEntry.Id
+}
+
+// DiffStage returns a diff for all files in the provided stage
+func (repoDataLoader *RepoDataLoader) DiffStage(statusType StatusType, contextLines uint) (diff *Diff, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	diff = &Diff{}
+
+	rawDiff, err := repoDataLoader.generateRawDiff(statusType, contextLines)
+	if err != nil || rawDiff == nil {
+		return
+	}
+	defer rawDiff.Free()
+
+	return repoDataLoader.generateDiff(rawDiff)
+}
+
+// DiffFile Generates a diff for the provided file
+// If statusType is StStaged then the diff is between HEAD and the index
+// If statusType is StUnstaged then the diff is between index and the working directory
+func (repoDataLoader *RepoDataLoader) DiffFile(statusType StatusType, path string, contextLines uint) (diff *Diff, err error) {
+	if !repoDataLoader.acquire() {
+		err = errRdlRepositoryFreed
+		return
+	}
+	defer repoDataLoader.release()
+
+	diff = &Diff{}
+
+	rawDiff, err := repoDataLoader.generateRawDiff(statusType, contextLines)
+	if err != nil || rawDiff == nil {
+		return
+	}
+	defer rawDiff.Free()
+
+	numDeltas, err := rawDiff.NumDeltas()
+	if err != nil {
+		return
+	}
+
+	var diffDelta git.DiffDelta
+	var patch *git.Patch
+	var patchString string
+
+	for i := 0; i < numDeltas; i++ {
+		if diffDelta, err = rawDiff.GetDelta(i); err != nil {
+			return
+		}
+
+		if diffDelta.NewFile.Path == path {
+			if patch, err = rawDiff.Patch(i); err != nil {
+				return
+			}
+
+			if patchString, err = patch.String(); err != nil {
+				return
+			}
+
+			diff.diffText.WriteString(patchString)
+
+			if err := patch.Free(); err != nil {
+				log.Errorf("Error when freeing patch: %v", err)
+			}
+
+			break
+		}
+	}
+
+	return
+}
+
+func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType, contextLines uint) (rawDiff *git.Diff, err error) {
+	var index *git.Index
+	var options git.DiffOptions
+
+	switch statusType {
+	case StStaged:
+		var head Ref
+		var commit *Commit
+		var tree *git.Tree
+
+		if head, err = repoDataLoader.Head(); err != nil {
+			return
+		}
+
+		if commit, err = repoDataLoader.Commit(head.Oid()); err != nil {
+			return
+		}
+
+		if tree, err = commit.commit.Tree(); err != nil {
+			return
+		}
+
+		if index, err = repoDataLoader.repo.Index(); err != nil {
+			return
+		}
 
 		if options, err = git.DefaultDiffOptions(); err != nil {
 			return
 		}
 
+		options.ContextLines = uint32(contextLines)
+
 		if rawDiff, err = repoDataLoader.repo.DiffTreeToIndex(tree, index, &options); err != nil {
 			return
 		}
@@ -1023,6 +2436,8 @@ func (repoDataLoader *RepoDataLoader) generateRawDiff(statusType StatusType) (ra
 			return
 		}
 
+		options.ContextLines = uint32(contextLines)
+
 		if rawDiff, err = repoDataLoader.repo.DiffIndexToWorkdir(index, &options); err != nil {
 			return
 		}
@@ -1075,6 +2490,11 @@ func (repoDataLoader *RepoDataLoader) generateDiff(rawDiff *git.Diff) (diff *Dif
 
 // LoadStatus loads git status and populates a Status instance with the data
 func (repoDataLoader *RepoDataLoader) LoadStatus() (*Status, error) {
+	if !repoDataLoader.acquire() {
+		return nil, errRdlRepositoryFreed
+	}
+	defer repoDataLoader.release()
+
 	log.Debug("Loading git status")
 
 	statusOptions := git.StatusOptions{
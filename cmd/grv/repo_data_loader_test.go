@@ -0,0 +1,200 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	testBlameOid       = "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5"
+	testBlameParentOid = "8fb4d487fb5d2550f05da4e9c9af4bf10e8e0a01"
+)
+
+func porcelainBlameLines(porcelain string) (parser *blamePorcelainParser, blameLines []*BlameLine) {
+	parser = newBlamePorcelainParser(newInstanceCache())
+
+	for _, text := range strings.Split(porcelain, "\n") {
+		if blameLine := parser.parseLine(text); blameLine != nil {
+			blameLines = append(blameLines, blameLine)
+		}
+	}
+
+	return
+}
+
+func TestBlamePorcelainIsParsed(t *testing.T) {
+	type expectedBlameLine struct {
+		oid        string
+		name       string
+		email      string
+		when       time.Time
+		lineNumber uint
+		line       string
+		origPath   string
+	}
+
+	when := time.Date(2018, 6, 1, 10, 30, 0, 0, time.FixedZone("+0130", 5400))
+
+	var blamePorcelainTests = []struct {
+		name               string
+		porcelain          string
+		expectedBlameLines []expectedBlameLine
+	}{
+		{
+			name: "Header with line count",
+			porcelain: testBlameOid + " 1 1 1\n" +
+				"author Test Author\n" +
+				"author-mail <test@example.com>\n" +
+				"author-time 1527843600\n" +
+				"author-tz +0130\n" +
+				"summary Add main\n" +
+				"filename main.go\n" +
+				"\tpackage main",
+			expectedBlameLines: []expectedBlameLine{
+				{oid: testBlameOid, name: "Test Author", email: "test@example.com", when: when, lineNumber: 1, line: "package main", origPath: "main.go"},
+			},
+		},
+		{
+			name: "Repeated commit with header without line count",
+			porcelain: testBlameOid + " 1 1 2\n" +
+				"author Test Author\n" +
+				"filename main.go\n" +
+				"\tpackage main\n" +
+				testBlameOid + " 2 2\n" +
+				"author Test Author\n" +
+				"filename main.go\n" +
+				"\t",
+			expectedBlameLines: []expectedBlameLine{
+				{oid: testBlameOid, name: "Test Author", lineNumber: 1, line: "package main", origPath: "main.go"},
+				{oid: testBlameOid, name: "Test Author", lineNumber: 2, line: "", origPath: "main.go"},
+			},
+		},
+		{
+			name: "Previous field does not set the original path",
+			porcelain: testBlameOid + " 3 1 1\n" +
+				"previous " + testBlameParentOid + " old.go\n" +
+				"filename moved.go\n" +
+				"\tfunc main() {}",
+			expectedBlameLines: []expectedBlameLine{
+				{oid: testBlameOid, lineNumber: 1, line: "func main() {}", origPath: "moved.go"},
+			},
+		},
+		{
+			name: "Quoted filename is unquoted",
+			porcelain: "\n" +
+				testBlameOid + " 1 1 1\n" +
+				"filename \"dir/tab\\tname.go\"\n" +
+				"\t// comment",
+			expectedBlameLines: []expectedBlameLine{
+				{oid: testBlameOid, lineNumber: 1, line: "// comment", origPath: "dir/tab\tname.go"},
+			},
+		},
+		{
+			name:      "Invalid header is ignored",
+			porcelain: "not-an-oid 1 1 1",
+		},
+	}
+
+	for _, blamePorcelainTest := range blamePorcelainTests {
+		_, blameLines := porcelainBlameLines(blamePorcelainTest.porcelain)
+
+		if len(blameLines) != len(blamePorcelainTest.expectedBlameLines) {
+			t.Errorf("%v: Expected %v blame lines but found %v", blamePorcelainTest.name, len(blamePorcelainTest.expectedBlameLines), len(blameLines))
+			continue
+		}
+
+		for index, blameLine := range blameLines {
+			expected := blamePorcelainTest.expectedBlameLines[index]
+
+			switch {
+			case blameLine.oid.String() != expected.oid:
+				t.Errorf("%v: Expected line %v to have oid %v but found %v", blamePorcelainTest.name, index, expected.oid, blameLine.oid)
+			case blameLine.author.Name != expected.name || blameLine.author.Email != expected.email:
+				t.Errorf("%v: Expected line %v to have author %v <%v> but found %v <%v>", blamePorcelainTest.name, index,
+					expected.name, expected.email, blameLine.author.Name, blameLine.author.Email)
+			case !blameLine.author.When.Equal(expected.when):
+				t.Errorf("%v: Expected line %v to have author time %v but found %v", blamePorcelainTest.name, index, expected.when, blameLine.author.When)
+			case blameLine.lineNumber != expected.lineNumber || blameLine.line != expected.line:
+				t.Errorf("%v: Expected line %v to be %v:%q but found %v:%q", blamePorcelainTest.name, index,
+					expected.lineNumber, expected.line, blameLine.lineNumber, blameLine.line)
+			case blameLine.origPath != expected.origPath:
+				t.Errorf("%v: Expected line %v to have original path %v but found %v", blamePorcelainTest.name, index, expected.origPath, blameLine.origPath)
+			}
+		}
+	}
+}
+
+func TestRepeatedBlameCommitsShareOid(t *testing.T) {
+	parser, blameLines := porcelainBlameLines(testBlameOid + " 1 1 2\n\tfirst\n" + testBlameOid + " 2 2\n\tsecond")
+
+	if len(blameLines) != 2 {
+		t.Fatalf("Expected 2 blame lines but found %v", len(blameLines))
+	}
+
+	if blameLines[0].oid != blameLines[1].oid {
+		t.Errorf("Expected lines of the same commit to share an oid instance")
+	}
+
+	if blameLines[0].author == blameLines[1].author {
+		t.Errorf("Expected each blame line to have its own author")
+	}
+
+	if parser.lineNumber != 2 {
+		t.Errorf("Expected 2 lines to have been parsed but found %v", parser.lineNumber)
+	}
+}
+
+func TestAuthorTimeIsInAuthorTimezone(t *testing.T) {
+	var authorTimezoneTests = []struct {
+		timezone       string
+		expectedOffset int
+	}{
+		{timezone: "+0000", expectedOffset: 0},
+		{timezone: "+0530", expectedOffset: 19800},
+		{timezone: "-0800", expectedOffset: -28800},
+		{timezone: "0800", expectedOffset: 0},
+	}
+
+	for _, authorTimezoneTest := range authorTimezoneTests {
+		blameLine := &BlameLine{author: &git.Signature{}}
+		applyBlamePorcelainField(blameLine, "author-time 1527843600")
+		applyBlamePorcelainField(blameLine, "author-tz "+authorTimezoneTest.timezone)
+
+		if unix := blameLine.author.When.Unix(); unix != 1527843600 {
+			t.Errorf("Expected author time to remain 1527843600 for timezone %v but found %v", authorTimezoneTest.timezone, unix)
+		}
+
+		if _, offset := blameLine.author.When.Zone(); offset != authorTimezoneTest.expectedOffset {
+			t.Errorf("Expected offset %v for timezone %v but found %v", authorTimezoneTest.expectedOffset, authorTimezoneTest.timezone, offset)
+		}
+	}
+}
+
+func TestTimezoneOffsetIsParsed(t *testing.T) {
+	var timezoneOffsetTests = []struct {
+		timezone       string
+		expectedOffset int
+		expectedOk     bool
+	}{
+		{timezone: "+0000", expectedOffset: 0, expectedOk: true},
+		{timezone: "+0130", expectedOffset: 5400, expectedOk: true},
+		{timezone: "-0800", expectedOffset: -28800, expectedOk: true},
+		{timezone: "-0045", expectedOffset: -2700, expectedOk: true},
+		{timezone: "0100", expectedOk: false},
+		{timezone: "+01", expectedOk: false},
+		{timezone: "+01a0", expectedOk: false},
+		{timezone: "", expectedOk: false},
+	}
+
+	for _, timezoneOffsetTest := range timezoneOffsetTests {
+		offset, ok := parseTimezoneOffset(timezoneOffsetTest.timezone)
+
+		if ok != timezoneOffsetTest.expectedOk || offset != timezoneOffsetTest.expectedOffset {
+			t.Errorf("Expected timezone %q to parse to (%v, %v) but found (%v, %v)", timezoneOffsetTest.timezone,
+				timezoneOffsetTest.expectedOffset, timezoneOffsetTest.expectedOk, offset, ok)
+		}
+	}
+}
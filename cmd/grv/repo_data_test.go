@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func newTestRepository(commitNum int, t *testing.T) (repoDir string) {
+	repoDir, err := ioutil.TempDir("", "grv-repository")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+
+	repo, err := git.InitRepository(repoDir, false)
+	if err != nil {
+		os.RemoveAll(repoDir)
+		t.Fatalf("Unable to initialise repository: %v", err)
+	}
+	defer repo.Free()
+
+	newBenchmarkCommits(repo, commitNum, t)
+
+	return
+}
+
+func TestSwitchingRepositoryWhileRefsAreLoading(t *testing.T) {
+	prevRepoDir := newTestRepository(500, t)
+	defer os.RemoveAll(prevRepoDir)
+	repoDir := newTestRepository(1, t)
+	defer os.RemoveAll(repoDir)
+
+	channels := newTestChannels()
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+
+	prevRepoData := NewRepositoryData(NewRepoDataLoader(channels), channels, config)
+	if err := prevRepoData.Initialise(prevRepoDir, ""); err != nil {
+		t.Fatalf("Unable to initialise repository data: %v", err)
+	}
+
+	if err := prevRepoData.LoadHead(); err != nil {
+		t.Fatalf("Unable to load HEAD: %v", err)
+	}
+
+	head := prevRepoData.Head()
+
+	if err := prevRepoData.LoadCommits(head); err != nil {
+		t.Fatalf("Unable to load commits: %v", err)
+	}
+
+	prevRepoData.LoadRefs(nil)
+	prevRepoData.LoadRefs(nil)
+
+	repoData := NewRepositoryData(NewRepoDataLoader(channels), channels, config)
+	if err := repoData.Initialise(repoDir, ""); err != nil {
+		t.Fatalf("Unable to initialise repository data: %v", err)
+	}
+	defer repoData.Free()
+
+	freedCh := make(chan bool)
+	go func() {
+		prevRepoData.Free()
+		close(freedCh)
+	}()
+
+	select {
+	case <-freedCh:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for the previous repository to be freed")
+	}
+
+	prevRepoData.OnRefsChanged(nil, nil, []*UpdatedRef{{OldRef: head, NewRef: head}})
+	prevRepoData.LoadRefs(nil)
+
+	if _, err := prevRepoData.repoDataLoader.Head(); err != errRdlRepositoryFreed {
+		t.Errorf("Expected freed repository to be inaccessible but found error: %v", err)
+	}
+
+	refsLoadedCh := make(chan []Ref, 1)
+	repoData.LoadRefs(func(refs []Ref) error {
+		refsLoadedCh <- refs
+		return nil
+	})
+
+	select {
+	case refs := <-refsLoadedCh:
+		if len(refs) != 1 {
+			t.Errorf("Expected 1 ref for the new repository but found %v", len(refs))
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("Timed out waiting for refs of the new repository to load")
+	}
+}
+
+type firstWindowListener struct {
+	firstWindowCh chan CommitSetState
+	loadedCh      chan CommitSetState
+	repoData      *RepositoryData
+}
+
+func (listener *firstWindowListener) OnCommitsLoaded(ref Ref, commitSetState CommitSetState, err error) {
+	listener.loadedCh <- commitSetState
+}
+
+func (listener *firstWindowListener) OnCommitsUpdated(ref Ref) {}
+
+func (listener *firstWindowListener) OnFirstWindowLoaded(ref Ref) {
+	listener.firstWindowCh <- listener.repoData.CommitSetState(ref)
+}
+
+func TestFirstWindowIsNotifiedBeforeSlowLoadFinishes(t *testing.T) {
+	const firstWindowCommitNum = 5
+	const commitNum = 10
+	loadDelay := time.Millisecond * 20
+
+	channels := newTestChannels()
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+	repoData := NewRepositoryData(NewRepoDataLoader(channels), channels, config)
+	repoData.SetFirstWindowCommitNum(firstWindowCommitNum)
+
+	listener := &firstWindowListener{
+		firstWindowCh: make(chan CommitSetState, 1),
+		loadedCh:      make(chan CommitSetState, 1),
+		repoData:      repoData,
+	}
+	repoData.RegisterCommitSetListener(listener)
+
+	ref := newTestLocalBranch("master", newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t))
+	commitCh := make(chan *Commit)
+	errorCh := make(chan error, 1)
+
+	var commits []*Commit
+	for i := 1; i <= commitNum; i++ {
+		commits = append(commits, &Commit{oid: newTestOid(fmt.Sprintf("%040x", i), t)})
+	}
+
+	start := time.Now()
+	repoData.loadCommits(ref, newCommitLoadThrottle(repoData.commitLoadLimit()), commitCh, errorCh)
+
+	go func() {
+		for _, commit := range commits {
+			time.Sleep(loadDelay)
+			commitCh <- commit
+		}
+
+		close(commitCh)
+	}()
+
+	select {
+	case commitSetState := <-listener.firstWindowCh:
+		timeToFirstPaint := time.Since(start)
+		t.Logf("Time to first paint: %v", timeToFirstPaint)
+
+		if !commitSetState.loading {
+			t.Errorf("Expected commits to still be loading when the first window was loaded")
+		}
+		if commitSetState.commitNum < firstWindowCommitNum {
+			t.Errorf("Expected at least %v commits to be loaded but found %v", firstWindowCommitNum, commitSetState.commitNum)
+		}
+		if timeToFirstPaint >= loadDelay*commitNum {
+			t.Errorf("Expected first window to be notified before all commits had loaded")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for the first window of commits to load")
+	}
+
+	select {
+	case commitSetState := <-listener.loadedCh:
+		if commitSetState.commitNum != commitNum {
+			t.Errorf("Expected %v commits to be loaded but found %v", commitNum, commitSetState.commitNum)
+		}
+	case <-time.After(10 * time.Second):
+		t.Errorf("Timed out waiting for commits to load")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"runtime"
+	"sort"
+	"unicode"
 )
 
 const (
@@ -47,12 +49,21 @@ type SearchMatch struct {
 type Search struct {
 	direction     SearchDirection
 	pattern       string
+	fuzzy         bool
 	regex         *regexp.Regexp
 	inputProvidor SearchInputProvidor
+	fuzzyMatches  []fuzzySearchMatch
+	fuzzyRanks    map[uint]int
+}
+
+type fuzzySearchMatch struct {
+	lineIndex uint
+	score     int
 }
 
 // CreateSearchFromAction is a utility method to create a search configured based on the action that triggered it
-func CreateSearchFromAction(action Action, inputProvidor SearchInputProvidor) (search *Search, err error) {
+// If fuzzy is true a fuzzy search is created
+func CreateSearchFromAction(action Action, inputProvidor SearchInputProvidor, fuzzy bool) (search *Search, err error) {
 	direction, ok := actionSearchDirection[action.ActionType]
 	if !ok {
 		return search, fmt.Errorf("Invalid ActionType: %v", action.ActionType)
@@ -67,10 +78,15 @@ func CreateSearchFromAction(action Action, inputProvidor SearchInputProvidor) (s
 		return search, fmt.Errorf("Expected search pattern")
 	}
 
+	if fuzzy {
+		return NewFuzzySearch(direction, pattern, inputProvidor)
+	}
+
 	return NewSearch(direction, pattern, inputProvidor)
 }
 
 // NewSearch creates a new search instance
+// The search is case insensitive unless the pattern contains an upper case character
 func NewSearch(direction SearchDirection, pattern string, inputProvidor SearchInputProvidor) (search *Search, err error) {
 	search = &Search{
 		direction:     direction,
@@ -78,15 +94,78 @@ func NewSearch(direction SearchDirection, pattern string, inputProvidor SearchIn
 		inputProvidor: inputProvidor,
 	}
 
-	if search.regex, err = regexp.Compile(pattern); err != nil {
+	regexPattern := pattern
+	if !containsUpperCase(pattern) {
+		regexPattern = "(?i)" + pattern
+	}
+
+	if search.regex, err = regexp.Compile(regexPattern); err != nil {
 		return
 	}
 
 	return
 }
 
+// NewFuzzySearch creates a search which matches lines containing the characters of the pattern in order.
+// Matches are traversed in order of descending score rather than by line position.
+// The input is scanned and the matches ranked once when the search is created
+func NewFuzzySearch(direction SearchDirection, pattern string, inputProvidor SearchInputProvidor) (search *Search, err error) {
+	if search, err = NewSearch(direction, FuzzyRegexPattern(pattern), inputProvidor); err != nil {
+		return
+	}
+
+	search.pattern = pattern
+	search.fuzzy = true
+	search.fuzzyMatches = search.fuzzySearchMatches()
+	search.fuzzyRanks = make(map[uint]int, len(search.fuzzyMatches))
+
+	for rank, fuzzySearchMatch := range search.fuzzyMatches {
+		search.fuzzyRanks[fuzzySearchMatch.lineIndex] = rank
+	}
+
+	return
+}
+
+// HighlightPattern returns a regular expression pattern matching the text matched by the search
+func (search *Search) HighlightPattern() string {
+	if search.fuzzy {
+		return FuzzyRegexPattern(search.pattern)
+	}
+
+	return search.pattern
+}
+
+func containsUpperCase(str string) bool {
+	for _, char := range str {
+		if unicode.IsUpper(char) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindFirst looks for the first match to select when a search is started.
+// For fuzzy searches this is the best match, otherwise it is the next match
+func (search *Search) FindFirst(startLineIndex uint) (matchedLineIndex uint, found bool) {
+	if search.fuzzy {
+		if len(search.fuzzyMatches) > 0 {
+			return search.fuzzyMatches[0].lineIndex, true
+		}
+
+		return
+	}
+
+	return search.FindNext(startLineIndex)
+}
+
 // FindNext looks for the next match starting from the line index provided
+// For fuzzy searches the next match is the match with the next highest score
 func (search *Search) FindNext(startLineIndex uint) (matchedLineIndex uint, found bool) {
+	if search.fuzzy {
+		return search.findRankedMatch(startLineIndex, 1)
+	}
+
 	switch search.direction {
 	case SdForward:
 		return search.findNext(startLineIndex)
@@ -98,7 +177,12 @@ func (search *Search) FindNext(startLineIndex uint) (matchedLineIndex uint, foun
 }
 
 // FindPrev looks for the next match in the reverse direction starting from the line index provided
+// For fuzzy searches the previous match is the match with the next lowest score
 func (search *Search) FindPrev(startLineIndex uint) (matchedLineIndex uint, found bool) {
+	if search.fuzzy {
+		return search.findRankedMatch(startLineIndex, -1)
+	}
+
 	switch search.direction {
 	case SdForward:
 		return search.findPrev(startLineIndex)
@@ -170,6 +254,49 @@ func (search *Search) findPrev(startLineIndex uint) (matchedLineIndex uint, foun
 	return
 }
 
+// fuzzySearchMatches returns all lines matching the fuzzy pattern ordered by descending score
+// Lines with equal scores are ordered by line index
+func (search *Search) fuzzySearchMatches() (fuzzySearchMatches []fuzzySearchMatch) {
+	for lineIndex := uint(0); lineIndex < search.inputProvidor.LineNumber(); lineIndex++ {
+		line := search.inputProvidor.Line(lineIndex)
+
+		if score, _, matched := FuzzyMatch(search.pattern, line); matched {
+			fuzzySearchMatches = append(fuzzySearchMatches, fuzzySearchMatch{
+				lineIndex: lineIndex,
+				score:     score,
+			})
+		}
+
+		if (lineIndex+1)%searchMaxIterationsBeforeYeild == 0 {
+			runtime.Gosched()
+		}
+	}
+
+	sort.SliceStable(fuzzySearchMatches, func(i, j int) bool {
+		return fuzzySearchMatches[i].score > fuzzySearchMatches[j].score
+	})
+
+	return
+}
+
+// findRankedMatch returns the match offset by step from the match at the provided
+// line index in score order. If the line is not a match the best match is returned
+func (search *Search) findRankedMatch(startLineIndex uint, step int) (matchedLineIndex uint, found bool) {
+	matchNum := len(search.fuzzyMatches)
+
+	if matchNum == 0 {
+		return
+	}
+
+	rank := 0
+
+	if startRank, ok := search.fuzzyRanks[startLineIndex]; ok {
+		rank = (startRank + step + matchNum) % matchNum
+	}
+
+	return search.fuzzyMatches[rank].lineIndex, true
+}
+
 // FindAll find all matches across the entire input provided
 func (search *Search) FindAll() (matches []SearchMatch) {
 	for lineIndex := uint(0); lineIndex < search.inputProvidor.LineNumber(); lineIndex++ {
@@ -175,6 +175,22 @@ func TestSearchBackwardFindPrevStartsFromLineBelowProvided(t *testing.T) {
 	checkResult(2, true, lineIndex, found, t)
 }
 
+func TestSearchIsCaseInsensitiveWhenPatternIsLowerCase(t *testing.T) {
+	search := createSearch(SdForward, "test line 3", t)
+
+	lineIndex, found := search.FindNext(0)
+
+	checkResult(2, true, lineIndex, found, t)
+}
+
+func TestSearchIsCaseSensitiveWhenPatternContainsUpperCase(t *testing.T) {
+	search := createSearch(SdForward, "TST", t)
+
+	lineIndex, found := search.FindNext(0)
+
+	checkResult(0, false, lineIndex, found, t)
+}
+
 func TestSearchFindAll(t *testing.T) {
 	search := createSearch(SdForward, `[Tt]est`, t)
 
@@ -231,3 +247,60 @@ func TestSearchFindAll(t *testing.T) {
 		t.Errorf("FindAll did not return expected matches. Expected: %v. Actual %v", expectedMatches, actualMatches)
 	}
 }
+
+type countingInputProvidor struct {
+	TestInputProvidor
+	lineCalls uint
+}
+
+func (inputProvidor *countingInputProvidor) Line(lineIndex uint) (line string) {
+	inputProvidor.lineCalls++
+	return inputProvidor.TestInputProvidor.Line(lineIndex)
+}
+
+func TestFuzzySearchRanksMatchesOnlyOnCreation(t *testing.T) {
+	inputProvidor := &countingInputProvidor{}
+
+	search, err := NewFuzzySearch(SdForward, "tst", inputProvidor)
+	if err != nil {
+		t.Fatalf("Failed to create fuzzy search instance: %v", err)
+	}
+
+	lineCalls := inputProvidor.lineCalls
+	if lineCalls != inputProvidor.LineNumber() {
+		t.Errorf("Expected every line to be scanned once on creation but found %v scans", lineCalls)
+	}
+
+	lineIndex, found := search.FindFirst(0)
+	for i := 0; i < 5; i++ {
+		lineIndex, found = search.FindNext(lineIndex)
+		lineIndex, found = search.FindPrev(lineIndex)
+	}
+	checkResult(3, true, lineIndex, found, t)
+
+	if inputProvidor.lineCalls != lineCalls {
+		t.Errorf("Expected matches to not be rescanned when moving between matches")
+	}
+}
+
+func TestFuzzySearchTraversesMatchesInScoreOrder(t *testing.T) {
+	search, err := NewFuzzySearch(SdForward, "tst", &TestInputProvidor{})
+	if err != nil {
+		t.Fatalf("Failed to create fuzzy search instance: %v", err)
+	}
+
+	lineIndex, found := search.FindFirst(0)
+	checkResult(3, true, lineIndex, found, t)
+
+	lineIndex, found = search.FindNext(3)
+	checkResult(0, true, lineIndex, found, t)
+
+	lineIndex, found = search.FindNext(0)
+	checkResult(1, true, lineIndex, found, t)
+
+	lineIndex, found = search.FindNext(2)
+	checkResult(3, true, lineIndex, found, t)
+
+	lineIndex, found = search.FindPrev(0)
+	checkResult(3, true, lineIndex, found, t)
+}
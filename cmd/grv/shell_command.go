@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	scShell          = "sh"
+	scVariablePrefix = '%'
+	scReadOnlyMode   = 0400
+)
+
+// ShellCommandVariableProvider is implemented by views which supply values for shell command variables
+type ShellCommandVariableProvider interface {
+	ShellCommandVariables() (map[rune]string, error)
+}
+
+// ShellQuote quotes the provided value so that it is interpreted literally by the shell
+func ShellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// ExpandShellCommand replaces each variable of the form %x in the provided command
+// with the shell quoted value the variable maps to. %% is replaced by a literal %.
+// Unknown variables are left unchanged
+func ExpandShellCommand(command string, variables map[rune]string) string {
+	var expanded bytes.Buffer
+	runes := []rune(command)
+
+	for index := 0; index < len(runes); index++ {
+		char := runes[index]
+
+		if char != scVariablePrefix || index+1 == len(runes) {
+			expanded.WriteRune(char)
+			continue
+		}
+
+		variable := runes[index+1]
+
+		if variable == scVariablePrefix {
+			expanded.WriteRune(scVariablePrefix)
+			index++
+		} else if value, ok := variables[variable]; ok {
+			expanded.WriteString(ShellQuote(value))
+			index++
+		} else {
+			expanded.WriteRune(char)
+		}
+	}
+
+	return expanded.String()
+}
+
+// NewShellCommandAction creates an action to run the provided shell command once its variables have been expanded
+// The output of the command is captured and displayed once it exits
+func NewShellCommandAction(command string, variables map[rune]string) Action {
+	return Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command:       scShell,
+			args:          []string{"-c", ExpandShellCommand(command, variables)},
+			captureOutput: true,
+		}},
+	}
+}
+
+// openFileInEditor writes the content of the file at the provided path as of the commit
+// with the provided oid to a read only temporary file and opens it in $EDITOR.
+// $PAGER is used if $EDITOR is not set. The temporary file is removed once the command exits or if it is never run.
+// Binary files are not opened
+func openFileInEditor(repoData RepoData, channels *Channels, path string, oid *Oid) (err error) {
+	content, err := repoData.FileContent(path, oid)
+	if err != nil {
+		return
+	}
+
+	if IsBinaryContent(content) {
+		channels.ReportStatus("File %v is binary and cannot be opened in an editor", path)
+		return
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		if editor = os.Getenv("PAGER"); editor == "" {
+			editor = cvPager
+		}
+	}
+
+	tempDir, err := ioutil.TempDir("", "grv")
+	if err != nil {
+		return
+	}
+
+	removeTempDir := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Errorf("Unable to remove temporary directory %v: %v", tempDir, err)
+		}
+	}
+
+	filePath := filepath.Join(tempDir, fmt.Sprintf("%v-%v", oid.ShortID(), filepath.Base(path)))
+
+	if err = ioutil.WriteFile(filePath, content, scReadOnlyMode); err != nil {
+		removeTempDir()
+		return
+	}
+
+	log.Debugf("Opening file %v at commit %v using %v", path, oid, editor)
+
+	channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command: scShell,
+			args:    []string{"-c", editor + " " + ShellQuote(filePath)},
+			cleanup: removeTempDir,
+		}},
+	})
+
+	return
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpandShellCommand(t *testing.T) {
+	variables := map[rune]string{
+		'h': "300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5",
+		'b': "it's-a-branch",
+	}
+
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{"git show %h", "git show '300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5'"},
+		{"git log %b", `git log 'it'\''s-a-branch'`},
+		{"date +%%Y %x", "date +%Y %x"},
+		{"echo 100%", "echo 100%"},
+	}
+
+	for _, test := range tests {
+		if expanded := ExpandShellCommand(test.command, variables); expanded != test.expected {
+			t.Errorf("Expanding %q returned %q but expected %q", test.command, expanded, test.expected)
+		}
+	}
+}
+
+func TestContainerViewProvidesShellCommandVariablesOfCommitView(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	commit := &Commit{oid: oid}
+	ref := newTestLocalBranch("master", oid)
+
+	repoData := &MockRepoData{}
+	repoData.On("LoadCommits", mock.Anything).Return(nil)
+	repoData.On("SetFirstWindowCommitNum", mock.Anything)
+	repoData.On("CommitSetState", mock.Anything).Return(CommitSetState{commitNum: 1})
+	repoData.On("Commit", mock.Anything).Return(commit, nil)
+	repoData.On("CommitByIndex", mock.Anything, mock.Anything).Return(commit, nil)
+
+	commitView := newTestCommitView(repoData)
+
+	if err := commitView.OnRefSelect(ref); err != nil {
+		t.Fatalf("Failed to select ref %v: %v", ref.Name(), err)
+	}
+
+	channels := newTestChannels()
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+
+	containerView := NewContainerView(channels, config)
+	containerView.AddChildViews(commitView, NewDiffView(repoData, channels))
+	containerView.activeViewIndex = 1
+
+	variables, err := containerView.ShellCommandVariables()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if variables['h'] != oid.String() || variables['b'] != "master" {
+		t.Errorf("Expected variables of the commit view while the diff view is active but found %v", variables)
+	}
+
+	emptyContainerView := NewContainerView(channels, config)
+	emptyContainerView.AddChildViews(NewDiffView(repoData, channels))
+
+	if variables, err = emptyContainerView.ShellCommandVariables(); err != nil || len(variables) != 0 {
+		t.Errorf("Expected no variables without a commit view but found %v, error %v", variables, err)
+	}
+}
+
+func TestCommandsWhichAreNeverRunAreCleanedUp(t *testing.T) {
+	actionCh := make(chan Action, 10)
+	cleanedUp := 0
+	onExitCalled := false
+
+	actionCh <- Action{ActionType: ActionNextLine}
+	actionCh <- Action{ActionType: ActionRunCommand, Args: []interface{}{ActionRunCommandArgs{
+		cleanup: func() { cleanedUp++ },
+		onExit:  func() { onExitCalled = true },
+	}}}
+	actionCh <- Action{ActionType: ActionRunCommand, Args: []interface{}{ActionRunCommandArgs{}}}
+
+	discardPendingActions(actionCh)
+
+	if cleanedUp != 1 {
+		t.Errorf("Expected command to be cleaned up once but found %v", cleanedUp)
+	}
+
+	if onExitCalled {
+		t.Errorf("Expected onExit not to be called for a command which was never run")
+	}
+
+	if pending := len(actionCh); pending != 0 {
+		t.Errorf("Expected all pending actions to be discarded but found %v", pending)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	spFrames      = `|/-\`
+	spRefreshRate = 100 * time.Millisecond
+)
+
+// Operation is a long running git operation whose progress is displayed in the status bar
+type Operation struct {
+	description string
+}
+
+// Spinner cycles through a set of frames to indicate an operation is in progress.
+// Once started its frame advances on each tick until it is stopped
+type Spinner struct {
+	frameIndex int
+	ticker     *time.Ticker
+	cancelCh   chan<- bool
+	lock       sync.Mutex
+}
+
+// NewSpinner creates a new instance
+func NewSpinner() *Spinner {
+	return &Spinner{}
+}
+
+// Start begins advancing the frame of the spinner. onTick is called after
+// each frame advance. Starting a running spinner has no effect
+func (spinner *Spinner) Start(onTick func()) {
+	spinner.lock.Lock()
+	defer spinner.lock.Unlock()
+
+	if spinner.ticker != nil {
+		return
+	}
+
+	log.Debug("Starting spinner")
+
+	ticker := time.NewTicker(spRefreshRate)
+	cancelCh := make(chan bool)
+	spinner.ticker = ticker
+	spinner.cancelCh = cancelCh
+
+	go func(ticker *time.Ticker, cancelCh <-chan bool) {
+		for {
+			select {
+			case <-ticker.C:
+				spinner.advance()
+				onTick()
+			case <-cancelCh:
+				return
+			}
+		}
+	}(ticker, cancelCh)
+}
+
+// Stop stops the spinner and resets it to its first frame
+// It never blocks and is safe to call when the spinner is not running
+func (spinner *Spinner) Stop() {
+	spinner.lock.Lock()
+	defer spinner.lock.Unlock()
+
+	spinner.frameIndex = 0
+
+	if spinner.ticker == nil {
+		return
+	}
+
+	log.Debug("Stopping spinner")
+
+	spinner.ticker.Stop()
+	close(spinner.cancelCh)
+	spinner.ticker = nil
+	spinner.cancelCh = nil
+}
+
+// Frame returns the current frame of the spinner
+func (spinner *Spinner) Frame() string {
+	spinner.lock.Lock()
+	defer spinner.lock.Unlock()
+
+	return spFrames[spinner.frameIndex : spinner.frameIndex+1]
+}
+
+func (spinner *Spinner) advance() {
+	spinner.lock.Lock()
+	defer spinner.lock.Unlock()
+
+	spinner.frameIndex = (spinner.frameIndex + 1) % len(spFrames)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpinnerFramesCycleAndResetOnStop(t *testing.T) {
+	spinner := NewSpinner()
+	spinner.Stop()
+
+	var frames []string
+	for frameIndex := 0; frameIndex <= len(spFrames); frameIndex++ {
+		frames = append(frames, spinner.Frame())
+		spinner.advance()
+	}
+
+	if expectedFrames := []string{"|", "/", "-", `\`, "|"}; !reflect.DeepEqual(frames, expectedFrames) {
+		t.Errorf("Spinner frames do not match expected value. Expected: %v, Actual: %v", expectedFrames, frames)
+	}
+
+	spinner.Start(func() {})
+	spinner.Start(func() {})
+	spinner.Stop()
+	spinner.Stop()
+
+	if frame := spinner.Frame(); frame != "|" {
+		t.Errorf("Expected spinner to be reset to its first frame after stopping but found %v", frame)
+	}
+}
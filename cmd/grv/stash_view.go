@@ -0,0 +1,452 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	svColumnNum = 3
+)
+
+type stashViewHandler func(*StashView, Action) error
+
+// StashView displays the stash entries of the repository
+type StashView struct {
+	channels       *Channels
+	repoData       RepoData
+	stashEntries   []*StashEntry
+	viewPos        ViewPos
+	viewDimension  ViewDimension
+	tableFormatter *TableFormatter
+	handlers       map[ActionType]stashViewHandler
+	active         bool
+	viewSearch     *ViewSearch
+	lock           sync.Mutex
+}
+
+// NewStashView creates a new instance of the stash view
+func NewStashView(repoData RepoData, channels *Channels) *StashView {
+	stashView := &StashView{
+		repoData:       repoData,
+		channels:       channels,
+		viewPos:        NewViewPosition(),
+		tableFormatter: NewTableFormatter(svColumnNum),
+		handlers: map[ActionType]stashViewHandler{
+			ActionPrevLine:     moveUpStashEntry,
+			ActionNextLine:     moveDownStashEntry,
+			ActionPrevPage:     moveUpStashPage,
+			ActionNextPage:     moveDownStashPage,
+			ActionPrevHalfPage: moveUpStashHalfPage,
+			ActionNextHalfPage: moveDownStashHalfPage,
+			ActionScrollRight:  scrollStashViewRight,
+			ActionScrollLeft:   scrollStashViewLeft,
+			ActionFirstLine:    moveToFirstStashEntry,
+			ActionLastLine:     moveToLastStashEntry,
+			ActionCenterView:   centerStashView,
+			ActionSelect:       applyStash,
+			ActionStashDrop:    dropStash,
+		},
+	}
+
+	stashView.viewSearch = NewViewSearch(stashView, channels)
+
+	return stashView
+}
+
+// Initialise does nothing
+func (stashView *StashView) Initialise() (err error) {
+	log.Info("Initialising StashView")
+	return
+}
+
+// LoadStashes loads the stash entries of the repository
+func (stashView *StashView) LoadStashes() (err error) {
+	log.Debug("StashView loading stashes")
+
+	stashEntries, err := stashView.repoData.Stashes()
+	if err != nil {
+		return
+	}
+
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	stashView.stashEntries = stashEntries
+
+	if entryNum := stashView.lineNumber(); entryNum > 0 && stashView.viewPos.ActiveRowIndex() >= entryNum {
+		stashView.viewPos.SetActiveRowIndex(entryNum - 1)
+	}
+
+	log.Debugf("StashView loaded %v stashes", len(stashEntries))
+	stashView.channels.UpdateDisplay()
+
+	return
+}
+
+// Render generates and writes the stash view to the provided window
+func (stashView *StashView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering StashView")
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	stashView.viewDimension = win.ViewDimensions()
+
+	lineNum := stashView.lineNumber()
+
+	if lineNum == 0 {
+		return stashView.renderEmptyView(win)
+	}
+
+	rows := stashView.pageRows()
+	viewPos := stashView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	tableFormatter := stashView.tableFormatter
+	tableFormatter.Resize(rows)
+	tableFormatter.Clear()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		if err = stashView.renderStashEntry(tableFormatter, rowIndex, stashView.stashEntries[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, stashView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpStashviewTitle, "Stashes"); err != nil {
+		return
+	}
+
+	if err = win.SetFooter(CmpStashviewFooter, "Stash %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := stashView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (stashView *StashView) renderEmptyView(win RenderWindow) (err error) {
+	if err = win.SetRow(2, 1, CmpNone, "   No stashes to display"); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return
+}
+
+func (stashView *StashView) renderStashEntry(tableFormatter *TableFormatter, rowIndex uint, stashEntry *StashEntry) (err error) {
+	colIndex := uint(0)
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStashviewSelector, "%v", stashEntry.Selector()); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStashviewBranch, "%v", stashEntry.branch); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStashviewMessage, "%v", stashEntry.message); err != nil {
+		return
+	}
+
+	return
+}
+
+// RenderHelpBar shows key bindings custom to the stash view
+func (stashView *StashView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(stashView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Apply"},
+		{action: ActionStashDrop, message: "Drop"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the stash view is the active view or not
+func (stashView *StashView) OnActiveChange(active bool) {
+	log.Debugf("StashView active: %v", active)
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	stashView.active = active
+}
+
+// ViewID returns the stash views ID
+func (stashView *StashView) ViewID() ViewID {
+	return ViewStash
+}
+
+// HandleEvent does nothing
+func (stashView *StashView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// ViewPos returns the current view position
+func (stashView *StashView) ViewPos() ViewPos {
+	return stashView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (stashView *StashView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	viewPos := stashView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Stashes have changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleAction checks if the stash view supports the provided action and executes it if so
+func (stashView *StashView) HandleAction(action Action) (err error) {
+	log.Debugf("StashView handling action %v", action)
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	if handler, ok := stashView.handlers[action.ActionType]; ok {
+		err = handler(stashView, action)
+	} else {
+		_, err = stashView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the stash view at the specified line index
+func (stashView *StashView) Line(lineIndex uint) (line string) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	lineNum := stashView.lineNumber()
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	stashEntry := stashView.stashEntries[lineIndex]
+	line = fmt.Sprintf("%v %v %v", stashEntry.Selector(), stashEntry.branch, stashEntry.message)
+
+	return
+}
+
+// LineNumber returns the number of stash entries
+func (stashView *StashView) LineNumber() (lineNumber uint) {
+	stashView.lock.Lock()
+	defer stashView.lock.Unlock()
+
+	return stashView.lineNumber()
+}
+
+func (stashView *StashView) lineNumber() uint {
+	return uint(len(stashView.stashEntries))
+}
+
+// pageRows returns the number of stash entries visible in the view
+// excluding the rows used by the border
+func (stashView *StashView) pageRows() uint {
+	if stashView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return stashView.viewDimension.rows - 2
+}
+
+func (stashView *StashView) selectedStashEntry() (stashEntry *StashEntry, ok bool) {
+	if stashView.lineNumber() == 0 {
+		return
+	}
+
+	return stashView.stashEntries[stashView.viewPos.ActiveRowIndex()], true
+}
+
+// runStashAction runs the provided stash operation asynchronously.
+// On completion the stash list is reloaded and the outcome reported in the status bar
+func (stashView *StashView) runStashAction(stashEntry *StashEntry, stashAction func(*StashEntry) error, statusMessage string) {
+	stashView.channels.RunOperation(fmt.Sprintf("Updating %v", stashEntry.Selector()), func() (status string, err error) {
+		if err = stashAction(stashEntry); err != nil {
+			return
+		}
+
+		if loadErr := stashView.LoadStashes(); loadErr != nil {
+			stashView.channels.ReportError(loadErr)
+		}
+
+		return fmt.Sprintf("%v %v", statusMessage, stashEntry.Selector()), nil
+	})
+}
+
+func moveDownStashEntry(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MoveLinesDown(ActionCount(action), stashView.lineNumber()) {
+		log.Debugf("Moving down %v entries in stash view", ActionCount(action))
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStashEntry(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v entries in stash view", ActionCount(action))
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownStashPage(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MovePageDown(stashView.pageRows(), stashView.lineNumber()) {
+		log.Debugf("Moving down one page in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStashPage(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MovePageUp(stashView.pageRows()) {
+		log.Debugf("Moving up one page in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownStashHalfPage(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MovePageDown(stashView.pageRows()/2, stashView.lineNumber()) {
+		log.Debugf("Moving down half a page in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStashHalfPage(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MovePageUp(stashView.pageRows() / 2) {
+		log.Debugf("Moving up half a page in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollStashViewRight(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+	viewPos.MovePageRight(stashView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	stashView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollStashViewLeft(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MovePageLeft(stashView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstStashEntry(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first entry in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastStashEntry(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.MoveToLastLine(stashView.lineNumber()) {
+		log.Debugf("Moving to last entry in stash view")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerStashView(stashView *StashView, action Action) (err error) {
+	viewPos := stashView.viewPos
+
+	if viewPos.CenterActiveRow(stashView.pageRows()) {
+		log.Debug("Centering StashView")
+		stashView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func applyStash(stashView *StashView, action Action) (err error) {
+	stashEntry, ok := stashView.selectedStashEntry()
+	if !ok {
+		return
+	}
+
+	stashView.runStashAction(stashEntry, stashView.repoData.StashApply, "Applied")
+
+	return
+}
+
+func dropStash(stashView *StashView, action Action) (err error) {
+	stashEntry, ok := stashView.selectedStashEntry()
+	if !ok {
+		return
+	}
+
+	resultCh := stashView.channels.Confirm(fmt.Sprintf("Drop %v?", stashEntry.Selector()))
+
+	go func() {
+		if <-resultCh {
+			stashView.runStashAction(stashEntry, stashView.repoData.StashDrop, "Dropped")
+		} else {
+			log.Debugf("Drop of stash %v was cancelled", stashEntry.Selector())
+		}
+	}()
+
+	return
+}
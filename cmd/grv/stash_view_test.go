@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestStashView(t *testing.T) (*StashView, *MockRepoData) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+
+	repoData := &MockRepoData{}
+	repoData.On("Stashes").Return([]*StashEntry{
+		{index: 0, oid: oid, branch: "master", message: "abc1234 First"},
+		{index: 1, oid: oid, branch: "master", message: "abc1234 Second"},
+	}, nil)
+
+	stashView := NewStashView(repoData, newTestChannels())
+	stashView.viewDimension = ViewDimension{rows: 12, cols: 80}
+
+	if err := stashView.LoadStashes(); err != nil {
+		t.Fatalf("Unable to load stashes: %v", err)
+	}
+
+	return stashView, repoData
+}
+
+func TestStashDropRequiresConfirmation(t *testing.T) {
+	stashView, repoData := newTestStashView(t)
+	actionCh := make(chan Action, 100)
+	stashView.channels.actionCh = actionCh
+
+	if err := stashView.HandleAction(Action{ActionType: ActionStashDrop}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var confirmPrompt *ConfirmPrompt
+
+	select {
+	case action := <-actionCh:
+		if action.ActionType != ActionConfirmPrompt || len(action.Args) == 0 {
+			t.Fatalf("Expected confirm prompt action but found %v", action)
+		}
+
+		confirmPrompt = action.Args[0].(*ConfirmPrompt)
+	default:
+		t.Fatalf("Expected confirm prompt action to be sent")
+	}
+
+	if question := confirmPrompt.Question(); question != "Drop stash@{0}?" {
+		t.Errorf("Unexpected confirmation question: %v", question)
+	}
+
+	confirmPrompt.Answer(false)
+
+	repoData.AssertNotCalled(t, "StashDrop", mock.Anything)
+}
+
+func TestStashViewMovesByActionCount(t *testing.T) {
+	stashView, _ := newTestStashView(t)
+
+	if err := stashView.HandleAction(Action{ActionType: ActionNextLine, Args: []interface{}{uint(5)}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if activeRowIndex := stashView.viewPos.ActiveRowIndex(); activeRowIndex != 1 {
+		t.Errorf("Expected to move to the last stash at index 1 but found %v", activeRowIndex)
+	}
+
+	if err := stashView.HandleAction(Action{ActionType: ActionPrevLine, Args: []interface{}{uint(2)}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if activeRowIndex := stashView.viewPos.ActiveRowIndex(); activeRowIndex != 0 {
+		t.Errorf("Expected to move to the first stash at index 0 but found %v", activeRowIndex)
+	}
+}
+
+func TestConfirmPromptAnswer(t *testing.T) {
+	tests := []struct {
+		key               string
+		expectedConfirmed bool
+		expectedConsumed  bool
+	}{
+		{"y", true, true},
+		{"n", false, true},
+		{"<Escape>", false, true},
+		{"j", false, false},
+	}
+
+	for _, test := range tests {
+		confirmed, consumed := ConfirmPromptAnswer(test.key)
+
+		if confirmed != test.expectedConfirmed || consumed != test.expectedConsumed {
+			t.Errorf("Key %v returned (%v, %v) but expected (%v, %v)",
+				test.key, confirmed, consumed, test.expectedConfirmed, test.expectedConsumed)
+		}
+	}
+}
+
+func TestParseStashMessage(t *testing.T) {
+	tests := []struct {
+		stashMessage    string
+		expectedBranch  string
+		expectedMessage string
+	}{
+		{"WIP on master: abc1234 Commit summary", "master", "abc1234 Commit summary"},
+		{"On feature/x: custom message", "feature/x", "custom message"},
+		{"unexpected format", "", "unexpected format"},
+	}
+
+	for _, test := range tests {
+		branch, message := parseStashMessage(test.stashMessage)
+
+		if branch != test.expectedBranch || message != test.expectedMessage {
+			t.Errorf("Parsing %q returned (%q, %q) but expected (%q, %q)",
+				test.stashMessage, branch, message, test.expectedBranch, test.expectedMessage)
+		}
+	}
+}
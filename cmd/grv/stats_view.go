@@ -0,0 +1,685 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	stvColumnNum       = 6
+	stvDateFormat      = "2006-01-02"
+	stvCommitBatchSize = 100
+)
+
+type statsViewHandler func(*StatsView, Action) error
+
+// StatsSortOrder determines the order authors are listed in the stats view
+type StatsSortOrder int
+
+// The set of supported author orderings
+const (
+	SsoCommitCount StatsSortOrder = iota
+	SsoLinesChanged
+)
+
+// AuthorStats contains the contribution statistics of a single author
+type AuthorStats struct {
+	name            string
+	email           string
+	commits         uint
+	added           uint
+	removed         uint
+	firstCommitDate time.Time
+	lastCommitDate  time.Time
+}
+
+func (authorStats *AuthorStats) linesChanged() uint {
+	return authorStats.added + authorStats.removed
+}
+
+// AuthorStatsSet aggregates the statistics of commits by author email
+type AuthorStatsSet struct {
+	authors map[string]*AuthorStats
+	sorted  []*AuthorStats
+}
+
+// NewAuthorStatsSet creates a new instance
+func NewAuthorStatsSet() *AuthorStatsSet {
+	return &AuthorStatsSet{
+		authors: make(map[string]*AuthorStats),
+	}
+}
+
+// Add includes a commit by the provided author in the statistics
+func (authorStatsSet *AuthorStatsSet) Add(name, email string, when time.Time, diffStat *DiffStat) {
+	authorStats, ok := authorStatsSet.authors[email]
+	if !ok {
+		authorStats = &AuthorStats{
+			name:            name,
+			email:           email,
+			firstCommitDate: when,
+			lastCommitDate:  when,
+		}
+
+		authorStatsSet.authors[email] = authorStats
+		authorStatsSet.sorted = append(authorStatsSet.sorted, authorStats)
+	}
+
+	authorStats.commits++
+
+	if diffStat != nil {
+		authorStats.added += diffStat.added
+		authorStats.removed += diffStat.removed
+	}
+
+	if when.Before(authorStats.firstCommitDate) {
+		authorStats.firstCommitDate = when
+	}
+
+	if when.After(authorStats.lastCommitDate) {
+		authorStats.lastCommitDate = when
+	}
+}
+
+// Sort orders the authors using the provided sort order.
+// Authors with equal values are ordered by name
+func (authorStatsSet *AuthorStatsSet) Sort(sortOrder StatsSortOrder) {
+	value := func(authorStats *AuthorStats) uint {
+		if sortOrder == SsoLinesChanged {
+			return authorStats.linesChanged()
+		}
+
+		return authorStats.commits
+	}
+
+	sort.SliceStable(authorStatsSet.sorted, func(i, j int) bool {
+		first, second := authorStatsSet.sorted[i], authorStatsSet.sorted[j]
+
+		if firstValue, secondValue := value(first), value(second); firstValue != secondValue {
+			return firstValue > secondValue
+		}
+
+		return first.name < second.name
+	})
+}
+
+// Authors returns the statistics of each author in sorted order
+func (authorStatsSet *AuthorStatsSet) Authors() []*AuthorStats {
+	return authorStatsSet.sorted
+}
+
+// StatsView displays per author statistics of the loaded commits of a ref
+type StatsView struct {
+	channels         *Channels
+	repoData         RepoData
+	ref              Ref
+	authorStatsSet   *AuthorStatsSet
+	sortOrder        StatsSortOrder
+	processedCommits uint
+	resetStats       bool
+	diffStatLoader   *DiffStatLoader
+	updateCh         chan bool
+	cancelCh         chan bool
+	viewPos          ViewPos
+	viewDimension    ViewDimension
+	tableFormatter   *TableFormatter
+	handlers         map[ActionType]statsViewHandler
+	active           bool
+	disposed         bool
+	viewSearch       *ViewSearch
+	lock             sync.Mutex
+}
+
+// NewStatsView creates a new instance of the stats view for the provided ref
+func NewStatsView(repoData RepoData, channels *Channels, ref Ref) *StatsView {
+	statsView := &StatsView{
+		repoData:       repoData,
+		channels:       channels,
+		ref:            ref,
+		authorStatsSet: NewAuthorStatsSet(),
+		diffStatLoader: NewDiffStatLoader(repoData, channels, dslWorkerNum),
+		updateCh:       make(chan bool, 1),
+		cancelCh:       make(chan bool),
+		viewPos:        NewViewPosition(),
+		tableFormatter: NewTableFormatter(stvColumnNum),
+		handlers: map[ActionType]statsViewHandler{
+			ActionPrevLine:             moveUpStatsEntry,
+			ActionNextLine:             moveDownStatsEntry,
+			ActionPrevPage:             moveUpStatsPage,
+			ActionNextPage:             moveDownStatsPage,
+			ActionPrevHalfPage:         moveUpStatsHalfPage,
+			ActionNextHalfPage:         moveDownStatsHalfPage,
+			ActionScrollRight:          scrollStatsViewRight,
+			ActionScrollLeft:           scrollStatsViewLeft,
+			ActionFirstLine:            moveToFirstStatsEntry,
+			ActionLastLine:             moveToLastStatsEntry,
+			ActionCenterView:           centerStatsView,
+			ActionToggleStatsSortOrder: toggleStatsSortOrder,
+		},
+	}
+
+	statsView.viewSearch = NewViewSearch(statsView, channels)
+
+	return statsView
+}
+
+// Initialise starts loading the commits of the ref and processing them as they load
+func (statsView *StatsView) Initialise() (err error) {
+	log.Infof("Initialising StatsView for ref %v", statsView.ref.Name())
+
+	statsView.repoData.RegisterCommitSetListener(statsView)
+
+	go statsView.processCommits(statsView.updateCh, statsView.cancelCh)
+
+	if err = statsView.repoData.LoadCommits(statsView.ref); err != nil {
+		return
+	}
+
+	statsView.requestUpdate()
+
+	return
+}
+
+// Dispose stops processing commits and stops listening for commit set events
+func (statsView *StatsView) Dispose() {
+	statsView.lock.Lock()
+
+	if statsView.disposed {
+		statsView.lock.Unlock()
+		return
+	}
+
+	log.Debug("Disposing of StatsView")
+	statsView.disposed = true
+	close(statsView.cancelCh)
+	statsView.lock.Unlock()
+
+	statsView.repoData.UnregisterCommitSetListener(statsView)
+	statsView.diffStatLoader.Stop()
+}
+
+// requestUpdate notifies the processing routine that more commits may be available
+func (statsView *StatsView) requestUpdate() {
+	select {
+	case statsView.updateCh <- true:
+	default:
+	}
+}
+
+// processCommits adds newly loaded commits to the author statistics each time an update is requested
+func (statsView *StatsView) processCommits(updateCh <-chan bool, cancelCh <-chan bool) {
+	for {
+		select {
+		case <-updateCh:
+			statsView.processLoadedCommits(cancelCh)
+		case <-cancelCh:
+			return
+		}
+	}
+}
+
+// processLoadedCommits adds commits to the author statistics in batches.
+// The diff stats of each batch are loaded in parallel by the diff stat workers
+func (statsView *StatsView) processLoadedCommits(cancelCh <-chan bool) {
+	for {
+		select {
+		case <-cancelCh:
+			return
+		default:
+		}
+
+		statsView.lock.Lock()
+		if statsView.resetStats {
+			statsView.resetStats = false
+			statsView.authorStatsSet = NewAuthorStatsSet()
+			statsView.processedCommits = 0
+		}
+		commitIndex := statsView.processedCommits
+		statsView.lock.Unlock()
+
+		commits := statsView.commitBatch(commitIndex)
+		if len(commits) == 0 {
+			break
+		}
+
+		oids := make([]*Oid, 0, len(commits))
+		for _, commit := range commits {
+			oids = append(oids, commit.oid)
+		}
+
+		diffStats, loaded := statsView.diffStatLoader.DiffStats(oids, cancelCh)
+		if !loaded {
+			return
+		}
+
+		statsView.lock.Lock()
+		if !statsView.resetStats && statsView.processedCommits == commitIndex {
+			for index, commit := range commits {
+				author := commit.commit.Author()
+				statsView.authorStatsSet.Add(author.Name, author.Email, author.When, diffStats[index])
+			}
+
+			statsView.processedCommits += uint(len(commits))
+		}
+		statsView.lock.Unlock()
+
+		statsView.updateStats()
+	}
+
+	statsView.updateStats()
+}
+
+// commitBatch returns up to stvCommitBatchSize loaded commits starting at the provided index
+func (statsView *StatsView) commitBatch(startIndex uint) (commits []*Commit) {
+	commitNum := statsView.repoData.CommitSetState(statsView.ref).commitNum
+
+	for commitIndex := startIndex; commitIndex < commitNum && len(commits) < stvCommitBatchSize; commitIndex++ {
+		commit, err := statsView.repoData.CommitByIndex(statsView.ref, commitIndex)
+		if err != nil {
+			log.Errorf("Unable to load commit at index %v for ref %v: %v", commitIndex, statsView.ref.Name(), err)
+			break
+		}
+
+		commits = append(commits, commit)
+	}
+
+	return
+}
+
+// updateStats resorts the authors and refreshes the display
+func (statsView *StatsView) updateStats() {
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	statsView.authorStatsSet.Sort(statsView.sortOrder)
+
+	if authorNum := statsView.lineNumber(); authorNum > 0 && statsView.viewPos.ActiveRowIndex() >= authorNum {
+		statsView.viewPos.SetActiveRowIndex(authorNum - 1)
+	}
+
+	statsView.channels.UpdateDisplay()
+}
+
+// OnCommitsLoaded processes any remaining commits of the ref
+func (statsView *StatsView) OnCommitsLoaded(ref Ref, commitSetState CommitSetState, err error) {
+	if ref.Name() == statsView.ref.Name() {
+		statsView.requestUpdate()
+	}
+}
+
+// OnFirstWindowLoaded processes the commits loaded so far
+func (statsView *StatsView) OnFirstWindowLoaded(ref Ref) {
+	if ref.Name() == statsView.ref.Name() {
+		statsView.requestUpdate()
+	}
+}
+
+// OnCommitsUpdated recalculates the statistics as the commits of the ref have changed
+func (statsView *StatsView) OnCommitsUpdated(ref Ref) {
+	if ref.Name() != statsView.ref.Name() {
+		return
+	}
+
+	statsView.lock.Lock()
+	statsView.resetStats = true
+	statsView.lock.Unlock()
+
+	statsView.requestUpdate()
+}
+
+// Render generates and writes the stats view to the provided window
+func (statsView *StatsView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering StatsView")
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	statsView.viewDimension = win.ViewDimensions()
+
+	lineNum := statsView.lineNumber()
+
+	if lineNum == 0 {
+		return statsView.renderEmptyView(win)
+	}
+
+	rows := statsView.pageRows()
+	viewPos := statsView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+	tableFormatter := statsView.tableFormatter
+	tableFormatter.Resize(rows)
+	tableFormatter.Clear()
+
+	authors := statsView.authorStatsSet.Authors()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		if err = statsView.renderAuthorStats(tableFormatter, rowIndex, authors[lineIndex]); err != nil {
+			return
+		}
+
+		lineIndex++
+	}
+
+	if err = tableFormatter.Render(win, viewPos.ViewStartColumn(), true); err != nil {
+		return
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, statsView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpStatsviewTitle, "Authors of %v by %v", statsView.ref.Shorthand(), statsView.sortOrderDescription()); err != nil {
+		return
+	}
+
+	if err = win.SetFooter(CmpStatsviewFooter, "Author %v of %v (%v commits)", viewPos.ActiveRowIndex()+1, lineNum, statsView.processedCommits); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := statsView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (statsView *StatsView) renderEmptyView(win RenderWindow) (err error) {
+	if err = win.SetRow(2, 1, CmpNone, "   No commits to display statistics for"); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return
+}
+
+func (statsView *StatsView) renderAuthorStats(tableFormatter *TableFormatter, rowIndex uint, authorStats *AuthorStats) (err error) {
+	colIndex := uint(0)
+
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewAuthor, "%v", authorStats.name); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewCommits, "%v commits", authorStats.commits); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewLinesAdded, "+%v", authorStats.added); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewLinesRemoved, "-%v", authorStats.removed); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewDate, "%v", authorStats.firstCommitDate.Format(stvDateFormat)); err != nil {
+		return
+	}
+
+	colIndex++
+	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpStatsviewDate, "%v", authorStats.lastCommitDate.Format(stvDateFormat)); err != nil {
+		return
+	}
+
+	return
+}
+
+func (statsView *StatsView) sortOrderDescription() string {
+	if statsView.sortOrder == SsoLinesChanged {
+		return "lines changed"
+	}
+
+	return "commit count"
+}
+
+// RenderHelpBar shows key bindings custom to the stats view
+func (statsView *StatsView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(statsView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionToggleStatsSortOrder, message: "Toggle sort order"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the stats view is the active view or not
+func (statsView *StatsView) OnActiveChange(active bool) {
+	log.Debugf("StatsView active: %v", active)
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	statsView.active = active
+}
+
+// ViewID returns the stats views ID
+func (statsView *StatsView) ViewID() ViewID {
+	return ViewStats
+}
+
+// HandleEvent does nothing
+func (statsView *StatsView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// ViewPos returns the current view position
+func (statsView *StatsView) ViewPos() ViewPos {
+	return statsView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (statsView *StatsView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	viewPos := statsView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Author statistics have changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleAction checks if the stats view supports the provided action and executes it if so
+func (statsView *StatsView) HandleAction(action Action) (err error) {
+	log.Debugf("StatsView handling action %v", action)
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	if handler, ok := statsView.handlers[action.ActionType]; ok {
+		err = handler(statsView, action)
+	} else {
+		_, err = statsView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the stats view at the specified line index
+func (statsView *StatsView) Line(lineIndex uint) (line string) {
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	lineNum := statsView.lineNumber()
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	authorStats := statsView.authorStatsSet.Authors()[lineIndex]
+	line = fmt.Sprintf("%v %v commits +%v -%v %v %v", authorStats.name, authorStats.commits, authorStats.added, authorStats.removed,
+		authorStats.firstCommitDate.Format(stvDateFormat), authorStats.lastCommitDate.Format(stvDateFormat))
+
+	return
+}
+
+// LineNumber returns the number of authors
+func (statsView *StatsView) LineNumber() (lineNumber uint) {
+	statsView.lock.Lock()
+	defer statsView.lock.Unlock()
+
+	return statsView.lineNumber()
+}
+
+func (statsView *StatsView) lineNumber() uint {
+	return uint(len(statsView.authorStatsSet.Authors()))
+}
+
+// pageRows returns the number of authors visible in the view
+// excluding the rows used by the border
+func (statsView *StatsView) pageRows() uint {
+	if statsView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return statsView.viewDimension.rows - 2
+}
+
+func moveDownStatsEntry(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MoveLinesDown(ActionCount(action), statsView.lineNumber()) {
+		log.Debugf("Moving down %v entries in stats view", ActionCount(action))
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStatsEntry(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v entries in stats view", ActionCount(action))
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownStatsPage(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MovePageDown(statsView.pageRows(), statsView.lineNumber()) {
+		log.Debugf("Moving down one page in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStatsPage(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MovePageUp(statsView.pageRows()) {
+		log.Debugf("Moving up one page in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownStatsHalfPage(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MovePageDown(statsView.pageRows()/2, statsView.lineNumber()) {
+		log.Debugf("Moving down half a page in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpStatsHalfPage(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MovePageUp(statsView.pageRows() / 2) {
+		log.Debugf("Moving up half a page in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollStatsViewRight(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+	viewPos.MovePageRight(statsView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	statsView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollStatsViewLeft(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MovePageLeft(statsView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstStatsEntry(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first entry in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastStatsEntry(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.MoveToLastLine(statsView.lineNumber()) {
+		log.Debugf("Moving to last entry in stats view")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerStatsView(statsView *StatsView, action Action) (err error) {
+	viewPos := statsView.viewPos
+
+	if viewPos.CenterActiveRow(statsView.pageRows()) {
+		log.Debug("Centering StatsView")
+		statsView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func toggleStatsSortOrder(statsView *StatsView, action Action) (err error) {
+	if statsView.sortOrder == SsoCommitCount {
+		statsView.sortOrder = SsoLinesChanged
+	} else {
+		statsView.sortOrder = SsoCommitCount
+	}
+
+	log.Debugf("Sorting authors by %v", statsView.sortOrderDescription())
+
+	statsView.authorStatsSet.Sort(statsView.sortOrder)
+	statsView.viewPos.SetActiveRowIndex(0)
+	statsView.channels.ReportStatus("Sorting authors by %v", statsView.sortOrderDescription())
+	statsView.channels.UpdateDisplay()
+
+	return
+}
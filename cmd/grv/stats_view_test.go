@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthorStatsSetAggregatesCommitsByAuthorEmail(t *testing.T) {
+	firstDate := time.Date(2017, 1, 2, 10, 0, 0, 0, time.UTC)
+	lastDate := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	authorStatsSet := NewAuthorStatsSet()
+	authorStatsSet.Add("Alice", "alice@example.com", lastDate, &DiffStat{added: 10, removed: 2})
+	authorStatsSet.Add("Bob", "bob@example.com", lastDate, &DiffStat{added: 100, removed: 50})
+	authorStatsSet.Add("Alice", "alice@example.com", firstDate, &DiffStat{added: 5, removed: 1})
+	authorStatsSet.Add("Alice", "alice@example.com", firstDate.AddDate(0, 1, 0), nil)
+	authorStatsSet.Sort(SsoCommitCount)
+
+	authors := authorStatsSet.Authors()
+	if len(authors) != 2 {
+		t.Fatalf("Expected 2 authors but found %v", len(authors))
+	}
+
+	alice := authors[0]
+	if alice.email != "alice@example.com" || alice.commits != 3 || alice.added != 15 || alice.removed != 3 {
+		t.Errorf("Unexpected statistics for first author: %+v", alice)
+	}
+
+	if !alice.firstCommitDate.Equal(firstDate) || !alice.lastCommitDate.Equal(lastDate) {
+		t.Errorf("Expected first and last commit dates %v and %v but found %v and %v",
+			firstDate, lastDate, alice.firstCommitDate, alice.lastCommitDate)
+	}
+
+	authorStatsSet.Sort(SsoLinesChanged)
+
+	if name := authorStatsSet.Authors()[0].name; name != "Bob" {
+		t.Errorf("Expected Bob to have changed the most lines but found %v", name)
+	}
+}
+
+func TestAuthorStatsSetOrdersAuthorsWithEqualValuesByName(t *testing.T) {
+	when := time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	authorStatsSet := NewAuthorStatsSet()
+	authorStatsSet.Add("Carol", "carol@example.com", when, &DiffStat{})
+	authorStatsSet.Add("Alice", "alice@example.com", when, &DiffStat{})
+	authorStatsSet.Add("Bob", "bob@example.com", when, &DiffStat{})
+	authorStatsSet.Sort(SsoCommitCount)
+
+	var names []string
+	for _, authorStats := range authorStatsSet.Authors() {
+		names = append(names, authorStats.name)
+	}
+
+	if expectedNames := []string{"Alice", "Bob", "Carol"}; !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected authors to be ordered as %v but found %v", expectedNames, names)
+	}
+}
+
+func TestStatsViewStopsListeningForCommitSetEventsOnDispose(t *testing.T) {
+	ref := newTestLocalBranch("master", newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t))
+
+	repoData := &MockRepoData{}
+	repoData.On("RegisterCommitSetListener", mock.Anything)
+	repoData.On("UnregisterCommitSetListener", mock.Anything)
+	repoData.On("LoadCommits", ref).Return(nil)
+	repoData.On("CommitSetState", ref).Return(CommitSetState{})
+
+	statsView := NewStatsView(repoData, newTestChannels(), ref)
+	if err := statsView.Initialise(); err != nil {
+		t.Fatalf("Failed to initialise StatsView: %v", err)
+	}
+
+	statsView.Dispose()
+	statsView.Dispose()
+
+	repoData.AssertCalled(t, "RegisterCommitSetListener", statsView)
+	repoData.AssertCalled(t, "UnregisterCommitSetListener", statsView)
+	repoData.AssertNumberOfCalls(t, "UnregisterCommitSetListener", 1)
+}
@@ -10,10 +10,17 @@ import (
 
 // The different prompt types grv uses
 const (
-	PromptText              = ":"
-	SearchPromptText        = "/"
-	ReverseSearchPromptText = "?"
-	FilterPromptText        = "query: "
+	PromptText               = ":"
+	SearchPromptText         = "/"
+	ReverseSearchPromptText  = "?"
+	FilterPromptText         = "query: "
+	DateFilterPromptText     = "date range (from..to): "
+	RefNameFilterPromptText  = "ref name: "
+	PathFilterPromptText     = "path: "
+	GotoCommitPromptText     = "commit: "
+	CreateTagPromptText      = "tag name: "
+	CreateBranchPromptText   = "branch name: "
+	OpenRepositoryPromptText = "repository path: "
 )
 
 type promptType int
@@ -23,6 +30,13 @@ const (
 	ptCommand
 	ptSearch
 	ptFilter
+	ptDateFilter
+	ptRefNameFilter
+	ptPathFilter
+	ptGotoCommit
+	ptCreateTag
+	ptCreateBranch
+	ptOpenRepository
 )
 
 // StatusBarView manages the display of the status bar
@@ -33,6 +47,9 @@ type StatusBarView struct {
 	active        bool
 	promptType    promptType
 	pendingStatus string
+	confirmPrompt *ConfirmPrompt
+	operations    []*Operation
+	spinner       *Spinner
 	lock          sync.Mutex
 }
 
@@ -42,6 +59,7 @@ func NewStatusBarView(repoData RepoData, channels *Channels, config ConfigSetter
 		repoData: repoData,
 		channels: channels,
 		config:   config,
+		spinner:  NewSpinner(),
 	}
 }
 
@@ -66,6 +84,20 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 		statusBarView.showSearchPrompt(ReverseSearchPromptText, ActionReverseSearch)
 	case ActionFilterPrompt:
 		statusBarView.showFilterPrompt()
+	case ActionDateFilterPrompt:
+		statusBarView.showInputPrompt(ptDateFilter, DateFilterPromptText, ActionAddDateFilter)
+	case ActionRefNameFilterPrompt:
+		err = statusBarView.showIncrementalInputPrompt(ptRefNameFilter, RefNameFilterPromptText, ActionUpdateRefNameFilter, ActionAddRefNameFilter, action)
+	case ActionPathFilterPrompt:
+		statusBarView.showInputPrompt(ptPathFilter, PathFilterPromptText, ActionAddPathFilter)
+	case ActionGotoCommitPrompt:
+		statusBarView.showInputPrompt(ptGotoCommit, GotoCommitPromptText, ActionGotoCommit)
+	case ActionCreateTagPrompt:
+		statusBarView.showInputPrompt(ptCreateTag, CreateTagPromptText, ActionCreateTag)
+	case ActionCreateBranchPrompt:
+		statusBarView.showInputPrompt(ptCreateBranch, CreateBranchPromptText, ActionCreateBranch)
+	case ActionOpenRepositoryPrompt:
+		statusBarView.showInputPrompt(ptOpenRepository, OpenRepositoryPromptText, ActionOpenRepository)
 	case ActionShowStatus:
 		statusBarView.lock.Lock()
 		defer statusBarView.lock.Unlock()
@@ -81,11 +113,67 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 		}
 
 		err = fmt.Errorf("Expected status argument but received: %v", action.Args)
+	case ActionConfirmPrompt:
+		statusBarView.lock.Lock()
+		defer statusBarView.lock.Unlock()
+
+		if len(action.Args) > 0 {
+			confirmPrompt, ok := action.Args[0].(*ConfirmPrompt)
+			if ok {
+				statusBarView.confirmPrompt = confirmPrompt
+				statusBarView.channels.UpdateDisplay()
+				return
+			}
+		}
+
+		err = fmt.Errorf("Expected confirm prompt argument but received: %v", action.Args)
+	case ActionOperationStarted, ActionOperationFinished:
+		statusBarView.lock.Lock()
+		defer statusBarView.lock.Unlock()
+
+		if len(action.Args) > 0 {
+			operation, ok := action.Args[0].(*Operation)
+			if ok {
+				if action.ActionType == ActionOperationStarted {
+					statusBarView.startOperation(operation)
+				} else {
+					statusBarView.finishOperation(operation)
+				}
+
+				statusBarView.channels.UpdateDisplay()
+				return
+			}
+		}
+
+		err = fmt.Errorf("Expected operation argument but received: %v", action.Args)
 	}
 
 	return
 }
 
+// startOperation displays the operation and starts the spinner if it is not already running
+func (statusBarView *StatusBarView) startOperation(operation *Operation) {
+	log.Debugf("Operation started: %v", operation.description)
+	statusBarView.operations = append(statusBarView.operations, operation)
+	statusBarView.spinner.Start(statusBarView.channels.UpdateDisplay)
+}
+
+// finishOperation stops displaying the operation. The spinner is stopped once no operations remain
+func (statusBarView *StatusBarView) finishOperation(operation *Operation) {
+	log.Debugf("Operation finished: %v", operation.description)
+
+	for index, runningOperation := range statusBarView.operations {
+		if runningOperation == operation {
+			statusBarView.operations = append(statusBarView.operations[:index], statusBarView.operations[index+1:]...)
+			break
+		}
+	}
+
+	if len(statusBarView.operations) == 0 {
+		statusBarView.spinner.Stop()
+	}
+}
+
 func (statusBarView *StatusBarView) showCommandPrompt() {
 	statusBarView.promptType = ptCommand
 	input := Prompt(PromptText)
@@ -126,6 +214,56 @@ func (statusBarView *StatusBarView) showFilterPrompt() {
 	statusBarView.promptType = ptNone
 }
 
+// showInputPrompt prompts for input which is passed as the argument to the provided action
+func (statusBarView *StatusBarView) showInputPrompt(promptType promptType, prompt string, actionType ActionType) {
+	statusBarView.promptType = promptType
+	input := Prompt(prompt)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: actionType,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+// showIncrementalInputPrompt prompts for input which is passed as the argument to the update action each time it changes.
+// The update action is handled immediately by the handler provided in the prompt action.
+// Once the prompt is complete the input is passed as the argument to the provided action
+func (statusBarView *StatusBarView) showIncrementalInputPrompt(promptType promptType, prompt string, updateActionType, actionType ActionType, promptAction Action) (err error) {
+	if len(promptAction.Args) == 0 {
+		return fmt.Errorf("Expected input handler argument")
+	}
+
+	inputHandler, ok := promptAction.Args[0].(func(Action) error)
+	if !ok {
+		return fmt.Errorf("Expected input handler argument but found %T", promptAction.Args[0])
+	}
+
+	statusBarView.promptType = promptType
+	input := PromptWithInputListener(prompt, func(input string) {
+		if err := inputHandler(Action{
+			ActionType: updateActionType,
+			Args:       []interface{}{input},
+		}); err != nil {
+			statusBarView.channels.ReportError(err)
+		}
+	})
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: actionType,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+
+	return
+}
+
 // OnActiveChange updates the active state of this view
 func (statusBarView *StatusBarView) OnActiveChange(active bool) {
 	statusBarView.lock.Lock()
@@ -168,7 +306,15 @@ func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 		}
 
 		err = win.SetCursor(0, uint(characters))
+	} else if statusBarView.confirmPrompt != nil && !statusBarView.confirmPrompt.Answered() {
+		lineBuilder.Append(" %v (y/n)", statusBarView.confirmPrompt.Question())
+		win.ApplyStyle(CmpStatusbarviewNormal)
+	} else if operationNum := len(statusBarView.operations); operationNum > 0 {
+		lineBuilder.Append(" %v %v", statusBarView.spinner.Frame(), statusBarView.operations[operationNum-1].description)
+		win.ApplyStyle(CmpStatusbarviewNormal)
 	} else {
+		statusBarView.confirmPrompt = nil
+
 		lineBuilder.Append(" %v", statusBarView.pendingStatus)
 		win.ApplyStyle(CmpStatusbarviewNormal)
 	}
@@ -187,6 +333,20 @@ func (statusBarView *StatusBarView) RenderHelpBar(lineBuilder *LineBuilder) (err
 		message = "Enter a regex pattern"
 	case ptFilter:
 		message = "Enter a filter query"
+	case ptDateFilter:
+		message = "Enter dates as YYYY-MM-DD or YYYY-MM-DD HH:MM:SS. Either date can be omitted"
+	case ptRefNameFilter:
+		message = "Enter a glob pattern or text the ref name contains"
+	case ptPathFilter:
+		message = "Enter a file or directory path relative to the repository root"
+	case ptGotoCommit:
+		message = "Enter a full or abbreviated commit id"
+	case ptCreateTag:
+		message = "Enter a name for the new tag"
+	case ptCreateBranch:
+		message = "Enter a name for the new branch"
+	case ptOpenRepository:
+		message = "Enter the path of a git repository"
 	}
 
 	if message != "" {
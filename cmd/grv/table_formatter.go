@@ -175,6 +175,86 @@ func (tableFormatter *TableFormatter) Render(win RenderWindow, viewStartColumn u
 	return
 }
 
+// CellColumns returns the column the specified cell starts at when rendered and the width of its text
+func (tableFormatter *TableFormatter) CellColumns(rowIndex, colIndex uint, border bool) (startColumn, width uint, err error) {
+	if rowIndex >= tableFormatter.Rows() || colIndex >= uint(len(tableFormatter.maxColWidths)) {
+		err = fmt.Errorf("Invalid rowIndex (%v), colIndex (%v) for dimensions rows (%v), cols (%v)",
+			rowIndex, colIndex, tableFormatter.Rows(), len(tableFormatter.maxColWidths))
+		return
+	}
+
+	tableFormatter.determineMaxColWidths(border)
+
+	startColumn = 1
+	if border {
+		startColumn++
+	}
+
+	for doneColIndex := uint(0); doneColIndex < colIndex; doneColIndex++ {
+		startColumn += tableFormatter.maxColWidths[doneColIndex] + uint(len(tfSeparator))
+	}
+
+	width = tableFormatter.textWidth(int(rowIndex), int(colIndex), startColumn)
+
+	return
+}
+
+// TruncateCell shortens the text of the specified cell so that it does not extend beyond lastColumn when rendered.
+// Truncated text ends with the provided suffix
+func (tableFormatter *TableFormatter) TruncateCell(rowIndex, colIndex, lastColumn uint, suffix string, border bool) (err error) {
+	startColumn, width, err := tableFormatter.CellColumns(rowIndex, colIndex, border)
+	if err != nil || startColumn > lastColumn || startColumn+width <= lastColumn+1 {
+		return
+	}
+
+	availableWidth := lastColumn - startColumn + 1
+
+	suffixWidth := uint(0)
+	for _, codePoint := range suffix {
+		suffixWidth += uint(RuneWidth(codePoint))
+	}
+
+	if suffixWidth > availableWidth {
+		suffix = ""
+		suffixWidth = 0
+	}
+
+	availableWidth -= suffixWidth
+
+	tableCell := &tableFormatter.cells[rowIndex][colIndex]
+	column := startColumn
+	var textEntries []tableCellText
+
+	for _, textEntry := range tableCell.textEntries {
+		var buf bytes.Buffer
+
+		for _, codePoint := range textEntry.text {
+			codePointWidth := uint(0)
+			for _, renderedCodePoint := range DetermineRenderedCodePoint(codePoint, column, tableFormatter.config) {
+				codePointWidth += renderedCodePoint.width
+			}
+
+			if codePointWidth > availableWidth {
+				buf.WriteString(suffix)
+				tableCell.textEntries = append(textEntries, tableCellText{
+					text:             buf.String(),
+					themeComponentID: textEntry.themeComponentID,
+				})
+
+				return
+			}
+
+			buf.WriteRune(codePoint)
+			availableWidth -= codePointWidth
+			column += codePointWidth
+		}
+
+		textEntries = append(textEntries, textEntry)
+	}
+
+	return
+}
+
 // PadCells pads each cell with whitespace so that the text in each column is of uniform width
 func (tableFormatter *TableFormatter) PadCells(border bool) (err error) {
 	tableFormatter.determineMaxColWidths(border)
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCellColumnsAccountsForPrecedingColumnWidths(t *testing.T) {
+	tableFormatter := NewTableFormatter(3)
+	tableFormatter.Resize(2)
+
+	tableFormatter.SetCell(0, 0, "abc")
+	tableFormatter.SetCell(1, 0, "abcdef")
+	tableFormatter.SetCell(0, 1, "x")
+	tableFormatter.SetCell(0, 2, "summary")
+
+	startColumn, width, err := tableFormatter.CellColumns(0, 2, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expectedStartColumn := uint(2 + 6 + 1 + 1 + 1); startColumn != expectedStartColumn {
+		t.Errorf("Start column does not match expected value. Expected: %v, Actual: %v", expectedStartColumn, startColumn)
+	}
+
+	if expectedWidth := uint(7); width != expectedWidth {
+		t.Errorf("Width does not match expected value. Expected: %v, Actual: %v", expectedWidth, width)
+	}
+}
+
+func TestCellColumnsReturnsErrorForInvalidCell(t *testing.T) {
+	tableFormatter := NewTableFormatter(1)
+	tableFormatter.Resize(1)
+
+	if _, _, err := tableFormatter.CellColumns(1, 0, true); err == nil {
+		t.Errorf("Expected error for invalid row index")
+	}
+}
+
+func TestTruncateCellEndsTextCutAtLastColumnWithSuffix(t *testing.T) {
+	var truncateCellTests = []struct {
+		lastColumn   uint
+		expectedText string
+	}{
+		{lastColumn: 20, expectedText: "ab 日本語 summary"},
+		{lastColumn: 18, expectedText: "ab 日本語 summary"},
+		{lastColumn: 17, expectedText: "ab 日本語 summa…"},
+		{lastColumn: 11, expectedText: "ab 日本語…"},
+		{lastColumn: 10, expectedText: "ab 日本…"},
+		{lastColumn: 8, expectedText: "ab 日…"},
+		{lastColumn: 1, expectedText: "ab 日本語 summary"},
+	}
+
+	for _, truncateCellTest := range truncateCellTests {
+		tableFormatter := NewTableFormatter(1)
+		tableFormatter.Resize(1)
+		tableFormatter.SetCell(0, 0, "ab ")
+		tableFormatter.AppendToCellWithStyle(0, 0, CmpCommitviewSummary, "日本語 summary")
+
+		if err := tableFormatter.TruncateCell(0, 0, truncateCellTest.lastColumn, "…", true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if text, _ := tableFormatter.RowString(0); text != truncateCellTest.expectedText+tfSeparator {
+			t.Errorf("Truncated cell does not match expected text for last column %v. Expected: %q, Actual: %q",
+				truncateCellTest.lastColumn, truncateCellTest.expectedText+tfSeparator, text)
+		}
+	}
+}
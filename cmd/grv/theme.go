@@ -12,6 +12,7 @@ const (
 	CmpAllviewSearchMatch
 	CmpAllviewActiveViewSelectedRow
 	CmpAllviewInactiveViewSelectedRow
+	CmpAllviewBorder
 
 	CmpMainviewActiveView
 	CmpMainviewNormalView
@@ -28,17 +29,40 @@ const (
 
 	CmpCommitviewTitle
 	CmpCommitviewFooter
+	CmpCommitviewLoadError
 	CmpCommitviewShortOid
 	CmpCommitviewDate
 	CmpCommitviewAuthor
+	CmpCommitviewGraph
 	CmpCommitviewSummary
 	CmpCommitviewTag
+	CmpCommitviewDecoration
 	CmpCommitviewLocalBranch
 	CmpCommitviewRemoteBranch
+	CmpCommitviewAuthorColor1
+	CmpCommitviewAuthorColor2
+	CmpCommitviewAuthorColor3
+	CmpCommitviewAuthorColor4
+	CmpCommitviewAuthorColor5
+	CmpCommitviewAuthorColor6
+	CmpCommitviewScrollBar
+	CmpCommitviewMinimap
+	CmpCommitviewMinimapWindow
+	CmpCommitviewPreview
+	CmpCommitviewPreviewSeparator
+	CmpCommitviewSignatureGood
+	CmpCommitviewSignatureBad
+	CmpCommitviewSignatureNone
+	CmpCommitviewDiffStatAdded
+	CmpCommitviewDiffStatRemoved
+	CmpCommitviewHeadMarker
+	CmpCommitviewMarkedCommit
 
 	CmpDiffviewTitle
 	CmpDiffviewFooter
 	CmpDiffviewDifflineNormal
+	CmpDiffviewDifflineDiffCommitOid
+	CmpDiffviewDifflineDiffCommitParent
 	CmpDiffviewDifflineDiffCommitAuthor
 	CmpDiffviewDifflineDiffCommitAuthorDate
 	CmpDiffviewDifflineDiffCommitCommitter
@@ -50,8 +74,12 @@ const (
 	CmpDiffviewDifflineUnifiedDiffHeader
 	CmpDiffviewDifflineHunkStart
 	CmpDiffviewDifflineHunkHeader
+	CmpDiffviewDifflineLineNumber
+	CmpDiffviewDifflineSubmodule
 	CmpDiffviewDifflineLineAdded
 	CmpDiffviewDifflineLineRemoved
+	CmpDiffviewDifflineLineAddedWord
+	CmpDiffviewDifflineLineRemovedWord
 
 	CmpGitStatusStagedTitle
 	CmpGitStatusUnstagedTitle
@@ -62,6 +90,47 @@ const (
 	CmpGitStatusUntrackedFile
 	CmpGitStatusConflictedFile
 
+	CmpBlameviewTitle
+	CmpBlameviewFooter
+	CmpBlameviewShortOid
+	CmpBlameviewRenamedFrom
+	CmpBlameviewAuthor
+	CmpBlameviewDate
+	CmpBlameviewLineNumber
+	CmpBlameviewLine
+
+	CmpReflogviewTitle
+	CmpReflogviewFooter
+	CmpReflogviewSelector
+	CmpReflogviewShortOid
+	CmpReflogviewDate
+	CmpReflogviewMessage
+
+	CmpTreeviewTitle
+	CmpTreeviewFooter
+	CmpTreeviewDirectory
+	CmpTreeviewFile
+
+	CmpStashviewTitle
+	CmpStashviewFooter
+	CmpStashviewSelector
+	CmpStashviewBranch
+	CmpStashviewMessage
+
+	CmpStatsviewTitle
+	CmpStatsviewFooter
+	CmpStatsviewAuthor
+	CmpStatsviewCommits
+	CmpStatsviewLinesAdded
+	CmpStatsviewLinesRemoved
+	CmpStatsviewDate
+
+	CmpHelpviewTitle
+	CmpHelpviewFooter
+	CmpHelpviewSectionTitle
+	CmpHelpviewKey
+	CmpHelpviewDescription
+
 	CmpStatusbarviewNormal
 
 	CmpHelpbarviewSpecial
@@ -74,6 +143,13 @@ const (
 	CmpCount
 )
 
+// themeComponentFallbacks maps theme components to the component displayed in their
+// place when the terminal does not support enough color pairs to display them
+var themeComponentFallbacks = map[ThemeComponentID]ThemeComponentID{
+	CmpDiffviewDifflineLineAddedWord:   CmpDiffviewDifflineLineAdded,
+	CmpDiffviewDifflineLineRemovedWord: CmpDiffviewDifflineLineRemoved,
+}
+
 // ThemeColor is a color that can be specified for a theme
 type ThemeColor interface {
 	themeColor()
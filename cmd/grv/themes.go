@@ -20,6 +20,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpAllviewBorder: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
 			CmpMainviewActiveView: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -36,6 +40,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
+			CmpCommitviewLoadError: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
 			CmpCommitviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorYellow),
@@ -48,6 +56,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorGreen),
 			},
+			CmpCommitviewGraph: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -56,6 +68,10 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
+			CmpCommitviewDecoration: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpCommitviewLocalBranch: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -64,6 +80,78 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorMagenta),
 			},
+			CmpCommitviewAuthorColor1: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewAuthorColor2: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewAuthorColor3: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewAuthorColor4: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewAuthorColor5: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewAuthorColor6: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewScrollBar: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewMinimap: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewMinimapWindow: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewPreview: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitviewPreviewSeparator: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewSignatureGood: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewSignatureBad: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewSignatureNone: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitviewDiffStatAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewDiffStatRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewHeadMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewMarkedCommit: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
 			CmpDiffviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -76,6 +164,14 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpDiffviewDifflineDiffCommitParent: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
 			CmpDiffviewDifflineDiffCommitAuthor: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -120,6 +216,14 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpDiffviewDifflineSubmodule: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
 			CmpDiffviewDifflineLineAdded: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorGreen),
@@ -128,6 +232,14 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
+			CmpDiffviewDifflineLineAddedWord: {
+				bgcolor: NewSystemColor(ColorGreen),
+				fgcolor: NewSystemColor(ColorBlack),
+			},
+			CmpDiffviewDifflineLineRemovedWord: {
+				bgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
 			CmpRefviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
@@ -220,231 +332,623 @@ func NewClassicTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
-		},
-	}
-}
-
-// NewColdTheme creates the cold theme of grv
-func NewColdTheme() MutableTheme {
-	return &ThemeComponents{
-		components: map[ThemeComponentID]*ThemeComponent{
-			CmpAllviewDefault: {
+			CmpBlameviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
-			},
-			CmpAllviewSearchMatch: {
-				bgcolor: NewSystemColor(ColorYellow),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpAllviewActiveViewSelectedRow: {
-				bgcolor: NewSystemColor(ColorWhite),
-				fgcolor: NewSystemColor(ColorBlue),
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpAllviewInactiveViewSelectedRow: {
+			CmpBlameviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
 			},
-			CmpMainviewActiveView: {
+			CmpBlameviewRenamedFrom: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpMainviewNormalView: {
-				bgcolor: NewSystemColor(ColorCyan),
-				fgcolor: NewSystemColor(ColorWhite),
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
 			},
-			CmpCommitviewTitle: {
+			CmpBlameviewDate: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpCommitviewFooter: {
+			CmpBlameviewLineNumber: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpCommitviewShortOid: {
+			CmpBlameviewLine: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpCommitviewDate: {
+			CmpReflogviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpCommitviewAuthor: {
+			CmpReflogviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpCommitviewSummary: {
+			CmpReflogviewSelector: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpCommitviewTag: {
-				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
-			},
-			CmpCommitviewLocalBranch: {
-				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
-			},
-			CmpCommitviewRemoteBranch: {
-				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
-			},
-			CmpDiffviewTitle: {
+			CmpReflogviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorBlue),
+				fgcolor: NewSystemColor(ColorYellow),
 			},
-			CmpDiffviewFooter: {
+			CmpReflogviewDate: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpDiffviewDifflineNormal: {
+			CmpReflogviewMessage: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpDiffviewDifflineDiffCommitAuthor: {
+			CmpTreeviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineDiffCommitAuthorDate: {
+			CmpTreeviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineDiffCommitCommitter: {
+			CmpTreeviewDirectory: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpTreeviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineDiffCommitCommitterDate: {
+			CmpStashviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineDiffCommitMessage: {
+			CmpStashviewSelector: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
+				fgcolor: NewSystemColor(ColorYellow),
 			},
-			CmpDiffviewDifflineDiffStatsFile: {
+			CmpStashviewBranch: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
 			},
-			CmpDiffviewDifflineGitDiffHeader: {
+			CmpStashviewMessage: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpDiffviewDifflineGitDiffExtendedHeader: {
+			CmpStatsviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineUnifiedDiffHeader: {
+			CmpStatsviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpDiffviewDifflineHunkStart: {
+			CmpStatsviewAuthor: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
+				fgcolor: NewSystemColor(ColorYellow),
 			},
-			CmpDiffviewDifflineHunkHeader: {
+			CmpStatsviewCommits: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorMagenta),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpDiffviewDifflineLineAdded: {
+			CmpStatsviewLinesAdded: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorGreen),
 			},
-			CmpDiffviewDifflineLineRemoved: {
+			CmpStatsviewLinesRemoved: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorRed),
 			},
-			CmpRefviewTitle: {
-				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorBlue),
-			},
-			CmpRefviewFooter: {
+			CmpStatsviewDate: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpRefviewLocalBranchesHeader: {
+			CmpHelpviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpRefviewRemoteBranchesHeader: {
+			CmpHelpviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpRefviewLocalBranch: {
+			CmpHelpviewSectionTitle: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
 			},
-			CmpRefviewHead: {
+			CmpHelpviewKey: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorGreen),
+				fgcolor: NewSystemColor(ColorMagenta),
 			},
-			CmpRefviewRemoteBranch: {
+			CmpHelpviewDescription: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpRefviewTagsHeader: {
+		},
+	}
+}
+
+// NewColdTheme creates the cold theme of grv
+func NewColdTheme() MutableTheme {
+	return &ThemeComponents{
+		components: map[ThemeComponentID]*ThemeComponent{
+			CmpAllviewDefault: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpRefviewTag: {
-				bgcolor: NewSystemColor(ColorNone),
+			CmpAllviewSearchMatch: {
+				bgcolor: NewSystemColor(ColorYellow),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpStatusbarviewNormal: {
-				bgcolor: NewSystemColor(ColorCyan),
-				fgcolor: NewSystemColor(ColorWhite),
+			CmpAllviewActiveViewSelectedRow: {
+				bgcolor: NewSystemColor(ColorWhite),
+				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpHelpbarviewSpecial: {
+			CmpAllviewInactiveViewSelectedRow: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpHelpbarviewNormal: {
+			CmpAllviewBorder: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpErrorViewTitle: {
+			CmpMainviewActiveView: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpMainviewNormalView: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpCommitviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpErrorViewFooter: {
+			CmpCommitviewFooter: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpErrorViewErrors: {
-				bgcolor: NewSystemColor(ColorRed),
-				fgcolor: NewSystemColor(ColorWhite),
+			CmpCommitviewLoadError: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
 			},
-			CmpGitStatusStagedTitle: {
+			CmpCommitviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorMagenta),
 			},
-			CmpGitStatusUnstagedTitle: {
+			CmpCommitviewDate: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpGitStatusUntrackedTitle: {
+			CmpCommitviewAuthor: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorCyan),
 			},
-			CmpGitStatusConflictedTitle: {
+			CmpCommitviewGraph: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorBlue),
 			},
-			CmpGitStatusStagedFile: {
+			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorGreen),
+				fgcolor: NewSystemColor(ColorNone),
 			},
-			CmpGitStatusUnstagedFile: {
+			CmpCommitviewTag: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorMagenta),
 			},
-			CmpGitStatusUntrackedFile: {
+			CmpCommitviewDecoration: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorMagenta),
 			},
-			CmpGitStatusConflictedFile: {
+			CmpCommitviewLocalBranch: {
 				bgcolor: NewSystemColor(ColorNone),
-				fgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorMagenta),
 			},
-		},
+			CmpCommitviewRemoteBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewAuthorColor1: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewAuthorColor2: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewAuthorColor3: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewAuthorColor4: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewAuthorColor5: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewAuthorColor6: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewScrollBar: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewMinimap: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewMinimapWindow: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpCommitviewPreview: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitviewPreviewSeparator: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewSignatureGood: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewSignatureBad: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewSignatureNone: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpCommitviewDiffStatAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewDiffStatRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewHeadMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewMarkedCommit: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpDiffviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpDiffviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpDiffviewDifflineNormal: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpDiffviewDifflineDiffCommitOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpDiffviewDifflineDiffCommitParent: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpDiffviewDifflineDiffCommitAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineDiffCommitAuthorDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineDiffCommitCommitter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineDiffCommitCommitterDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineDiffCommitMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpDiffviewDifflineDiffStatsFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpDiffviewDifflineGitDiffHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpDiffviewDifflineGitDiffExtendedHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpDiffviewDifflineUnifiedDiffHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpDiffviewDifflineHunkStart: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpDiffviewDifflineHunkHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpDiffviewDifflineSubmodule: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpDiffviewDifflineLineAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpDiffviewDifflineLineRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpDiffviewDifflineLineAddedWord: {
+				bgcolor: NewSystemColor(ColorGreen),
+				fgcolor: NewSystemColor(ColorBlack),
+			},
+			CmpDiffviewDifflineLineRemovedWord: {
+				bgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpRefviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpRefviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpRefviewLocalBranchesHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpRefviewRemoteBranchesHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpRefviewLocalBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpRefviewHead: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpRefviewRemoteBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpRefviewTagsHeader: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpRefviewTag: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStatusbarviewNormal: {
+				bgcolor: NewSystemColor(ColorCyan),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpHelpbarviewSpecial: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpHelpbarviewNormal: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpErrorViewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpErrorViewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpErrorViewErrors: {
+				bgcolor: NewSystemColor(ColorRed),
+				fgcolor: NewSystemColor(ColorWhite),
+			},
+			CmpGitStatusStagedTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpGitStatusUnstagedTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpGitStatusUntrackedTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpGitStatusConflictedTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpGitStatusStagedFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpGitStatusUnstagedFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpGitStatusUntrackedFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpGitStatusConflictedFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpBlameviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpBlameviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpBlameviewRenamedFrom: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpBlameviewLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpBlameviewLine: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpReflogviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpReflogviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpReflogviewSelector: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpReflogviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpReflogviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpReflogviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpTreeviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpTreeviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpTreeviewDirectory: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpTreeviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStashviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStashviewSelector: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStashviewBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpStashviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStatsviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStatsviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStatsviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpStatsviewCommits: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStatsviewLinesAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpStatsviewLinesRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpStatsviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpHelpviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpHelpviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpHelpviewSectionTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpHelpviewKey: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpHelpviewDescription: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+		},
 	}
 }
 
@@ -469,6 +973,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(245),
 			},
+			CmpAllviewBorder: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
 			CmpMainviewActiveView: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(254),
@@ -485,6 +993,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(37),
 			},
+			CmpCommitviewLoadError: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
 			CmpCommitviewShortOid: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(136),
@@ -497,6 +1009,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(64),
 			},
+			CmpCommitviewGraph: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(61),
+			},
 			CmpCommitviewSummary: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
@@ -505,6 +1021,10 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(160),
 			},
+			CmpCommitviewDecoration: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
 			CmpCommitviewLocalBranch: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(37),
@@ -513,6 +1033,78 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(125),
 			},
+			CmpCommitviewAuthorColor1: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorRed),
+			},
+			CmpCommitviewAuthorColor2: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorGreen),
+			},
+			CmpCommitviewAuthorColor3: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorYellow),
+			},
+			CmpCommitviewAuthorColor4: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorBlue),
+			},
+			CmpCommitviewAuthorColor5: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorMagenta),
+			},
+			CmpCommitviewAuthorColor6: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorCyan),
+			},
+			CmpCommitviewScrollBar: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpCommitviewMinimap: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(66),
+			},
+			CmpCommitviewMinimapWindow: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpCommitviewPreview: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(245),
+			},
+			CmpCommitviewPreviewSeparator: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpCommitviewSignatureGood: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpCommitviewSignatureBad: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpCommitviewSignatureNone: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(240),
+			},
+			CmpCommitviewDiffStatAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpCommitviewDiffStatRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpCommitviewHeadMarker: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpCommitviewMarkedCommit: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(125),
+			},
 			CmpDiffviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(37),
@@ -525,6 +1117,14 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewSystemColor(ColorNone),
 			},
+			CmpDiffviewDifflineDiffCommitOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpDiffviewDifflineDiffCommitParent: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
 			CmpDiffviewDifflineDiffCommitAuthor: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(61),
@@ -569,6 +1169,14 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(33),
 			},
+			CmpDiffviewDifflineLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(244),
+			},
+			CmpDiffviewDifflineSubmodule: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(125),
+			},
 			CmpDiffviewDifflineLineAdded: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(64),
@@ -577,6 +1185,14 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(160),
 			},
+			CmpDiffviewDifflineLineAddedWord: {
+				bgcolor: NewColorNumber(64),
+				fgcolor: NewColorNumber(230),
+			},
+			CmpDiffviewDifflineLineRemovedWord: {
+				bgcolor: NewColorNumber(160),
+				fgcolor: NewColorNumber(230),
+			},
 			CmpRefviewTitle: {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(37),
@@ -669,6 +1285,146 @@ func NewSolarizedTheme() MutableTheme {
 				bgcolor: NewSystemColor(ColorNone),
 				fgcolor: NewColorNumber(160),
 			},
+			CmpBlameviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpBlameviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpBlameviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpBlameviewRenamedFrom: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpBlameviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpBlameviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(33),
+			},
+			CmpBlameviewLineNumber: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpBlameviewLine: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpReflogviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpReflogviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpReflogviewSelector: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpReflogviewShortOid: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpReflogviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(33),
+			},
+			CmpReflogviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpTreeviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpTreeviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpTreeviewDirectory: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(33),
+			},
+			CmpTreeviewFile: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStashviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStashviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStashviewSelector: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpStashviewBranch: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpStashviewMessage: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStatsviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStatsviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpStatsviewAuthor: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpStatsviewCommits: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
+			CmpStatsviewLinesAdded: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(64),
+			},
+			CmpStatsviewLinesRemoved: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(160),
+			},
+			CmpStatsviewDate: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(33),
+			},
+			CmpHelpviewTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpHelpviewFooter: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(37),
+			},
+			CmpHelpviewSectionTitle: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(136),
+			},
+			CmpHelpviewKey: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewColorNumber(125),
+			},
+			CmpHelpviewDescription: {
+				bgcolor: NewSystemColor(ColorNone),
+				fgcolor: NewSystemColor(ColorNone),
+			},
 		},
 	}
 }
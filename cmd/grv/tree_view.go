@@ -0,0 +1,557 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	tvParentDirName = ".."
+	tvPathSeparator = "/"
+)
+
+type treeViewHandler func(*TreeView, Action) error
+
+// FileHistoryListener is notified when the history of a file or directory is requested
+type FileHistoryListener interface {
+	OnFileHistoryRequested(path string) error
+}
+
+// TreeView displays the directory structure of the tree of a commit
+type TreeView struct {
+	channels         *Channels
+	repoData         RepoData
+	commitOid        *Oid
+	path             string
+	treeEntries      []*TreeEntry
+	parentViewPoss   []ViewPos
+	viewPos          ViewPos
+	viewDimension    ViewDimension
+	handlers         map[ActionType]treeViewHandler
+	active           bool
+	viewSearch       *ViewSearch
+	historyListeners []FileHistoryListener
+	lock             sync.Mutex
+}
+
+// NewTreeView creates a new instance of the tree view
+func NewTreeView(repoData RepoData, channels *Channels) *TreeView {
+	treeView := &TreeView{
+		repoData: repoData,
+		channels: channels,
+		viewPos:  NewViewPosition(),
+		handlers: map[ActionType]treeViewHandler{
+			ActionPrevLine:            moveUpTreeEntry,
+			ActionNextLine:            moveDownTreeEntry,
+			ActionPrevPage:            moveUpTreePage,
+			ActionNextPage:            moveDownTreePage,
+			ActionPrevHalfPage:        moveUpTreeHalfPage,
+			ActionNextHalfPage:        moveDownTreeHalfPage,
+			ActionScrollRight:         scrollTreeViewRight,
+			ActionScrollLeft:          scrollTreeViewLeft,
+			ActionFirstLine:           moveToFirstTreeEntry,
+			ActionLastLine:            moveToLastTreeEntry,
+			ActionCenterView:          centerTreeView,
+			ActionSelect:              selectTreeEntry,
+			ActionTreeParentDirectory: moveToParentDirectory,
+			ActionOpenFileInEditor:    openTreeEntryInEditor,
+			ActionShowFileHistory:     showTreeEntryHistory,
+		},
+	}
+
+	treeView.viewSearch = NewViewSearch(treeView, channels)
+
+	return treeView
+}
+
+// Initialise does nothing
+func (treeView *TreeView) Initialise() (err error) {
+	log.Info("Initialising TreeView")
+	return
+}
+
+// OnCommitSelected displays the tree of the selected commit
+// The current directory is retained if it exists in the tree of the selected commit
+func (treeView *TreeView) OnCommitSelected(commit *Commit) (err error) {
+	log.Debugf("TreeView loading tree for commit %v", commit.oid)
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	treeView.commitOid = commit.oid
+	treeView.viewPos = NewViewPosition()
+
+	if err = treeView.loadTree(treeView.path); err != nil && treeView.path != "" {
+		log.Debugf("Unable to load directory %v for commit %v: %v. Loading root directory", treeView.path, commit.oid, err)
+		treeView.parentViewPoss = nil
+		err = treeView.loadTree("")
+	}
+
+	treeView.channels.UpdateDisplay()
+
+	return
+}
+
+// RegisterFileHistoryListener adds a listener to be notified when the history of an entry is requested
+func (treeView *TreeView) RegisterFileHistoryListener(historyListener FileHistoryListener) {
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	treeView.historyListeners = append(treeView.historyListeners, historyListener)
+}
+
+func (treeView *TreeView) loadTree(path string) (err error) {
+	treeEntries, err := treeView.repoData.Tree(treeView.commitOid, path)
+	if err != nil {
+		return
+	}
+
+	if path != "" {
+		treeEntries = append([]*TreeEntry{{name: tvParentDirName, isDir: true}}, treeEntries...)
+	}
+
+	treeView.path = path
+	treeView.treeEntries = treeEntries
+
+	return
+}
+
+// Render generates and writes the tree view to the provided window
+func (treeView *TreeView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering TreeView")
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	treeView.viewDimension = win.ViewDimensions()
+
+	lineNum := treeView.lineNumber()
+
+	if lineNum == 0 {
+		return treeView.renderEmptyView(win)
+	}
+
+	rows := treeView.pageRows()
+	viewPos := treeView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum)
+
+	lineIndex := viewPos.ViewStartRowIndex()
+
+	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, viewPos.ViewStartColumn()); err != nil {
+			return
+		}
+
+		treeEntry := treeView.treeEntries[lineIndex]
+		lineBuilder.Append(" ")
+
+		if treeEntry.isDir {
+			lineBuilder.AppendWithStyle(CmpTreeviewDirectory, "%v", treeEntryDisplayName(treeEntry))
+		} else {
+			lineBuilder.AppendWithStyle(CmpTreeviewFile, "%v", treeEntryDisplayName(treeEntry))
+		}
+
+		lineIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, treeView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpTreeviewTitle, "Tree for %v:%v%v", treeView.commitOid.ShortID(), tvPathSeparator, treeView.path); err != nil {
+		return
+	}
+
+	if err = win.SetFooter(CmpTreeviewFooter, "Entry %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+		return
+	}
+
+	if searchActive, searchPattern, lastSearchFoundMatch := treeView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (treeView *TreeView) renderEmptyView(win RenderWindow) (err error) {
+	message := "No tree entries to display"
+	if treeView.commitOid == nil {
+		message = "No commit selected"
+	}
+
+	if err = win.SetRow(2, 1, CmpNone, "   %v", message); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return
+}
+
+func treeEntryDisplayName(treeEntry *TreeEntry) string {
+	if treeEntry.isDir && treeEntry.name != tvParentDirName {
+		return treeEntry.name + tvPathSeparator
+	}
+
+	return treeEntry.name
+}
+
+// RenderHelpBar shows key bindings custom to the tree view
+func (treeView *TreeView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(treeView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Open"},
+		{action: ActionTreeParentDirectory, message: "Parent Directory"},
+		{action: ActionOpenFileInEditor, message: "Edit"},
+		{action: ActionShowFileHistory, message: "History"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the tree view is the active view or not
+func (treeView *TreeView) OnActiveChange(active bool) {
+	log.Debugf("TreeView active: %v", active)
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	treeView.active = active
+}
+
+// ViewID returns the tree views ID
+func (treeView *TreeView) ViewID() ViewID {
+	return ViewTree
+}
+
+// HandleEvent does nothing
+func (treeView *TreeView) HandleEvent(event Event) (err error) {
+	return
+}
+
+// ViewPos returns the current view position
+func (treeView *TreeView) ViewPos() ViewPos {
+	return treeView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (treeView *TreeView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	viewPos := treeView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Tree has changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleAction checks if the tree view supports the provided action and executes it if so
+func (treeView *TreeView) HandleAction(action Action) (err error) {
+	log.Debugf("TreeView handling action %v", action)
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	if handler, ok := treeView.handlers[action.ActionType]; ok {
+		err = handler(treeView, action)
+	} else {
+		_, err = treeView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the tree view at the specified line index
+func (treeView *TreeView) Line(lineIndex uint) (line string) {
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	lineNum := treeView.lineNumber()
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	return treeEntryDisplayName(treeView.treeEntries[lineIndex])
+}
+
+// LineNumber returns the number of entries in the current directory
+func (treeView *TreeView) LineNumber() (lineNumber uint) {
+	treeView.lock.Lock()
+	defer treeView.lock.Unlock()
+
+	return treeView.lineNumber()
+}
+
+func (treeView *TreeView) lineNumber() uint {
+	return uint(len(treeView.treeEntries))
+}
+
+// pageRows returns the number of tree entries visible in the view
+// excluding the rows used by the border
+func (treeView *TreeView) pageRows() uint {
+	if treeView.viewDimension.rows < 2 {
+		return 0
+	}
+
+	return treeView.viewDimension.rows - 2
+}
+
+func (treeView *TreeView) entryPath(treeEntry *TreeEntry) string {
+	if treeView.path == "" {
+		return treeEntry.name
+	}
+
+	return treeView.path + tvPathSeparator + treeEntry.name
+}
+
+func (treeView *TreeView) enterDirectory(treeEntry *TreeEntry) (err error) {
+	if err = treeView.loadTree(treeView.entryPath(treeEntry)); err != nil {
+		return
+	}
+
+	log.Debugf("Entered directory %v", treeView.path)
+
+	treeView.parentViewPoss = append(treeView.parentViewPoss, treeView.viewPos)
+	treeView.viewPos = NewViewPosition()
+	treeView.channels.UpdateDisplay()
+
+	return
+}
+
+func (treeView *TreeView) showFile(treeEntry *TreeEntry) {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = cvPager
+	}
+
+	path := treeView.entryPath(treeEntry)
+	log.Debugf("Showing file %v at commit %v using pager %v", path, treeView.commitOid, pager)
+
+	treeView.channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{ActionRunCommandArgs{
+			command: "git",
+			args:    []string{"--git-dir", treeView.repoData.Path(), "show", fmt.Sprintf("%v:%v", treeView.commitOid, path)},
+			env:     []string{"GIT_PAGER=" + pager},
+		}},
+	})
+}
+
+func moveDownTreeEntry(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MoveLinesDown(ActionCount(action), treeView.lineNumber()) {
+		log.Debugf("Moving down %v entries in tree view", ActionCount(action))
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpTreeEntry(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MoveLinesUp(ActionCount(action)) {
+		log.Debugf("Moving up %v entries in tree view", ActionCount(action))
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownTreePage(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MovePageDown(treeView.pageRows(), treeView.lineNumber()) {
+		log.Debugf("Moving down one page in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpTreePage(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MovePageUp(treeView.pageRows()) {
+		log.Debugf("Moving up one page in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownTreeHalfPage(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MovePageDown(treeView.pageRows()/2, treeView.lineNumber()) {
+		log.Debugf("Moving down half a page in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpTreeHalfPage(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MovePageUp(treeView.pageRows() / 2) {
+		log.Debugf("Moving up half a page in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollTreeViewRight(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+	viewPos.MovePageRight(treeView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	treeView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollTreeViewLeft(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MovePageLeft(treeView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstTreeEntry(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first entry in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastTreeEntry(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.MoveToLastLine(treeView.lineNumber()) {
+		log.Debugf("Moving to last entry in tree view")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerTreeView(treeView *TreeView, action Action) (err error) {
+	viewPos := treeView.viewPos
+
+	if viewPos.CenterActiveRow(treeView.pageRows()) {
+		log.Debug("Centering TreeView")
+		treeView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func selectTreeEntry(treeView *TreeView, action Action) (err error) {
+	if treeView.lineNumber() == 0 {
+		return
+	}
+
+	treeEntry := treeView.treeEntries[treeView.viewPos.ActiveRowIndex()]
+
+	switch {
+	case treeEntry.name == tvParentDirName:
+		err = moveToParentDirectory(treeView, action)
+	case treeEntry.isDir:
+		err = treeView.enterDirectory(treeEntry)
+	default:
+		treeView.showFile(treeEntry)
+	}
+
+	return
+}
+
+func openTreeEntryInEditor(treeView *TreeView, action Action) (err error) {
+	if treeView.lineNumber() == 0 {
+		return
+	}
+
+	treeEntry := treeView.treeEntries[treeView.viewPos.ActiveRowIndex()]
+	if treeEntry.isDir {
+		treeView.channels.ReportStatus("%v is a directory", treeEntry.name)
+		return
+	}
+
+	return openFileInEditor(treeView.repoData, treeView.channels, treeView.entryPath(treeEntry), treeView.commitOid)
+}
+
+func showTreeEntryHistory(treeView *TreeView, action Action) (err error) {
+	if treeView.lineNumber() == 0 {
+		return
+	}
+
+	treeEntry := treeView.treeEntries[treeView.viewPos.ActiveRowIndex()]
+	if treeEntry.name == tvParentDirName {
+		return
+	}
+
+	if len(treeView.historyListeners) == 0 {
+		return fmt.Errorf("No commit view is available to show the history of %v", treeEntry.name)
+	}
+
+	path := treeView.entryPath(treeEntry)
+	historyListeners := append([]FileHistoryListener(nil), treeView.historyListeners...)
+	log.Debugf("Requesting history of %v", path)
+
+	go func() {
+		for _, historyListener := range historyListeners {
+			if err := historyListener.OnFileHistoryRequested(path); err != nil {
+				treeView.channels.ReportError(err)
+			}
+		}
+	}()
+
+	return
+}
+
+func moveToParentDirectory(treeView *TreeView, action Action) (err error) {
+	if treeView.path == "" {
+		return
+	}
+
+	parentPath := ""
+	if separatorIndex := strings.LastIndex(treeView.path, tvPathSeparator); separatorIndex != -1 {
+		parentPath = treeView.path[:separatorIndex]
+	}
+
+	if err = treeView.loadTree(parentPath); err != nil {
+		return
+	}
+
+	log.Debugf("Moved to parent directory \"%v\"", parentPath)
+
+	if parentViewPosNum := len(treeView.parentViewPoss); parentViewPosNum > 0 {
+		treeView.viewPos = treeView.parentViewPoss[parentViewPosNum-1]
+		treeView.parentViewPoss = treeView.parentViewPoss[:parentViewPosNum-1]
+	} else {
+		treeView.viewPos = NewViewPosition()
+	}
+
+	treeView.channels.UpdateDisplay()
+
+	return
+}
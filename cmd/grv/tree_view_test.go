@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTreeViewEntersAndLeavesDirectories(t *testing.T) {
+	oid := newTestOid("300dc7fd7cf162e89136ad3b1b8b4b7bd7dd13a5", t)
+	commit := &Commit{oid: oid}
+
+	repoData := &MockRepoData{}
+	repoData.On("Tree", oid, "").Return([]*TreeEntry{
+		{name: "cmd", isDir: true},
+		{name: "doc", isDir: true},
+		{name: "README.md"},
+	}, nil)
+	repoData.On("Tree", oid, "doc").Return([]*TreeEntry{
+		{name: "documentation.md"},
+	}, nil)
+
+	treeView := NewTreeView(repoData, newTestChannels())
+	treeView.viewDimension = ViewDimension{rows: 12, cols: 80}
+
+	if err := treeView.OnCommitSelected(commit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	treeView.HandleAction(Action{ActionType: ActionNextLine})
+	treeView.HandleAction(Action{ActionType: ActionSelect})
+
+	if treeView.path != "doc" {
+		t.Fatalf("Expected path to be doc but found %v", treeView.path)
+	}
+
+	if line := treeView.Line(0); line != tvParentDirName {
+		t.Errorf("Expected first entry to be %v but found %v", tvParentDirName, line)
+	}
+
+	treeView.HandleAction(Action{ActionType: ActionTreeParentDirectory})
+
+	if treeView.path != "" {
+		t.Fatalf("Expected root path but found %v", treeView.path)
+	}
+
+	if activeRowIndex := treeView.viewPos.ActiveRowIndex(); activeRowIndex != 1 {
+		t.Errorf("Expected active row index to be restored to 1 but found %v", activeRowIndex)
+	}
+
+	if line := treeView.Line(0); line != "cmd/" {
+		t.Errorf("Expected directory to be displayed as cmd/ but found %v", line)
+	}
+}
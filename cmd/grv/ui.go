@@ -40,6 +40,9 @@ const (
 	// UINoKey is the value returned when there was no user input available
 	UINoKey         = -1
 	inputNoWinSleep = 50 * time.Millisecond
+	// goncurses doesn't expose button 5 which is used for scroll down events
+	ncMouseButton5Pressed = gc.MouseButton(0x200000)
+	ncMouseEventMask      = gc.M_B1_PRESSED | gc.M_B1_CLICKED | gc.M_B4_PRESSED | ncMouseButton5Pressed
 )
 
 var systemColors = map[SystemColorValue]int16{
@@ -84,6 +87,38 @@ var color256GreyComponents = []byte{
 // Key is a raw code received from ncurses
 type Key int
 
+// MouseEventType describes the type of mouse event that occurred
+type MouseEventType int
+
+// The set of supported mouse events
+const (
+	MetLeftClick MouseEventType = iota
+	MetScrollUp
+	MetScrollDown
+)
+
+// MouseEvent contains the type and screen position of a mouse event
+type MouseEvent struct {
+	mouseEventType MouseEventType
+	row            uint
+	col            uint
+}
+
+// GetMouseEventFromAction extracts the mouse event from the arguments of a mouse action
+func GetMouseEventFromAction(action Action) (mouseEvent MouseEvent, err error) {
+	if len(action.Args) == 0 {
+		err = fmt.Errorf("Expected MouseEvent argument for action %v", action.ActionType)
+		return
+	}
+
+	mouseEvent, ok := action.Args[0].(MouseEvent)
+	if !ok {
+		err = fmt.Errorf("Expected first argument to be MouseEvent but found %T", action.Args[0])
+	}
+
+	return
+}
+
 // InputUI is capable of providing input from the UI
 type InputUI interface {
 	GetInput(force bool) (Key, error)
@@ -97,6 +132,7 @@ type UI interface {
 	Resize() error
 	ViewDimension() ViewDimension
 	Update([]*Window) error
+	GetMouseEvent() (MouseEvent, bool)
 	Suspend()
 	Resume() error
 	Free()
@@ -184,6 +220,7 @@ func (ui *NCursesUI) Initialise() (err error) {
 	}
 
 	ui.config.AddOnChangeListener(CfTheme, ui)
+	ui.config.AddOnChangeListener(CfMouse, ui)
 
 	read, write, err := os.Pipe()
 	if err != nil {
@@ -235,9 +272,22 @@ func (ui *NCursesUI) initialiseNCurses() (err error) {
 		return fmt.Errorf("NCurses Keypad failed: %v", err)
 	}
 
+	ui.setMouseEnabled(ui.config.GetBool(CfMouse))
+
 	return
 }
 
+func (ui *NCursesUI) setMouseEnabled(enabled bool) {
+	var mouseMask gc.MouseButton
+
+	if enabled {
+		mouseMask = ncMouseEventMask
+	}
+
+	log.Debugf("Setting mouse mask to %v", mouseMask)
+	gc.MouseMask(mouseMask, nil)
+}
+
 // Suspend ends ncurses to leave the terminal in the correct state when
 // GRV is suspended
 func (ui *NCursesUI) Suspend() {
@@ -256,7 +306,19 @@ func (ui *NCursesUI) Resume() (err error) {
 
 	ui.stdscr.Refresh()
 	ui.suspended = false
-	return ui.resize()
+
+	if err = ui.resize(); err != nil {
+		return
+	}
+
+	return ui.cancelGetInput()
+}
+
+func (ui *NCursesUI) isSuspended() bool {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+
+	return ui.suspended
 }
 
 // Resize determines the current terminal dimensions reinitialises NCurses
@@ -331,8 +393,18 @@ func (ui *NCursesUI) createAndUpdateWindows(wins []*Window) (err error) {
 		winMap[win] = true
 	}
 
+	layoutChanged := false
+
 	for win, nwin := range ui.windows {
 		if _, ok := winMap[win]; ok {
+			rows, cols := nwin.MaxYX()
+			startRow, startCol := nwin.YX()
+
+			if nwin.hidden() || rows != int(win.rows) || cols != int(win.cols) ||
+				startRow != int(win.startRow) || startCol != int(win.startCol) {
+				layoutChanged = true
+			}
+
 			nwin.Resize(int(win.rows), int(win.cols))
 			nwin.MoveWindow(int(win.startRow), int(win.startCol))
 			nwin.setHidden(false)
@@ -342,6 +414,7 @@ func (ui *NCursesUI) createAndUpdateWindows(wins []*Window) (err error) {
 			nwin.Resize(0, 0)
 			nwin.NoutRefresh()
 			nwin.setHidden(true)
+			layoutChanged = true
 			log.Debugf("Hiding NCurses window %v - %v:%v", win.ID())
 		}
 	}
@@ -373,6 +446,16 @@ func (ui *NCursesUI) createAndUpdateWindows(wins []*Window) (err error) {
 
 			ui.windows[win] = nwin
 		}
+
+		layoutChanged = true
+	}
+
+	if layoutChanged {
+		log.Debug("Window layout changed. Redrawing all rows")
+
+		for _, win := range wins {
+			win.Invalidate()
+		}
 	}
 
 	return
@@ -380,10 +463,22 @@ func (ui *NCursesUI) createAndUpdateWindows(wins []*Window) (err error) {
 
 func (ui *NCursesUI) drawWindows(wins []*Window) (err error) {
 	var cursorWin *Window
+	var redrawnWins []*Window
 
 	for _, win := range wins {
 		if nwin, ok := ui.windows[win]; ok {
-			drawWindow(win, nwin)
+			// Windows drawn later are layered on top of earlier windows.
+			// Redraw them in full if any window beneath them has repainted rows
+			for _, redrawnWin := range redrawnWins {
+				if win.Overlaps(redrawnWin) {
+					win.Invalidate()
+					break
+				}
+			}
+
+			if drawWindow(win, nwin, ui.maxColorPairs) {
+				redrawnWins = append(redrawnWins, win)
+			}
 
 			if win.IsCursorSet() {
 				cursorWin = win
@@ -412,12 +507,15 @@ func (ui *NCursesUI) drawWindows(wins []*Window) (err error) {
 	return
 }
 
-func drawWindow(win *Window, nwin *nCursesWindow) {
+func drawWindow(win *Window, nwin *nCursesWindow, maxColorPairs int) (redrawn bool) {
 	log.Debugf("Drawing window %v", win.ID())
 
 	nwin.SetBackground(gc.ColorPair(int16(CmpAllviewDefault)))
 
-	for rowIndex := uint(0); rowIndex < win.rows; rowIndex++ {
+	rowIndexes := win.ChangedRows()
+	log.Debugf("Redrawing %v of %v rows for window %v", len(rowIndexes), win.rows, win.ID())
+
+	for _, rowIndex := range rowIndexes {
 		line := win.lines[rowIndex]
 		nwin.Move(int(rowIndex), 0)
 
@@ -425,7 +523,12 @@ func drawWindow(win *Window, nwin *nCursesWindow) {
 			cell := line.cells[colIndex]
 
 			if cell.style.acsChar != 0 || cell.codePoints.Len() > 0 {
-				attr := cell.style.attr | gc.ColorPair(int16(cell.style.themeComponentID))
+				themeComponentID := cell.style.themeComponentID
+				if fallbackComponentID, ok := themeComponentFallbacks[themeComponentID]; ok && int(themeComponentID) >= maxColorPairs {
+					themeComponentID = fallbackComponentID
+				}
+
+				attr := cell.style.attr | gc.ColorPair(int16(themeComponentID))
 				if err := nwin.AttrOn(attr); err != nil {
 					log.Errorf("Error when attempting to set AttrOn with %v: %v", attr, err)
 				}
@@ -444,6 +547,8 @@ func drawWindow(win *Window, nwin *nCursesWindow) {
 	}
 
 	nwin.NoutRefresh()
+
+	return len(rowIndexes) > 0
 }
 
 // GetInput blocks until user input is available
@@ -452,7 +557,8 @@ func drawWindow(win *Window, nwin *nCursesWindow) {
 func (ui *NCursesUI) GetInput(force bool) (key Key, err error) {
 	key = UINoKey
 
-	if ui.suspended {
+	suspended := ui.isSuspended()
+	if suspended && force {
 		return
 	}
 
@@ -464,8 +570,13 @@ func (ui *NCursesUI) GetInput(force bool) (key Key, err error) {
 	OuterLoop:
 		for {
 			fdZero(rfds)
-			fdSet(stdinFd, rfds)
 			fdSet(pipeFd, rfds)
+
+			// Another process owns the terminal while suspended, so only
+			// wait to be woken up on resume
+			if !suspended {
+				fdSet(stdinFd, rfds)
+			}
 			nullPointer := uintptr(unsafe.Pointer(nil))
 
 			if _, _, errno := syscall.Syscall6(SelectSyscallID(), uintptr(pipeFd+1), uintptr(unsafe.Pointer(rfds)),
@@ -532,13 +643,53 @@ func (ui *NCursesUI) cancelGetInput() error {
 	return err
 }
 
+// GetMouseEvent returns the mouse event which caused the last KEY_MOUSE key code to be received
+// This should be called on the same thread as GetInput
+func (ui *NCursesUI) GetMouseEvent() (mouseEvent MouseEvent, exists bool) {
+	event := gc.GetMouse()
+	if event == nil {
+		log.Debug("No mouse event available")
+		return
+	}
+
+	switch {
+	case event.State&(gc.M_B1_PRESSED|gc.M_B1_CLICKED) != 0:
+		mouseEvent.mouseEventType = MetLeftClick
+	case event.State&gc.M_B4_PRESSED != 0:
+		mouseEvent.mouseEventType = MetScrollUp
+	case event.State&ncMouseButton5Pressed != 0:
+		mouseEvent.mouseEventType = MetScrollDown
+	default:
+		log.Debugf("Ignoring unsupported mouse event with state %v", event.State)
+		return
+	}
+
+	if event.Y < 0 || event.X < 0 {
+		return
+	}
+
+	mouseEvent.row = uint(event.Y)
+	mouseEvent.col = uint(event.X)
+	exists = true
+
+	return
+}
+
 func (ui *NCursesUI) onConfigVariableChange(configVariable ConfigVariable) {
-	theme := ui.config.GetTheme()
+	switch configVariable {
+	case CfTheme:
+		theme := ui.config.GetTheme()
 
-	ui.lock.Lock()
-	defer ui.lock.Unlock()
+		ui.lock.Lock()
+		defer ui.lock.Unlock()
+
+		ui.initialiseColorPairsFromTheme(theme)
+	case CfMouse:
+		ui.lock.Lock()
+		defer ui.lock.Unlock()
 
-	ui.initialiseColorPairsFromTheme(theme)
+		ui.setMouseEnabled(ui.config.GetBool(CfMouse))
+	}
 }
 
 func (ui *NCursesUI) initialiseColorPairsFromTheme(theme Theme) {
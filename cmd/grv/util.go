@@ -1,12 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	rw "github.com/mattn/go-runewidth"
 )
 
+const (
+	utBinaryCheckBytes = 8000
+)
+
 // MinUint returns the minimum value of the supplied arguments
 func MinUint(x, y uint) uint {
 	if x < y {
@@ -16,6 +23,24 @@ func MinUint(x, y uint) uint {
 	return y
 }
 
+// MaxUint returns the maximum value of the supplied arguments
+func MaxUint(x, y uint) uint {
+	if x > y {
+		return x
+	}
+
+	return y
+}
+
+// MinInt returns the minimum value of the supplied arguments
+func MinInt(x, y int) int {
+	if x < y {
+		return x
+	}
+
+	return y
+}
+
 // MaxInt returns the largest values of the supplied arguments
 func MaxInt(x, y int) int {
 	if x > y {
@@ -49,6 +74,46 @@ func RuneWidth(codePoint rune) int {
 	return rw.RuneWidth(codePoint)
 }
 
+// TruncateToWidth shortens the provided text so that its display width does not exceed width.
+// Truncated text ends with the provided suffix, which counts towards the width
+func TruncateToWidth(text string, width uint, suffix string) string {
+	textWidth := uint(0)
+	for _, codePoint := range text {
+		textWidth += uint(RuneWidth(codePoint))
+	}
+
+	if textWidth <= width {
+		return text
+	}
+
+	suffixWidth := uint(0)
+	for _, codePoint := range suffix {
+		suffixWidth += uint(RuneWidth(codePoint))
+	}
+
+	if suffixWidth > width {
+		suffix = ""
+		suffixWidth = 0
+	}
+
+	var buf bytes.Buffer
+	availableWidth := width - suffixWidth
+
+	for _, codePoint := range text {
+		codePointWidth := uint(RuneWidth(codePoint))
+		if codePointWidth > availableWidth {
+			break
+		}
+
+		buf.WriteRune(codePoint)
+		availableWidth -= codePointWidth
+	}
+
+	buf.WriteString(suffix)
+
+	return buf.String()
+}
+
 // NonPrintableCharString converts a control character into a string representation
 func NonPrintableCharString(codePoint rune) string {
 	if IsNonPrintableCharacter(codePoint) {
@@ -71,3 +136,75 @@ func CanonicalPath(path string) (canonicalPath string, err error) {
 
 	return filepath.Abs(canonicalPath)
 }
+
+// ValidateRefName checks the provided name can be used as a branch or tag name
+// following the rules described in git-check-ref-format
+func ValidateRefName(name string) (err error) {
+	switch {
+	case name == "" || name == "@":
+		err = fmt.Errorf("Invalid ref name \"%v\"", name)
+	case strings.HasPrefix(name, "-") || strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/"):
+		err = fmt.Errorf("Invalid ref name \"%v\": Cannot begin with \"-\" or begin or end with \"/\"", name)
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock"):
+		err = fmt.Errorf("Invalid ref name \"%v\": Cannot end with \".\" or \".lock\"", name)
+	case strings.Contains(name, "..") || strings.Contains(name, "@{") || strings.Contains(name, "//") || strings.Contains(name, "/."):
+		err = fmt.Errorf("Invalid ref name \"%v\": Cannot contain \"..\", \"@{\", \"//\" or \"/.\"", name)
+	case strings.HasPrefix(name, "."):
+		err = fmt.Errorf("Invalid ref name \"%v\": Cannot begin with \".\"", name)
+	default:
+		for _, char := range name {
+			if char <= ' ' || char == 0x7f || strings.ContainsRune(`~^:?*[\`, char) {
+				err = fmt.Errorf("Invalid ref name \"%v\": Contains invalid character %q", name, char)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// FormatRelativeTime returns a human readable description of how long before now the provided time is
+// Times more than a year before now are formatted as an absolute date
+func FormatRelativeTime(t, now time.Time) string {
+	duration := now.Sub(t)
+	if duration < 0 {
+		duration = 0
+	}
+
+	day := 24 * time.Hour
+
+	switch {
+	case duration < time.Minute:
+		return relativeTimeDescription(uint(duration/time.Second), "second")
+	case duration < time.Hour:
+		return relativeTimeDescription(uint(duration/time.Minute), "minute")
+	case duration < day:
+		return relativeTimeDescription(uint(duration/time.Hour), "hour")
+	case duration < 7*day:
+		return relativeTimeDescription(uint(duration/day), "day")
+	case duration < 30*day:
+		return relativeTimeDescription(uint(duration/(7*day)), "week")
+	case duration < 365*day:
+		return relativeTimeDescription(uint(duration/(30*day)), "month")
+	}
+
+	return t.Format("2006-01-02")
+}
+
+func relativeTimeDescription(value uint, unit string) string {
+	if value != 1 {
+		unit += "s"
+	}
+
+	return fmt.Sprintf("%v %v ago", value, unit)
+}
+
+// IsBinaryContent uses the same heuristic as git to determine if the provided
+// content is binary: binary content contains a NUL byte within its first 8000 bytes
+func IsBinaryContent(content []byte) bool {
+	if len(content) > utBinaryCheckBytes {
+		content = content[:utBinaryCheckBytes]
+	}
+
+	return bytes.IndexByte(content, 0) != -1
+}
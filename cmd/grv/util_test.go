@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bytes"
 	"testing"
+	"time"
 )
 
 func TestMinUint(t *testing.T) {
@@ -36,6 +38,38 @@ func TestMinUint(t *testing.T) {
 	}
 }
 
+func TestMinInt(t *testing.T) {
+	var minTests = []struct {
+		arg1           int
+		arg2           int
+		expectedResult int
+	}{
+		{
+			arg1:           1,
+			arg2:           2,
+			expectedResult: 1,
+		},
+		{
+			arg1:           5,
+			arg2:           4,
+			expectedResult: 4,
+		},
+		{
+			arg1:           -1,
+			arg2:           -2,
+			expectedResult: -2,
+		},
+	}
+
+	for _, minTest := range minTests {
+		actualResult := MinInt(minTest.arg1, minTest.arg2)
+
+		if actualResult != minTest.expectedResult {
+			t.Errorf("Min return arg does not match expected arg. Expected: %v, Actual: %v", minTest.expectedResult, actualResult)
+		}
+	}
+}
+
 func TestMaxInt(t *testing.T) {
 	var maxTests = []struct {
 		arg1           int
@@ -196,3 +230,89 @@ func TestNonPrintableCharString(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2017, time.October, 10, 12, 0, 0, 0, time.UTC)
+
+	var relativeTimeTests = []struct {
+		time           time.Time
+		expectedResult string
+	}{
+		{
+			time:           now.Add(-1 * time.Second),
+			expectedResult: "1 second ago",
+		},
+		{
+			time:           now.Add(-5 * time.Minute),
+			expectedResult: "5 minutes ago",
+		},
+		{
+			time:           now.Add(-3 * time.Hour),
+			expectedResult: "3 hours ago",
+		},
+		{
+			time:           now.Add(-50 * time.Hour),
+			expectedResult: "2 days ago",
+		},
+		{
+			time:           now.Add(-15 * 24 * time.Hour),
+			expectedResult: "2 weeks ago",
+		},
+		{
+			time:           now.Add(-65 * 24 * time.Hour),
+			expectedResult: "2 months ago",
+		},
+		{
+			time:           time.Date(2016, time.March, 14, 9, 0, 0, 0, time.UTC),
+			expectedResult: "2016-03-14",
+		},
+		{
+			time:           now.Add(time.Hour),
+			expectedResult: "0 seconds ago",
+		},
+	}
+
+	for _, relativeTimeTest := range relativeTimeTests {
+		actualResult := FormatRelativeTime(relativeTimeTest.time, now)
+
+		if actualResult != relativeTimeTest.expectedResult {
+			t.Errorf("Relative time does not match expected value. Expected: %v, Actual: %v", relativeTimeTest.expectedResult, actualResult)
+		}
+	}
+}
+
+func TestValidateRefName(t *testing.T) {
+	validNames := []string{"v1.0", "release/2017-10", "feature_x", "a-b"}
+	invalidNames := []string{"", "@", "has space", "-v1", "/v1", "v1/", "v1.", "v1.lock", "v1..2", "v@{1}", "a//b", "a/.b", ".v1", "v1~1", "v1^", "a:b", "a?b", "a*b", "a[b", "a\\b"}
+
+	for _, name := range validNames {
+		if err := ValidateRefName(name); err != nil {
+			t.Errorf("Expected ref name \"%v\" to be valid but received error: %v", name, err)
+		}
+	}
+
+	for _, name := range invalidNames {
+		if err := ValidateRefName(name); err == nil {
+			t.Errorf("Expected ref name \"%v\" to be invalid", name)
+		}
+	}
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	var binaryContentTests = []struct {
+		content        []byte
+		expectedBinary bool
+	}{
+		{content: nil, expectedBinary: false},
+		{content: []byte("package main\n"), expectedBinary: false},
+		{content: []byte{0x89, 'P', 'N', 'G', 0x00, 0x01}, expectedBinary: true},
+		{content: append(bytes.Repeat([]byte("a"), utBinaryCheckBytes), 0), expectedBinary: false},
+	}
+
+	for _, binaryContentTest := range binaryContentTests {
+		if binary := IsBinaryContent(binaryContentTest.content); binary != binaryContentTest.expectedBinary {
+			t.Errorf("IsBinaryContent returned unexpected value for content of length %v. Expected: %v, Actual: %v",
+				len(binaryContentTest.content), binaryContentTest.expectedBinary, binary)
+		}
+	}
+}
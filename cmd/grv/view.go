@@ -29,6 +29,12 @@ const (
 	ViewHelpBar
 	ViewError
 	ViewGitStatus
+	ViewBlame
+	ViewHelp
+	ViewReflog
+	ViewTree
+	ViewStash
+	ViewStats
 )
 
 // HelpRenderer renders help information
@@ -46,6 +52,19 @@ type AbstractView interface {
 	ViewID() ViewID
 }
 
+// Disposable is implemented by views which hold resources that must be
+// released once the view is no longer used
+type Disposable interface {
+	Dispose()
+}
+
+// DisposeView disposes of the provided view if it holds resources
+func DisposeView(abstractView AbstractView) {
+	if disposable, ok := abstractView.(Disposable); ok {
+		disposable.Dispose()
+	}
+}
+
 // WindowView is a single window view
 type WindowView interface {
 	AbstractView
@@ -71,6 +90,15 @@ func (viewDimension ViewDimension) String() string {
 	return fmt.Sprintf("rows:%v,cols:%v", viewDimension.rows, viewDimension.cols)
 }
 
+// PageRows returns the number of rows available for content inside the border of a view
+func (viewDimension ViewDimension) PageRows() uint {
+	if viewDimension.rows < 2 {
+		return 0
+	}
+
+	return viewDimension.rows - 2
+}
+
 // RegisterViewListener is a function which registers an observer on a view
 type RegisterViewListener func(observer interface{}) error
 
@@ -86,13 +114,16 @@ type View struct {
 	errorView         *ErrorView
 	errorViewWin      *Window
 	activeViewWin     *Window
+	helpView          *HelpView
+	helpViewWin       *Window
+	helpViewActive    bool
 	errors            []error
 	windowViewFactory *WindowViewFactory
 	lock              sync.Mutex
 }
 
 // NewView creates a new instance
-func NewView(repoData RepoData, channels *Channels, config ConfigSetter) (view *View) {
+func NewView(repoData RepoData, channels *Channels, config ConfigSetter, keyBindings KeyBindings) (view *View) {
 	view = &View{
 		views: []WindowViewCollection{
 			NewHistoryView(repoData, channels, config),
@@ -107,6 +138,8 @@ func NewView(repoData RepoData, channels *Channels, config ConfigSetter) (view *
 	view.errorView = NewErrorView()
 	view.errorViewWin = NewWindow("errorView", config)
 	view.activeViewWin = NewWindow("activeView", config)
+	view.helpView = NewHelpView(channels, keyBindings)
+	view.helpViewWin = NewWindow("helpView", config)
 
 	return
 }
@@ -148,6 +181,7 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 
 	view.lock.Lock()
 	childView := view.views[view.activeViewPos]
+	helpViewActive := view.helpViewActive
 	view.lock.Unlock()
 
 	startRow := uint(0)
@@ -168,6 +202,15 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 	}
 
 	wins = append(wins, activeViewWins...)
+
+	if helpViewActive {
+		if wins, err = view.renderHelpView(wins, activeViewDim); err != nil {
+			return
+		}
+
+		view.helpViewWin.OffsetPosition(int(startRow), 0)
+	}
+
 	startRow += activeViewDim.rows
 
 	if errorViewDim.rows > 0 {
@@ -227,6 +270,20 @@ func (view *View) renderErrorView(wins []*Window, errorViewDim, activeViewDim Vi
 	return
 }
 
+func (view *View) renderHelpView(wins []*Window, helpViewDim ViewDimension) (allWins []*Window, err error) {
+	view.helpViewWin.Resize(helpViewDim)
+	view.helpViewWin.Clear()
+	view.helpViewWin.SetPosition(0, 0)
+
+	if err = view.helpView.Render(view.helpViewWin); err != nil {
+		return
+	}
+
+	allWins = append(wins, view.helpViewWin)
+
+	return
+}
+
 func (view *View) renderActiveView(availableCols uint) (err error) {
 	viewTitles := make([]string, len(view.views))
 	cols := uint(0)
@@ -283,6 +340,7 @@ func (view *View) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 			{action: ActionPrompt, message: "Cmd Prompt"},
 			{action: ActionNextTab, message: "Next Tab"},
 			{action: ActionPrevTab, message: "Prev Tab"},
+			{action: ActionToggleHelp, message: "Help"},
 		})
 	}
 
@@ -310,11 +368,27 @@ func (view *View) HandleEvent(event Event) (err error) {
 func (view *View) HandleAction(action Action) (err error) {
 	log.Debugf("View handling action %v", action)
 
+	view.lock.Lock()
+	helpViewActive := view.helpViewActive
+	view.lock.Unlock()
+
+	switch {
+	case action.ActionType == ActionToggleHelp, helpViewActive && action.ActionType == ActionRemoveView:
+		view.lock.Lock()
+		defer view.lock.Unlock()
+
+		view.toggleHelpView()
+		return
+	}
+
 	switch action.ActionType {
-	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt:
+	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt, ActionDateFilterPrompt, ActionRefNameFilterPrompt, ActionPathFilterPrompt, ActionGotoCommitPrompt, ActionCreateTagPrompt, ActionCreateBranchPrompt, ActionOpenRepositoryPrompt:
 		err = view.prompt(action)
 		return
-	case ActionShowStatus:
+	case ActionRunShellCommand:
+		err = view.runShellCommand(action)
+		return
+	case ActionShowStatus, ActionConfirmPrompt, ActionOperationStarted, ActionOperationFinished:
 		view.lock.Lock()
 		defer view.lock.Unlock()
 
@@ -367,6 +441,39 @@ func (view *View) HandleAction(action Action) (err error) {
 	return view.ActiveView().HandleAction(action)
 }
 
+// runShellCommand runs the shell command from any view. Variables are expanded
+// with the values provided by the views in the active view hierarchy
+func (view *View) runShellCommand(action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected shell command argument")
+	}
+
+	shellCommand, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected shell command argument to have type string")
+	}
+
+	variables := make(map[rune]string)
+
+	for _, activeView := range view.ActiveViewHierarchy() {
+		if variableProvider, ok := activeView.(ShellCommandVariableProvider); ok {
+			var viewVariables map[rune]string
+			if viewVariables, err = variableProvider.ShellCommandVariables(); err != nil {
+				return
+			}
+
+			for variable, value := range viewVariables {
+				variables[variable] = value
+			}
+		}
+	}
+
+	log.Debugf("Running shell command %v", shellCommand)
+	view.channels.DoAction(NewShellCommandAction(shellCommand, variables))
+
+	return
+}
+
 // OnActiveChange updates the active state of the currently active child view
 func (view *View) OnActiveChange(active bool) {
 	view.lock.Lock()
@@ -429,11 +536,25 @@ func (view *View) ActiveView() AbstractView {
 func (view *View) activeView() AbstractView {
 	if view.promptActive {
 		return view.grvStatusView
+	} else if view.helpViewActive {
+		return view.helpView
 	}
 
 	return view.views[view.activeViewPos]
 }
 
+// Dispose releases the resources held by all tabs
+func (view *View) Dispose() {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	log.Info("Disposing of views")
+
+	for _, tabView := range view.views {
+		DisposeView(tabView)
+	}
+}
+
 // SetErrors sets errors to be displayed in the error view
 func (view *View) SetErrors(errors []error) {
 	view.lock.Lock()
@@ -449,7 +570,15 @@ func (view *View) Title() string {
 
 func (view *View) prompt(action Action) (err error) {
 	view.lock.Lock()
-	view.views[view.activeViewPos].OnActiveChange(false)
+	activeView := view.views[view.activeViewPos]
+
+	if action.ActionType == ActionRefNameFilterPrompt {
+		// The ref name filter is applied as it is typed, so the view the prompt
+		// was opened from handles each change to the input directly
+		action.Args = []interface{}{activeView.HandleAction}
+	}
+
+	activeView.OnActiveChange(false)
 	view.grvStatusView.OnActiveChange(true)
 	view.promptActive = true
 	view.lock.Unlock()
@@ -467,6 +596,20 @@ func (view *View) prompt(action Action) (err error) {
 	return
 }
 
+func (view *View) toggleHelpView() {
+	view.activeView().OnActiveChange(false)
+
+	if !view.helpViewActive {
+		view.helpView.Refresh()
+	}
+
+	view.helpViewActive = !view.helpViewActive
+	view.activeView().OnActiveChange(true)
+
+	log.Debugf("Help view active: %v", view.helpViewActive)
+	view.channels.UpdateDisplay()
+}
+
 func (view *View) nextTab() {
 	view.activeViewPos++
 	view.activeViewPos %= uint(len(view.views))
@@ -509,6 +652,7 @@ func (view *View) removeTab() {
 	}
 
 	index := view.activeViewPos
+	DisposeView(view.views[index])
 	view.views = append(view.views[:index], view.views[index+1:]...)
 
 	if index >= uint(len(view.views)) {
@@ -10,10 +10,14 @@ type ViewPos interface {
 	DetermineViewStartRow(viewRows, rows uint)
 	MoveLineDown(rows uint) (changed bool)
 	MoveLineUp() (changed bool)
+	MoveLinesDown(lines, rows uint) (changed bool)
+	MoveLinesUp(lines uint) (changed bool)
 	MovePageDown(pageRows, rows uint) (changed bool)
 	MovePageUp(pageRows uint) (changed bool)
 	MovePageRight(cols uint)
 	MovePageLeft(cols uint) (changed bool)
+	MoveToFirstColumn() (changed bool)
+	ClampViewStartColumn(maxColumn uint) (changed bool)
 	MoveToFirstLine() (changed bool)
 	MoveToLastLine(rows uint) (changed bool)
 	CenterActiveRow(pageRows uint) (changed bool)
@@ -56,40 +60,59 @@ func (viewPos *ViewPosition) ViewStartColumn() uint {
 }
 
 // SelectedRowIndex calculates the offset of the active row
+// Zero is returned if the active row is before the start of the view
 func (viewPos *ViewPosition) SelectedRowIndex() uint {
+	if viewPos.activeRowIndex < viewPos.viewStartRowIndex {
+		return 0
+	}
+
 	return viewPos.activeRowIndex - viewPos.viewStartRowIndex
 }
 
 // DetermineViewStartRow determines the row the view should start displaying from based on the current cursor position
+// The view start row index is never greater than the active row index
 func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows uint) {
 	if rows > 0 && viewPos.activeRowIndex >= rows {
 		viewPos.activeRowIndex = rows - 1
 	}
 
-	if viewPos.viewStartRowIndex > viewPos.activeRowIndex {
+	switch {
+	case viewPos.viewStartRowIndex > viewPos.activeRowIndex, viewRows == 0:
 		viewPos.viewStartRowIndex = viewPos.activeRowIndex
-	} else if rowDiff := viewPos.activeRowIndex - viewPos.viewStartRowIndex; rowDiff >= viewRows {
-		viewPos.viewStartRowIndex += (rowDiff - viewRows) + 1
-	} else if visibleRows := rows - (viewPos.viewStartRowIndex + 1); visibleRows < viewRows && viewPos.viewStartRowIndex > 0 {
-		viewPos.viewStartRowIndex -= MinUint(viewPos.viewStartRowIndex, (viewRows-visibleRows)-1)
+	case viewPos.activeRowIndex-viewPos.viewStartRowIndex >= viewRows:
+		viewPos.viewStartRowIndex = viewPos.activeRowIndex - (viewRows - 1)
+	case viewPos.viewStartRowIndex > 0 && viewPos.viewStartRowIndex < rows:
+		if visibleRows := rows - viewPos.viewStartRowIndex; visibleRows < viewRows {
+			viewPos.viewStartRowIndex -= MinUint(viewPos.viewStartRowIndex, viewRows-visibleRows)
+		}
 	}
 }
 
 // MoveLineDown moves the cursor down one line
 func (viewPos *ViewPosition) MoveLineDown(rows uint) (changed bool) {
+	return viewPos.MoveLinesDown(1, rows)
+}
+
+// MoveLineUp moves the cursor up one line
+func (viewPos *ViewPosition) MoveLineUp() (changed bool) {
+	return viewPos.MoveLinesUp(1)
+}
+
+// MoveLinesDown moves the cursor down the provided number of lines without moving past the last line
+func (viewPos *ViewPosition) MoveLinesDown(lines, rows uint) (changed bool) {
 	if viewPos.activeRowIndex+1 < rows {
-		viewPos.activeRowIndex++
-		changed = true
+		viewPos.activeRowIndex += MinUint(lines, rows-(viewPos.activeRowIndex+1))
+		changed = lines > 0
 	}
 
 	return
 }
 
-// MoveLineUp moves the cursor up one line
-func (viewPos *ViewPosition) MoveLineUp() (changed bool) {
+// MoveLinesUp moves the cursor up the provided number of lines without moving past the first line
+func (viewPos *ViewPosition) MoveLinesUp(lines uint) (changed bool) {
 	if viewPos.activeRowIndex > 0 {
-		viewPos.activeRowIndex--
-		changed = true
+		viewPos.activeRowIndex -= MinUint(lines, viewPos.activeRowIndex)
+		changed = lines > 0
 	}
 
 	return
@@ -140,6 +163,28 @@ func (viewPos *ViewPosition) MovePageLeft(cols uint) (changed bool) {
 	return
 }
 
+// MoveToFirstColumn scrolls the view back to the first column
+func (viewPos *ViewPosition) MoveToFirstColumn() (changed bool) {
+	if viewPos.viewStartColumn > 1 {
+		viewPos.viewStartColumn = 1
+		changed = true
+	}
+
+	return
+}
+
+// ClampViewStartColumn ensures the view does not start beyond the provided column
+func (viewPos *ViewPosition) ClampViewStartColumn(maxColumn uint) (changed bool) {
+	maxColumn = MaxUint(1, maxColumn)
+
+	if viewPos.viewStartColumn > maxColumn {
+		viewPos.viewStartColumn = maxColumn
+		changed = true
+	}
+
+	return
+}
+
 // MoveToFirstLine moves the cursor to the first line of the view
 func (viewPos *ViewPosition) MoveToFirstLine() (changed bool) {
 	if viewPos.activeRowIndex > 0 {
@@ -175,3 +220,41 @@ func (viewPos *ViewPosition) CenterActiveRow(pageRows uint) (changed bool) {
 
 	return
 }
+
+// MoveViewPos applies a navigation action to the view position of a list of lines.
+// handled is false if the action is not a navigation action. changed is true if the
+// view position was updated and the display should be refreshed
+func MoveViewPos(viewPos ViewPos, action Action, lineNumber uint, viewDimension ViewDimension) (handled, changed bool) {
+	pageRows := viewDimension.PageRows()
+	handled = true
+
+	switch action.ActionType {
+	case ActionNextLine:
+		changed = viewPos.MoveLinesDown(ActionCount(action), lineNumber)
+	case ActionPrevLine:
+		changed = viewPos.MoveLinesUp(ActionCount(action))
+	case ActionNextPage:
+		changed = viewPos.MovePageDown(pageRows, lineNumber)
+	case ActionPrevPage:
+		changed = viewPos.MovePageUp(pageRows)
+	case ActionNextHalfPage:
+		changed = viewPos.MovePageDown(pageRows/2, lineNumber)
+	case ActionPrevHalfPage:
+		changed = viewPos.MovePageUp(pageRows / 2)
+	case ActionScrollRight:
+		viewPos.MovePageRight(viewDimension.cols)
+		changed = true
+	case ActionScrollLeft:
+		changed = viewPos.MovePageLeft(viewDimension.cols)
+	case ActionFirstLine:
+		changed = viewPos.MoveToFirstLine()
+	case ActionLastLine:
+		changed = viewPos.MoveToLastLine(lineNumber)
+	case ActionCenterView:
+		changed = viewPos.CenterActiveRow(pageRows)
+	default:
+		handled = false
+	}
+
+	return
+}
@@ -47,6 +47,23 @@ func TestMoveLineDownIncrementsActiveRowIndex(t *testing.T) {
 	checkViewPosResult(true, result, t)
 }
 
+func TestMoveLinesDownAndUpClampToValidRows(t *testing.T) {
+	viewPos := NewViewPosition()
+
+	checkViewPosResult(true, viewPos.MoveLinesDown(3, 5), t)
+	checkViewPos(newViewPos(3, 0, 1), viewPos, t)
+
+	checkViewPosResult(true, viewPos.MoveLinesDown(10, 5), t)
+	checkViewPos(newViewPos(4, 0, 1), viewPos, t)
+
+	checkViewPosResult(false, viewPos.MoveLinesDown(10, 5), t)
+
+	checkViewPosResult(true, viewPos.MoveLinesUp(10), t)
+	checkViewPos(newViewPos(0, 0, 1), viewPos, t)
+
+	checkViewPosResult(false, viewPos.MoveLinesUp(1), t)
+}
+
 func TestMoveLineDownDoesNotIncrementsActiveRowIndexIfNoRowsLeft(t *testing.T) {
 	expected := newViewPos(4, 0, 1)
 
@@ -176,6 +193,46 @@ func TestMovePageLeftDoesNotDecreaseViewStartColumnIfNoColumnsRemain(t *testing.
 	checkViewPosResult(false, result, t)
 }
 
+func TestMoveToFirstColumnResetsViewStartColumn(t *testing.T) {
+	expected := newViewPos(0, 0, 1)
+
+	actual := newViewPos(0, 0, 11)
+	result := actual.MoveToFirstColumn()
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestClampViewStartColumnLimitsViewStartColumn(t *testing.T) {
+	expected := newViewPos(0, 0, 8)
+
+	actual := newViewPos(0, 0, 16)
+	result := actual.ClampViewStartColumn(8)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestClampViewStartColumnDoesNotChangeViewStartColumnWithinLimit(t *testing.T) {
+	expected := newViewPos(0, 0, 6)
+
+	actual := newViewPos(0, 0, 6)
+	result := actual.ClampViewStartColumn(20)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(false, result, t)
+}
+
+func TestClampViewStartColumnDoesNotMoveBeforeFirstColumn(t *testing.T) {
+	expected := newViewPos(0, 0, 1)
+
+	actual := newViewPos(0, 0, 6)
+	result := actual.ClampViewStartColumn(0)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
 func TestMoveToFirstLineUpdatesActiveRowIndex(t *testing.T) {
 	expected := newViewPos(0, 5, 1)
 
@@ -281,3 +338,72 @@ func TestDetermineViewStartRowDecreasesActiveRowIndexIfItIsGreaterThanTheTotalNu
 
 	checkViewPos(expected, actual, t)
 }
+
+func TestMovePageDownNearLastRowLeavesActiveRowVisibleAfterDetermineViewStartRow(t *testing.T) {
+	expected := newViewPos(9, 5, 1)
+
+	actual := newViewPos(6, 3, 1)
+	result := actual.MovePageDown(5, 10)
+	actual.DetermineViewStartRow(5, 10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestDetermineViewStartRowWithNoViewRowsDoesNotMoveViewStartPastActiveRow(t *testing.T) {
+	expected := newViewPos(5, 5, 1)
+
+	actual := newViewPos(5, 2, 1)
+	actual.DetermineViewStartRow(0, 20)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowNeverWrapsWhenScrollingAcrossBoundaries(t *testing.T) {
+	const rows = 50
+
+	for viewRows := uint(0); viewRows <= rows+5; viewRows++ {
+		viewPos := NewViewPosition()
+		pageRows := MaxUint(1, viewRows)
+
+		checkBounds := func(action string) {
+			viewPos.DetermineViewStartRow(viewRows, rows)
+
+			if viewPos.viewStartRowIndex > viewPos.activeRowIndex || viewPos.activeRowIndex >= rows {
+				t.Fatalf("Invalid ViewPos after %v with %v view rows: %v", action, viewRows, *viewPos)
+			}
+
+			if viewRows > 0 && viewPos.SelectedRowIndex() >= viewRows {
+				t.Fatalf("Active row not visible after %v with %v view rows: %v", action, viewRows, *viewPos)
+			}
+		}
+
+		for viewPos.MoveLineDown(rows) {
+			checkBounds("MoveLineDown")
+		}
+
+		viewPos.MoveLineDown(rows)
+		checkBounds("MoveLineDown on last row")
+
+		for viewPos.MoveLineUp() {
+			checkBounds("MoveLineUp")
+		}
+
+		viewPos.MoveLineUp()
+		checkBounds("MoveLineUp on first row")
+
+		for viewPos.MovePageDown(pageRows, rows) {
+			checkBounds("MovePageDown")
+		}
+
+		for viewPos.MovePageUp(pageRows) {
+			checkBounds("MovePageUp")
+		}
+
+		viewPos.MoveToLastLine(rows)
+		checkBounds("MoveToLastLine")
+
+		viewPos.DetermineViewStartRow(viewRows, rows/2)
+		checkBounds("shrinking rows")
+	}
+}
@@ -19,6 +19,7 @@ type ViewSearch struct {
 	searchableView       SearchableView
 	channels             *Channels
 	lastSearchFoundMatch bool
+	fuzzy                bool
 	lock                 sync.Mutex
 }
 
@@ -31,6 +32,7 @@ func NewViewSearch(searchableView SearchableView, channels *Channels) *ViewSearc
 }
 
 // SearchActive returns the state of the most recent search (if one has been performed)
+// The pattern returned is a regular expression matching the text matched by the search
 func (viewSearch *ViewSearch) SearchActive() (active bool, pattern string, lastSearchFoundMatch bool) {
 	viewSearch.lock.Lock()
 	defer viewSearch.lock.Unlock()
@@ -41,7 +43,7 @@ func (viewSearch *ViewSearch) SearchActive() (active bool, pattern string, lastS
 func (viewSearch *ViewSearch) searchActive() (active bool, pattern string, lastSearchFoundMatch bool) {
 	if viewSearch.search != nil {
 		active = true
-		pattern = viewSearch.search.pattern
+		pattern = viewSearch.search.HighlightPattern()
 		lastSearchFoundMatch = viewSearch.lastSearchFoundMatch
 	}
 
@@ -64,6 +66,8 @@ func (viewSearch *ViewSearch) HandleAction(action Action) (handled bool, err err
 		err = viewSearch.findPrevMatch()
 	case ActionClearSearch:
 		err = viewSearch.clearSearch()
+	case ActionToggleFuzzySearch:
+		err = viewSearch.toggleFuzzySearch()
 	default:
 		handled = false
 	}
@@ -71,31 +75,52 @@ func (viewSearch *ViewSearch) HandleAction(action Action) (handled bool, err err
 	return
 }
 
+// Fuzzy returns true if searches are performed using fuzzy matching
+func (viewSearch *ViewSearch) Fuzzy() bool {
+	viewSearch.lock.Lock()
+	defer viewSearch.lock.Unlock()
+
+	return viewSearch.fuzzy
+}
+
 func (viewSearch *ViewSearch) doSearch(action Action) (err error) {
-	search, err := CreateSearchFromAction(action, viewSearch.searchableView)
+	search, err := CreateSearchFromAction(action, viewSearch.searchableView, viewSearch.fuzzy)
 	if err != nil {
 		return
 	}
 
 	viewSearch.search = search
 
-	return viewSearch.findNextMatch()
+	return viewSearch.findMatch("first", search.FindFirst)
 }
 
 func (viewSearch *ViewSearch) findNextMatch() (err error) {
-	active, pattern, _ := viewSearch.searchActive()
-	if !active {
-		return
+	if viewSearch.search != nil {
+		err = viewSearch.findMatch("next", viewSearch.search.FindNext)
+	}
+
+	return
+}
+
+func (viewSearch *ViewSearch) findPrevMatch() (err error) {
+	if viewSearch.search != nil {
+		err = viewSearch.findMatch("previous", viewSearch.search.FindPrev)
 	}
 
+	return
+}
+
+// findMatch runs the provided search function from the active row of the view
+// in the background and selects the matching line if one is found
+func (viewSearch *ViewSearch) findMatch(description string, find func(startLineIndex uint) (uint, bool)) (err error) {
 	viewPos := viewSearch.searchableView.ViewPos()
 
 	viewSearch.channels.ReportStatus("Searching...")
-	log.Debugf("Searching for next occurrence of pattern %v starting from row index :%v",
-		pattern, viewPos.ActiveRowIndex())
+	log.Debugf("Searching for %v occurrence of pattern %v starting from row index :%v",
+		description, viewSearch.search.pattern, viewPos.ActiveRowIndex())
 
 	go func() {
-		matchLineIndex, found := viewSearch.search.FindNext(viewPos.ActiveRowIndex())
+		matchLineIndex, found := find(viewPos.ActiveRowIndex())
 
 		viewSearch.lock.Lock()
 		viewSearch.lastSearchFoundMatch = found
@@ -112,33 +137,31 @@ func (viewSearch *ViewSearch) findNextMatch() (err error) {
 	return
 }
 
-func (viewSearch *ViewSearch) findPrevMatch() (err error) {
-	active, pattern, _ := viewSearch.searchActive()
-	if !active {
-		return
-	}
+// toggleFuzzySearch switches between fuzzy and regular expression matching.
+// Any active search is recreated using the new matching mode
+func (viewSearch *ViewSearch) toggleFuzzySearch() (err error) {
+	viewSearch.fuzzy = !viewSearch.fuzzy
+	log.Debugf("Fuzzy search enabled: %v", viewSearch.fuzzy)
 
-	viewPos := viewSearch.searchableView.ViewPos()
-
-	viewSearch.channels.ReportStatus("Searching...")
-	log.Debugf("Searching for previous occurrence of pattern %v starting from row index :%v",
-		pattern, viewPos.ActiveRowIndex())
-
-	go func() {
-		matchLineIndex, found := viewSearch.search.FindPrev(viewPos.ActiveRowIndex())
-
-		viewSearch.lock.Lock()
-		viewSearch.lastSearchFoundMatch = found
-		viewSearch.lock.Unlock()
-
-		if found {
-			viewSearch.searchableView.OnSearchMatch(viewPos, matchLineIndex)
-			viewSearch.channels.ReportStatus("Match found")
+	if search := viewSearch.search; search != nil {
+		if viewSearch.fuzzy {
+			viewSearch.search, err = NewFuzzySearch(search.direction, search.pattern, viewSearch.searchableView)
 		} else {
-			viewSearch.channels.ReportStatus("No matches found")
+			viewSearch.search, err = NewSearch(search.direction, search.pattern, viewSearch.searchableView)
 		}
 
-	}()
+		if err != nil {
+			viewSearch.search = nil
+			viewSearch.lastSearchFoundMatch = false
+			return
+		}
+	}
+
+	if viewSearch.fuzzy {
+		viewSearch.channels.ReportStatus("Fuzzy search enabled")
+	} else {
+		viewSearch.channels.ReportStatus("Fuzzy search disabled")
+	}
 
 	return
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"unicode"
 
 	log "github.com/Sirupsen/logrus"
@@ -64,6 +65,8 @@ type RenderWindow interface {
 	ApplyStyle(themeComponentID ThemeComponentID)
 	Highlight(pattern string, themeComponentID ThemeComponentID) error
 	DrawBorder()
+	DrawScrollBar(startRow, visibleRows, totalRows uint, themeComponentID ThemeComponentID)
+	SetCell(rowIndex, colIndex uint, codePoint rune, themeComponentID ThemeComponentID) error
 	LineBuilder(rowIndex, startColumn uint) (*LineBuilder, error)
 }
 
@@ -108,15 +111,16 @@ type cursor struct {
 
 // Window implements the RenderWindow interface and contains all rendered data
 type Window struct {
-	id       string
-	rows     uint
-	cols     uint
-	lines    []*line
-	startRow uint
-	startCol uint
-	border   bool
-	config   Config
-	cursor   *cursor
+	id        string
+	rows      uint
+	cols      uint
+	lines     []*line
+	startRow  uint
+	startCol  uint
+	border    bool
+	config    Config
+	cursor    *cursor
+	drawnRows []string
 }
 
 func newLine(cols uint) *line {
@@ -131,6 +135,24 @@ func newLine(cols uint) *line {
 	return line
 }
 
+// signature returns a value which uniquely identifies the text and style of the line
+func (line *line) signature() string {
+	buf := make([]byte, 0, len(line.cells)*4)
+
+	for _, cell := range line.cells {
+		buf = append(buf, cell.codePoints.Bytes()...)
+		buf = append(buf, 0)
+		buf = strconv.AppendInt(buf, int64(cell.style.themeComponentID), 16)
+		buf = append(buf, ':')
+		buf = strconv.AppendUint(buf, uint64(cell.style.attr), 16)
+		buf = append(buf, ':')
+		buf = strconv.AppendUint(buf, uint64(cell.style.acsChar), 16)
+		buf = append(buf, 0)
+	}
+
+	return string(buf)
+}
+
 // String returns the text contained in the line
 func (line *line) String() string {
 	var buf bytes.Buffer
@@ -262,12 +284,48 @@ func (win *Window) Resize(viewDimension ViewDimension) {
 	win.cols = viewDimension.cols
 
 	win.lines = make([]*line, win.rows)
+	win.drawnRows = nil
 
 	for i := uint(0); i < win.rows; i++ {
 		win.lines[i] = newLine(win.cols)
 	}
 }
 
+// ChangedRows returns the indexes of rows whose content has changed since
+// the last call and records the current content as drawn
+func (win *Window) ChangedRows() (rowIndexes []uint) {
+	if uint(len(win.drawnRows)) != win.rows {
+		win.drawnRows = make([]string, win.rows)
+
+		for rowIndex := uint(0); rowIndex < win.rows; rowIndex++ {
+			win.drawnRows[rowIndex] = win.lines[rowIndex].signature()
+			rowIndexes = append(rowIndexes, rowIndex)
+		}
+
+		return
+	}
+
+	for rowIndex := uint(0); rowIndex < win.rows; rowIndex++ {
+		if signature := win.lines[rowIndex].signature(); signature != win.drawnRows[rowIndex] {
+			win.drawnRows[rowIndex] = signature
+			rowIndexes = append(rowIndexes, rowIndex)
+		}
+	}
+
+	return
+}
+
+// Invalidate forces all rows to be reported as changed on the next call to ChangedRows
+func (win *Window) Invalidate() {
+	win.drawnRows = nil
+}
+
+// Overlaps returns true if the display area of this window intersects the display area of the provided window
+func (win *Window) Overlaps(other *Window) bool {
+	return win.startRow < other.startRow+other.rows && other.startRow < win.startRow+win.rows &&
+		win.startCol < other.startCol+other.cols && other.startCol < win.startCol+win.cols
+}
+
 // SetPosition sets the coordintates the window should appear on the display
 func (win *Window) SetPosition(startRow, startCol uint) {
 	win.startRow = startRow
@@ -460,21 +518,21 @@ func (win *Window) DrawBorder() {
 
 	firstLine := win.lines[0]
 	firstLine.cells[0].setStyle(cellStyle{
-		themeComponentID: CmpNone,
+		themeComponentID: CmpAllviewBorder,
 		acsChar:          gc.ACS_ULCORNER,
 		attr:             gc.A_NORMAL,
 	})
 
 	for i := uint(1); i < win.cols-1; i++ {
 		firstLine.cells[i].setStyle(cellStyle{
-			themeComponentID: CmpNone,
+			themeComponentID: CmpAllviewBorder,
 			acsChar:          gc.ACS_HLINE,
 			attr:             gc.A_NORMAL,
 		})
 	}
 
 	firstLine.cells[win.cols-1].setStyle(cellStyle{
-		themeComponentID: CmpNone,
+		themeComponentID: CmpAllviewBorder,
 		acsChar:          gc.ACS_URCORNER,
 		attr:             gc.A_NORMAL,
 	})
@@ -482,12 +540,12 @@ func (win *Window) DrawBorder() {
 	for i := uint(1); i < win.rows-1; i++ {
 		line := win.lines[i]
 		line.cells[0].setStyle(cellStyle{
-			themeComponentID: CmpNone,
+			themeComponentID: CmpAllviewBorder,
 			acsChar:          gc.ACS_VLINE,
 			attr:             gc.A_NORMAL,
 		})
 		line.cells[win.cols-1].setStyle(cellStyle{
-			themeComponentID: CmpNone,
+			themeComponentID: CmpAllviewBorder,
 			acsChar:          gc.ACS_VLINE,
 			attr:             gc.A_NORMAL,
 		})
@@ -495,21 +553,21 @@ func (win *Window) DrawBorder() {
 
 	lastLine := win.lines[win.rows-1]
 	lastLine.cells[0].setStyle(cellStyle{
-		themeComponentID: CmpNone,
+		themeComponentID: CmpAllviewBorder,
 		acsChar:          gc.ACS_LLCORNER,
 		attr:             gc.A_NORMAL,
 	})
 
 	for i := uint(1); i < win.cols-1; i++ {
 		lastLine.cells[i].setStyle(cellStyle{
-			themeComponentID: CmpNone,
+			themeComponentID: CmpAllviewBorder,
 			acsChar:          gc.ACS_HLINE,
 			attr:             gc.A_NORMAL,
 		})
 	}
 
 	lastLine.cells[win.cols-1].setStyle(cellStyle{
-		themeComponentID: CmpNone,
+		themeComponentID: CmpAllviewBorder,
 		acsChar:          gc.ACS_LRCORNER,
 		attr:             gc.A_NORMAL,
 	})
@@ -517,6 +575,62 @@ func (win *Window) DrawBorder() {
 	win.border = true
 }
 
+// DrawScrollBar draws a scroll bar on the right border of the window
+// The position and size of the thumb reflect the visible rows relative to the total rows
+// Nothing is drawn if there is no border or all rows are visible
+func (win *Window) DrawScrollBar(startRow, visibleRows, totalRows uint, themeComponentID ThemeComponentID) {
+	if !win.border || totalRows <= visibleRows {
+		return
+	}
+
+	thumbStart, thumbRows := ScrollBarThumb(win.rows-2, startRow, visibleRows, totalRows)
+
+	for i := thumbStart; i < thumbStart+thumbRows; i++ {
+		win.lines[i+1].cells[win.cols-1].setStyle(cellStyle{
+			themeComponentID: themeComponentID,
+			acsChar:          gc.ACS_CKBOARD,
+			attr:             gc.A_NORMAL,
+		})
+	}
+}
+
+// SetCell sets the character and style of a single cell
+func (win *Window) SetCell(rowIndex, colIndex uint, codePoint rune, themeComponentID ThemeComponentID) error {
+	if rowIndex >= win.rows || colIndex >= win.cols {
+		return fmt.Errorf("SetCell: Invalid cell %v:%v for window with %v rows and %v cols", rowIndex, colIndex, win.rows, win.cols)
+	}
+
+	cell := win.lines[rowIndex].cells[colIndex]
+	cell.codePoints.Reset()
+	cell.codePoints.WriteRune(codePoint)
+	cell.style.themeComponentID = themeComponentID
+	cell.style.acsChar = 0
+
+	return nil
+}
+
+// ScrollBarThumb calculates the start row and number of rows of a scroll bar thumb
+// for a track of trackRows rows. The thumb is at least one row in size
+func ScrollBarThumb(trackRows, startRow, visibleRows, totalRows uint) (thumbStart, thumbRows uint) {
+	if trackRows == 0 || totalRows == 0 {
+		return
+	}
+
+	if visibleRows >= totalRows {
+		return 0, trackRows
+	}
+
+	thumbRows = MaxUint(1, (trackRows*visibleRows)/totalRows)
+
+	if startRow+visibleRows >= totalRows {
+		thumbStart = trackRows - thumbRows
+	} else {
+		thumbStart = MinUint(trackRows-thumbRows, (trackRows*startRow)/totalRows)
+	}
+
+	return
+}
+
 // ApplyStyle sets a single style for all cells in the window
 func (win *Window) ApplyStyle(themeComponentID ThemeComponentID) {
 	for _, line := range win.lines {
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestScrollBarThumb(t *testing.T) {
+	var scrollBarThumbTests = []struct {
+		trackRows          uint
+		startRow           uint
+		visibleRows        uint
+		totalRows          uint
+		expectedThumbStart uint
+		expectedThumbRows  uint
+	}{
+		{trackRows: 10, startRow: 0, visibleRows: 10, totalRows: 5, expectedThumbStart: 0, expectedThumbRows: 10},
+		{trackRows: 10, startRow: 0, visibleRows: 10, totalRows: 100, expectedThumbStart: 0, expectedThumbRows: 1},
+		{trackRows: 10, startRow: 50, visibleRows: 20, totalRows: 100, expectedThumbStart: 5, expectedThumbRows: 2},
+		{trackRows: 10, startRow: 90, visibleRows: 10, totalRows: 100, expectedThumbStart: 9, expectedThumbRows: 1},
+		{trackRows: 10, startRow: 99000, visibleRows: 10, totalRows: 100000, expectedThumbStart: 9, expectedThumbRows: 1},
+	}
+
+	for _, scrollBarThumbTest := range scrollBarThumbTests {
+		thumbStart, thumbRows := ScrollBarThumb(scrollBarThumbTest.trackRows, scrollBarThumbTest.startRow,
+			scrollBarThumbTest.visibleRows, scrollBarThumbTest.totalRows)
+
+		if thumbStart != scrollBarThumbTest.expectedThumbStart || thumbRows != scrollBarThumbTest.expectedThumbRows {
+			t.Errorf("ScrollBarThumb returned unexpected value for %+v. Expected: (%v, %v), Actual: (%v, %v)", scrollBarThumbTest,
+				scrollBarThumbTest.expectedThumbStart, scrollBarThumbTest.expectedThumbRows, thumbStart, thumbRows)
+		}
+	}
+}
+
+func TestChangedRowsOnlyReportsRowsThatDiffer(t *testing.T) {
+	win := NewWindow("test", NewConfiguration(NewKeyBindingManager(), newTestChannels()))
+	win.Resize(ViewDimension{rows: 10, cols: 20})
+
+	render := func(selectedRowIndex uint) {
+		win.Clear()
+
+		for rowIndex := uint(0); rowIndex < win.Rows(); rowIndex++ {
+			if err := win.SetRow(rowIndex, 1, CmpNone, "Row %v", rowIndex); err != nil {
+				t.Fatalf("SetRow failed: %v", err)
+			}
+		}
+
+		if err := win.SetSelectedRow(selectedRowIndex, true); err != nil {
+			t.Fatalf("SetSelectedRow failed: %v", err)
+		}
+	}
+
+	render(2)
+
+	if changedRows := win.ChangedRows(); len(changedRows) != 10 {
+		t.Errorf("Expected all rows to be redrawn on first render but found %v", changedRows)
+	}
+
+	render(2)
+
+	if changedRows := win.ChangedRows(); len(changedRows) != 0 {
+		t.Errorf("Expected no rows to be redrawn for unchanged content but found %v", changedRows)
+	}
+
+	render(3)
+
+	if changedRows := win.ChangedRows(); len(changedRows) != 2 || changedRows[0] != 2 || changedRows[1] != 3 {
+		t.Errorf("Expected only the old and new selected rows to be redrawn but found %v", changedRows)
+	}
+
+	win.Invalidate()
+	render(3)
+
+	if changedRows := win.ChangedRows(); len(changedRows) != 10 {
+		t.Errorf("Expected all rows to be redrawn after invalidation but found %v", changedRows)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), newTestChannels())
+
+	newPositionedWindow := func(startRow, startCol, rows, cols uint) *Window {
+		win := NewWindow("test", config)
+		win.Resize(ViewDimension{rows: rows, cols: cols})
+		win.SetPosition(startRow, startCol)
+		return win
+	}
+
+	view := newPositionedWindow(0, 0, 20, 80)
+
+	overlapsTests := []struct {
+		win      *Window
+		overlaps bool
+	}{
+		{win: newPositionedWindow(5, 10, 5, 40), overlaps: true},
+		{win: newPositionedWindow(19, 79, 5, 5), overlaps: true},
+		{win: newPositionedWindow(20, 0, 1, 80), overlaps: false},
+		{win: newPositionedWindow(0, 80, 20, 10), overlaps: false},
+		{win: newPositionedWindow(5, 10, 0, 0), overlaps: false},
+	}
+
+	for _, overlapsTest := range overlapsTests {
+		if overlaps := overlapsTest.win.Overlaps(view); overlaps != overlapsTest.overlaps {
+			t.Errorf("Overlaps does not match expected value for window at row:%v,col:%v. Expected: %v, Actual: %v",
+				overlapsTest.win.startRow, overlapsTest.win.startCol, overlapsTest.overlaps, overlaps)
+		}
+
+		if overlaps := view.Overlaps(overlapsTest.win); overlaps != overlapsTest.overlaps {
+			t.Errorf("Overlaps is not symmetric for window at row:%v,col:%v", overlapsTest.win.startRow, overlapsTest.win.startCol)
+		}
+	}
+}
+
+func TestHighlightOnlyStylesMatchedText(t *testing.T) {
+	win := NewWindow("test", NewConfiguration(NewKeyBindingManager(), newTestChannels()))
+	win.Resize(ViewDimension{rows: 4, cols: 20})
+
+	var highlightTests = []struct {
+		row                string
+		highlightedIndexes map[int]bool
+	}{
+		{row: "abc foo def", highlightedIndexes: map[int]bool{4: true, 5: true, 6: true}},
+		{row: "no match"},
+		{row: "foo foo", highlightedIndexes: map[int]bool{0: true, 1: true, 2: true, 4: true, 5: true, 6: true}},
+	}
+
+	for rowIndex, highlightTest := range highlightTests {
+		if err := win.SetRow(uint(rowIndex), 1, CmpNone, "%v", highlightTest.row); err != nil {
+			t.Fatalf("SetRow failed: %v", err)
+		}
+	}
+
+	if err := win.Highlight("foo", CmpAllviewSearchMatch); err != nil {
+		t.Fatalf("Highlight failed: %v", err)
+	}
+
+	for rowIndex, highlightTest := range highlightTests {
+		for cellIndex, cell := range win.lines[rowIndex].cells {
+			expectedThemeComponentID := CmpNone
+			if highlightTest.highlightedIndexes[cellIndex] {
+				expectedThemeComponentID = CmpAllviewSearchMatch
+			}
+
+			if themeComponentID := cell.style.themeComponentID; themeComponentID != expectedThemeComponentID {
+				t.Errorf("Cell %v on row %q has theme component %v but expected %v", cellIndex, highlightTest.row, themeComponentID, expectedThemeComponentID)
+			}
+		}
+	}
+}
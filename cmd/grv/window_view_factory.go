@@ -42,6 +42,16 @@ func (windowViewFactory *WindowViewFactory) CreateWindowViewWithArgs(viewID View
 		windowView, err = windowViewFactory.createDiffView(args)
 	case ViewGitStatus:
 		windowView = windowViewFactory.createGitStatusView()
+	case ViewBlame:
+		windowView, err = windowViewFactory.createBlameView(args)
+	case ViewReflog:
+		windowView, err = windowViewFactory.createReflogView()
+	case ViewStash:
+		windowView, err = windowViewFactory.createStashView()
+	case ViewStats:
+		windowView, err = windowViewFactory.createStatsView(args)
+	case ViewTree:
+		windowView, err = windowViewFactory.createTreeView(args)
 	default:
 		err = fmt.Errorf("Unsupported view type: %v", viewID)
 	}
@@ -51,7 +61,7 @@ func (windowViewFactory *WindowViewFactory) CreateWindowViewWithArgs(viewID View
 
 func (windowViewFactory *WindowViewFactory) createRefView() *RefView {
 	log.Info("Created RefView instance")
-	return NewRefView(windowViewFactory.repoData, windowViewFactory.channels)
+	return NewRefView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 }
 
 func (windowViewFactory *WindowViewFactory) createCommitView(args []interface{}) (commitView *CommitView, err error) {
@@ -60,7 +70,7 @@ func (windowViewFactory *WindowViewFactory) createCommitView(args []interface{})
 		return
 	}
 
-	commitView = NewCommitView(windowViewFactory.repoData, windowViewFactory.channels)
+	commitView = NewCommitView(windowViewFactory.repoData, windowViewFactory.channels, windowViewFactory.config)
 
 	log.Info("Created CommitView instance")
 
@@ -82,16 +92,37 @@ func (windowViewFactory *WindowViewFactory) createDiffView(args []interface{}) (
 
 	log.Info("Created DiffView instance")
 
-	if ref != nil {
-		var commit *Commit
-		commit, err = windowViewFactory.repoData.Commit(ref.Oid())
+	if ref == nil {
+		return
+	}
 
-		if err == nil {
-			log.Debugf("Providing Commit to DiffView instance %v", commit.oid)
-			err = diffView.OnCommitSelected(commit)
-		}
+	commit, err := windowViewFactory.repoData.Commit(ref.Oid())
+	if err != nil {
+		return
+	}
+
+	if len(args) < 2 {
+		log.Debugf("Providing Commit to DiffView instance %v", commit.oid)
+		err = diffView.OnCommitSelected(commit)
+		return
+	}
+
+	toRef, err := windowViewFactory.getRef(args[1:])
+	if err != nil {
+		return
+	} else if toRef == nil {
+		err = fmt.Errorf("Invalid ref: %v", args[1])
+		return
+	}
+
+	toCommit, err := windowViewFactory.repoData.Commit(toRef.Oid())
+	if err != nil {
+		return
 	}
 
+	log.Debugf("Providing Commits to DiffView instance %v..%v", commit.oid, toCommit.oid)
+	err = diffView.OnCommitRangeSelected(commit, toCommit)
+
 	return
 }
 
@@ -106,6 +137,93 @@ func (windowViewFactory *WindowViewFactory) createGitStatusView() *GitStatusView
 	return gitStatusView
 }
 
+func (windowViewFactory *WindowViewFactory) createBlameView(args []interface{}) (blameView *BlameView, err error) {
+	if len(args) == 0 {
+		err = fmt.Errorf("BlameView requires a file path argument")
+		return
+	}
+
+	path, ok := args[0].(string)
+	if !ok {
+		err = fmt.Errorf("Expected path argument of type string but got type %T", args[0])
+		return
+	}
+
+	ref, err := windowViewFactory.getRef(args[1:])
+	if err != nil {
+		return
+	} else if ref == nil {
+		ref = windowViewFactory.repoData.Head()
+	}
+
+	blameView = NewBlameView(windowViewFactory.repoData, windowViewFactory.channels)
+
+	log.Info("Created BlameView instance")
+	log.Debugf("Providing file %v and Ref %v:%v to BlameView instance", path, ref.Name(), ref.Oid())
+
+	err = blameView.LoadBlame(path, ref.Oid())
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createReflogView() (reflogView *ReflogView, err error) {
+	reflogView = NewReflogView(windowViewFactory.repoData, windowViewFactory.channels)
+
+	log.Info("Created ReflogView instance")
+
+	err = reflogView.LoadReflog()
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createStashView() (stashView *StashView, err error) {
+	stashView = NewStashView(windowViewFactory.repoData, windowViewFactory.channels)
+
+	log.Info("Created StashView instance")
+
+	err = stashView.LoadStashes()
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createStatsView(args []interface{}) (statsView *StatsView, err error) {
+	ref, err := windowViewFactory.getRef(args)
+	if err != nil {
+		return
+	} else if ref == nil {
+		ref = windowViewFactory.repoData.Head()
+	}
+
+	statsView = NewStatsView(windowViewFactory.repoData, windowViewFactory.channels, ref)
+
+	log.Infof("Created StatsView instance for ref %v", ref.Name())
+
+	return
+}
+
+func (windowViewFactory *WindowViewFactory) createTreeView(args []interface{}) (treeView *TreeView, err error) {
+	ref, err := windowViewFactory.getRef(args)
+	if err != nil {
+		return
+	} else if ref == nil {
+		ref = windowViewFactory.repoData.Head()
+	}
+
+	treeView = NewTreeView(windowViewFactory.repoData, windowViewFactory.channels)
+
+	log.Info("Created TreeView instance")
+
+	commit, err := windowViewFactory.repoData.Commit(ref.Oid())
+	if err != nil {
+		return
+	}
+
+	log.Debugf("Providing Commit to TreeView instance %v", commit.oid)
+	err = treeView.OnCommitSelected(commit)
+
+	return
+}
+
 func (windowViewFactory *WindowViewFactory) getRef(args []interface{}) (ref Ref, err error) {
 	if len(args) == 0 {
 		return
@@ -131,7 +249,7 @@ func (windowViewFactory *WindowViewFactory) getRef(args []interface{}) (ref Ref,
 		}
 
 		log.Debugf("Found commit %v for input %v", commit.oid, refName)
-		ref = &HEAD{oid: commit.oid}
+		ref = NewOidRef(commit.oid)
 	} else {
 		log.Debug("Input is not oid")
 	}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	wdMaxTokenProduct = 40000
+	wdMinSimilarity   = 0.5
+)
+
+// wordDiffSpan is the byte range of changed text within a diff line
+type wordDiffSpan struct {
+	start int
+	end   int
+}
+
+type wordDiffToken struct {
+	text  string
+	start int
+}
+
+type runeClass int
+
+const (
+	rcWord runeClass = iota
+	rcSpace
+	rcOther
+)
+
+func classifyRune(char rune) runeClass {
+	switch {
+	case unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_':
+		return rcWord
+	case unicode.IsSpace(char):
+		return rcSpace
+	}
+
+	return rcOther
+}
+
+// tokenizeWords splits the line into tokens for diffing. Runs of word characters and
+// runs of whitespace form single tokens, all other characters are individual tokens
+func tokenizeWords(line string) (tokens []wordDiffToken) {
+	start := 0
+
+	for start < len(line) {
+		char, width := utf8.DecodeRuneInString(line[start:])
+		class := classifyRune(char)
+		end := start + width
+
+		if class != rcOther {
+			for end < len(line) {
+				nextChar, nextWidth := utf8.DecodeRuneInString(line[end:])
+				if classifyRune(nextChar) != class {
+					break
+				}
+
+				end += nextWidth
+			}
+		}
+
+		tokens = append(tokens, wordDiffToken{text: line[start:end], start: start})
+		start = end
+	}
+
+	return
+}
+
+// WordDiff determines the spans of text which differ between a removed and an added line.
+// The lines are compared token by token using their longest common subsequence.
+// If the lines are too long to compare or have too little in common then paired is false
+func WordDiff(removed, added string) (removedSpans, addedSpans []wordDiffSpan, paired bool) {
+	removedTokens := tokenizeWords(removed)
+	addedTokens := tokenizeWords(added)
+	rows := len(removedTokens)
+	cols := len(addedTokens)
+
+	if rows == 0 || cols == 0 || rows*cols > wdMaxTokenProduct {
+		return
+	}
+
+	lcs := make([][]int, rows+1)
+	for row := range lcs {
+		lcs[row] = make([]int, cols+1)
+	}
+
+	for row := rows - 1; row >= 0; row-- {
+		for col := cols - 1; col >= 0; col-- {
+			if removedTokens[row].text == addedTokens[col].text {
+				lcs[row][col] = lcs[row+1][col+1] + 1
+			} else if lcs[row+1][col] >= lcs[row][col+1] {
+				lcs[row][col] = lcs[row+1][col]
+			} else {
+				lcs[row][col] = lcs[row][col+1]
+			}
+		}
+	}
+
+	removedChanged := make([]bool, rows)
+	addedChanged := make([]bool, cols)
+	unchangedBytes := 0
+	row, col := 0, 0
+
+	for row < rows || col < cols {
+		switch {
+		case row < rows && col < cols && removedTokens[row].text == addedTokens[col].text:
+			unchangedBytes += len(removedTokens[row].text)
+			row++
+			col++
+		case col < cols && (row == rows || lcs[row][col+1] >= lcs[row+1][col]):
+			addedChanged[col] = true
+			col++
+		default:
+			removedChanged[row] = true
+			row++
+		}
+	}
+
+	if float64(unchangedBytes*2) < wdMinSimilarity*float64(len(removed)+len(added)) {
+		return
+	}
+
+	return changedSpans(removedTokens, removedChanged), changedSpans(addedTokens, addedChanged), true
+}
+
+// changedSpans merges changed tokens into spans. Changed tokens separated
+// only by unchanged whitespace are merged into a single span
+func changedSpans(tokens []wordDiffToken, changed []bool) (spans []wordDiffSpan) {
+	whitespaceOnlyGap := false
+
+	for index, token := range tokens {
+		if !changed[index] {
+			char, _ := utf8.DecodeRuneInString(token.text)
+			whitespaceOnlyGap = whitespaceOnlyGap && classifyRune(char) == rcSpace
+			continue
+		}
+
+		end := token.start + len(token.text)
+
+		if spanNum := len(spans); spanNum > 0 && whitespaceOnlyGap {
+			spans[spanNum-1].end = end
+		} else {
+			spans = append(spans, wordDiffSpan{start: token.start, end: end})
+		}
+
+		whitespaceOnlyGap = true
+	}
+
+	return
+}
+
+// annotateWordDiffs pairs each block of removed lines with the block of added lines
+// immediately following it and stores the changed spans of each paired line.
+// Lines are paired by position within their blocks. Lines without a counterpart
+// or which differ too much from it are left without spans
+func annotateWordDiffs(lines []*diffLineData) {
+	for lineIndex := 0; lineIndex < len(lines); {
+		removedStart := lineIndex
+
+		for lineIndex < len(lines) && lines[lineIndex].diffLineType() == dltLineRemoved {
+			lineIndex++
+		}
+
+		addedStart := lineIndex
+
+		for lineIndex < len(lines) && lines[lineIndex].diffLineType() == dltLineAdded {
+			lineIndex++
+		}
+
+		if removedStart == lineIndex {
+			lineIndex++
+			continue
+		}
+
+		removedLines := lines[removedStart:addedStart]
+		addedLines := lines[addedStart:lineIndex]
+
+		for pairIndex := 0; pairIndex < len(removedLines) && pairIndex < len(addedLines); pairIndex++ {
+			removedLine := removedLines[pairIndex]
+			addedLine := addedLines[pairIndex]
+
+			removedSpans, addedSpans, paired := WordDiff(removedLine.line[1:], addedLine.line[1:])
+			if paired {
+				removedLine.changedSpans = offsetSpans(removedSpans, 1)
+				addedLine.changedSpans = offsetSpans(addedSpans, 1)
+			}
+		}
+	}
+}
+
+func offsetSpans(spans []wordDiffSpan, offset int) []wordDiffSpan {
+	for index := range spans {
+		spans[index].start += offset
+		spans[index].end += offset
+	}
+
+	return spans
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	var wordDiffTests = []struct {
+		removed              string
+		added                string
+		expectedRemovedSpans []wordDiffSpan
+		expectedAddedSpans   []wordDiffSpan
+		expectedPaired       bool
+	}{
+		{
+			removed:              "return foo(bar)",
+			added:                "return foo(baz)",
+			expectedRemovedSpans: []wordDiffSpan{{start: 11, end: 14}},
+			expectedAddedSpans:   []wordDiffSpan{{start: 11, end: 14}},
+			expectedPaired:       true,
+		},
+		{
+			removed:              "a := 1",
+			added:                "a, b := 1, 2",
+			expectedRemovedSpans: nil,
+			expectedAddedSpans:   []wordDiffSpan{{start: 1, end: 5}, {start: 9, end: 12}},
+			expectedPaired:       true,
+		},
+		{
+			removed:        "completely different",
+			added:          "nothing alike here",
+			expectedPaired: false,
+		},
+		{
+			removed:        "",
+			added:          "added",
+			expectedPaired: false,
+		},
+	}
+
+	for _, wordDiffTest := range wordDiffTests {
+		removedSpans, addedSpans, paired := WordDiff(wordDiffTest.removed, wordDiffTest.added)
+
+		if paired != wordDiffTest.expectedPaired {
+			t.Errorf("WordDiff(%q, %q) paired does not match expected value. Expected: %v, Actual: %v",
+				wordDiffTest.removed, wordDiffTest.added, wordDiffTest.expectedPaired, paired)
+		} else if paired && (!reflect.DeepEqual(removedSpans, wordDiffTest.expectedRemovedSpans) ||
+			!reflect.DeepEqual(addedSpans, wordDiffTest.expectedAddedSpans)) {
+			t.Errorf("WordDiff(%q, %q) spans do not match expected value. Expected: %v %v, Actual: %v %v",
+				wordDiffTest.removed, wordDiffTest.added, wordDiffTest.expectedRemovedSpans,
+				wordDiffTest.expectedAddedSpans, removedSpans, addedSpans)
+		}
+	}
+}
+
+func TestAnnotateWordDiffsOnlyPairsRemovedLinesWithFollowingAddedLines(t *testing.T) {
+	lines := []*diffLineData{
+		{line: "@@ -1,4 +1,3 @@"},
+		{line: "-value := compute(a)"},
+		{line: "-unpaired removed line"},
+		{line: "+value := compute(b)"},
+		{line: " context"},
+		{line: "+added without removal"},
+	}
+
+	annotateWordDiffs(lines)
+
+	if expectedSpans := []wordDiffSpan{{start: 18, end: 19}}; !reflect.DeepEqual(lines[1].changedSpans, expectedSpans) {
+		t.Errorf("Removed line spans do not match expected value. Expected: %v, Actual: %v", expectedSpans, lines[1].changedSpans)
+	}
+
+	if expectedSpans := []wordDiffSpan{{start: 18, end: 19}}; !reflect.DeepEqual(lines[3].changedSpans, expectedSpans) {
+		t.Errorf("Added line spans do not match expected value. Expected: %v, Actual: %v", expectedSpans, lines[3].changedSpans)
+	}
+
+	for _, lineIndex := range []int{0, 2, 4, 5} {
+		if len(lines[lineIndex].changedSpans) > 0 {
+			t.Errorf("Expected line %q to have no changed spans but found %v", lines[lineIndex].line, lines[lineIndex].changedSpans)
+		}
+	}
+}